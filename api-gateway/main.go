@@ -1,24 +1,45 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	pb "shoeshop/proto"
 )
 
 type APIGateway struct {
-	userClient    pb.UserServiceClient
-	productClient pb.ProductServiceClient
-	orderClient   pb.OrderServiceClient
-	emailClient   pb.EmailServiceClient
+	userClient     pb.UserServiceClient
+	productClient  pb.ProductServiceClient
+	orderClient    pb.OrderServiceClient
+	emailClient    pb.EmailServiceClient
+	reviewClient   pb.ReviewServiceClient
+	wishlistClient pb.WishlistServiceClient
+	// Key of the HMAC-SHA256 signature of carrier webhooks
+	carrierSecret []byte
+	// Accept unsigned carrier webhooks when no secret is set; meant only for
+	// running the carrier stub locally
+	allowUnsignedCarrier bool
 }
 
 func NewAPIGateway() (*APIGateway, error) {
@@ -46,11 +67,28 @@ func NewAPIGateway() (*APIGateway, error) {
 		return nil, err
 	}
 
+	// Connect to Review Service
+	reviewConn, err := grpc.Dial("localhost:50055", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to Wishlist Service
+	wishlistConn, err := grpc.Dial("localhost:50056", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
 	return &APIGateway{
-		userClient:    pb.NewUserServiceClient(userConn),
-		productClient: pb.NewProductServiceClient(productConn),
-		orderClient:   pb.NewOrderServiceClient(orderConn),
-		emailClient:   pb.NewEmailServiceClient(emailConn),
+		userClient:     pb.NewUserServiceClient(userConn),
+		productClient:  pb.NewProductServiceClient(productConn),
+		orderClient:    pb.NewOrderServiceClient(orderConn),
+		emailClient:    pb.NewEmailServiceClient(emailConn),
+		reviewClient:   pb.NewReviewServiceClient(reviewConn),
+		wishlistClient: pb.NewWishlistServiceClient(wishlistConn),
+		carrierSecret:  []byte(os.Getenv("CARRIER_WEBHOOK_SECRET")),
+
+		allowUnsignedCarrier: os.Getenv("CARRIER_WEBHOOK_ALLOW_UNSIGNED") == "true",
 	}, nil
 }
 
@@ -60,13 +98,22 @@ func main() {
 		log.Fatalf("Failed to create API Gateway: %v", err)
 	}
 
+	if len(gateway.carrierSecret) == 0 {
+		if gateway.allowUnsignedCarrier {
+			log.Println("CARRIER_WEBHOOK_SECRET is not set, carrier webhooks are accepted unsigned")
+		} else {
+			log.Println("CARRIER_WEBHOOK_SECRET is not set, carrier webhooks are rejected")
+		}
+	}
+
 	r := gin.Default()
 
 	// Add CORS middleware
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"http://localhost:3000"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match"}
+	config.ExposeHeaders = []string{"ETag"}
 	r.Use(cors.New(config))
 
 	// API routes group
@@ -77,19 +124,92 @@ func main() {
 		api.POST("/users/login", gateway.login)
 		api.GET("/users/profile", gateway.getUser)
 		api.PUT("/users/profile/update", gateway.updateUser)
+		api.PATCH("/users/profile", gateway.patchUser)
 
 		// Product routes
 		api.POST("/products", gateway.createProduct)
 		api.GET("/products/:id", gateway.getProduct)
 		api.PUT("/products/:id", gateway.updateProduct)
+		api.PATCH("/products/:id", gateway.patchProduct)
 		api.DELETE("/products/:id", gateway.deleteProduct)
 		api.GET("/products", gateway.listProducts)
+		api.GET("/products/autocomplete", gateway.autocompleteProducts)
+		api.POST("/products/import", gateway.importProducts)
+		api.GET("/products/export", gateway.exportProducts)
+		api.POST("/products/:id/images", gateway.uploadProductImage)
+		api.PUT("/products/:id/images/:imageId", gateway.updateProductImage)
+		api.DELETE("/products/:id/images/:imageId", gateway.deleteProductImage)
+		api.GET("/products/:id/price-history", gateway.getPriceHistory)
+		api.GET("/products/:id/recommendations", gateway.getProductRecommendations)
+		api.GET("/products/:id/scheduled-prices", gateway.listScheduledPrices)
+		api.POST("/products/:id/scheduled-prices", gateway.schedulePriceChange)
+		api.DELETE("/products/:id/scheduled-prices/:scheduleId", gateway.cancelScheduledPrice)
+
+		// Category routes
+		api.GET("/categories", gateway.listCategories)
+		api.GET("/categories/:id", gateway.getCategory)
+
+		// Brand routes
+		api.GET("/brands", gateway.listBrands)
+		api.GET("/brands/:id", gateway.getBrand)
+
+		// Review routes
+		api.GET("/products/:id/reviews", gateway.listProductReviews)
+		api.POST("/products/:id/reviews", gateway.createReview)
+		api.GET("/products/:id/size-recommendation", gateway.recommendSize)
+
+		// Back in stock subscriptions
+		api.POST("/products/:id/stock-subscriptions", gateway.subscribeBackInStock)
+		api.GET("/stock-subscriptions", gateway.listStockSubscriptions)
+		api.DELETE("/stock-subscriptions/:id", gateway.unsubscribeBackInStock)
 
 		// Order routes
 		api.POST("/orders", gateway.createOrder)
 		api.GET("/orders/:id", gateway.getOrder)
+		api.PATCH("/orders/:id", gateway.patchOrder)
 		api.GET("/orders/user/:userId", gateway.listUserOrders)
 		api.PUT("/orders/:id/status", gateway.updateOrderStatus)
+		api.POST("/orders/:id/returns", gateway.returnOrderItem)
+		api.POST("/orders/:id/shipments", gateway.createShipment)
+		api.GET("/orders/:id/invoice", gateway.getInvoice)
+
+		// Carrier tracking webhooks
+		api.POST("/webhooks/carriers/:carrier", gateway.carrierWebhook)
+
+		// Cart routes
+		api.POST("/cart/quote", gateway.quoteCart)
+		api.POST("/cart/recommendations", gateway.getCartRecommendations)
+		api.POST("/cart/shipping", gateway.quoteShipping)
+
+		// Wishlist routes
+		api.GET("/wishlist", gateway.getWishlist)
+		api.POST("/wishlist/items", gateway.addWishlistItem)
+		api.DELETE("/wishlist/items/:productId", gateway.removeWishlistItem)
+		api.POST("/wishlist/items/:productId/move-to-cart", gateway.moveWishlistItemToCart)
+		api.POST("/wishlist/share", gateway.shareWishlist)
+		api.GET("/wishlist/shared/:token", gateway.getSharedWishlist)
+
+		// Admin routes
+		api.GET("/admin/cache/stats", gateway.getCacheStats)
+		api.GET("/admin/products/archived", gateway.listArchivedProducts)
+		api.GET("/admin/products/reorder-report", gateway.getReorderReport)
+		api.POST("/admin/products/:id/restore", gateway.restoreProduct)
+		api.GET("/admin/reviews", gateway.listReviewsForModeration)
+		api.PUT("/admin/reviews/:id/moderate", gateway.moderateReview)
+		api.GET("/admin/promotions", gateway.listPromotions)
+		api.POST("/admin/promotions", gateway.createPromotion)
+		api.PUT("/admin/promotions/:id", gateway.updatePromotion)
+		api.DELETE("/admin/promotions/:id", gateway.deletePromotion)
+		api.GET("/admin/coupons", gateway.listCoupons)
+		api.POST("/admin/coupons", gateway.createCoupon)
+		api.PUT("/admin/coupons/:code", gateway.updateCoupon)
+		api.GET("/admin/coupons/:code/report", gateway.getCouponReport)
+		api.POST("/admin/categories", gateway.createCategory)
+		api.PUT("/admin/categories/:id", gateway.updateCategory)
+		api.DELETE("/admin/categories/:id", gateway.deleteCategory)
+		api.POST("/admin/brands", gateway.createBrand)
+		api.PUT("/admin/brands/:id", gateway.updateBrand)
+		api.DELETE("/admin/brands/:id", gateway.deleteBrand)
 	}
 
 	log.Fatal(r.Run(":8080"))
@@ -163,6 +283,7 @@ func (g *APIGateway) getUser(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", etag(user.User.GetVersion()))
 	c.JSON(http.StatusOK, user)
 }
 
@@ -180,36 +301,52 @@ func (g *APIGateway) updateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "User data is required"})
 		return
 	}
+	if !applyIfMatch(c, &req.User.Version) {
+		return
+	}
 
-	// Получаем текущего пользователя
-	currentUser, err := g.userClient.GetUserByEmail(context.Background(), &pb.GetUserByEmailRequest{
-		Email: req.User.Email,
+	// Меняем только поля профиля, остальные поля пользователя сохраняются
+	resp, err := g.userClient.UpdateUser(context.Background(), &pb.UpdateUserRequest{
+		User:       req.User,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: profileFields},
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get current user: " + err.Error()})
+		c.JSON(versionStatus(c, err), gin.H{"error": "Failed to update user: " + err.Error()})
 		return
 	}
 
-	// Сохраняем неизменяемые поля
-	req.User.Id = currentUser.User.Id
-	req.User.CreatedAt = currentUser.User.CreatedAt
-	req.User.PasswordHash = currentUser.User.PasswordHash
-	req.User.IsAdmin = currentUser.User.IsAdmin
-	req.User.OrderIds = currentUser.User.OrderIds
-	req.User.Balance = currentUser.User.Balance
+	c.Header("ETag", etag(resp.User.GetVersion()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// profileFields are the user fields a profile update may change
+var profileFields = []string{"username", "firstName", "lastName", "shippingAddress", "phone"}
+
+// patchUser applies a JSON Merge Patch to the profile of the user given by email
+func (g *APIGateway) patchUser(c *gin.Context) {
+	email := strings.ToLower(c.Query("email"))
+	if email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email parameter is required"})
+		return
+	}
 
-	// Обновляем время изменения
-	req.User.UpdatedAt = time.Now().Format(time.RFC3339)
+	var user pb.User
+	paths, ok := bindMergePatch(c, &user)
+	if !ok || !applyIfMatch(c, &user.Version) {
+		return
+	}
+	user.Email = email
 
-	// Отправляем запрос на обновление
 	resp, err := g.userClient.UpdateUser(context.Background(), &pb.UpdateUserRequest{
-		User: req.User,
+		User:       &user,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user: " + err.Error()})
+		c.JSON(versionStatus(c, err), gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(resp.User.GetVersion()))
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -242,7 +379,7 @@ func (g *APIGateway) createProduct(c *gin.Context) {
 
 	resp, err := g.productClient.CreateProduct(context.Background(), &pb.CreateProductRequest{Product: &product})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -251,12 +388,17 @@ func (g *APIGateway) createProduct(c *gin.Context) {
 
 func (g *APIGateway) getProduct(c *gin.Context) {
 	id := c.Param("id")
-	resp, err := g.productClient.GetProduct(context.Background(), &pb.GetProductRequest{Id: id})
+	resp, err := g.productClient.GetProduct(context.Background(), &pb.GetProductRequest{
+		Id:         id,
+		SizeSystem: c.Query("size_system"),
+		Locale:     c.Query("locale"),
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(resp.Product.GetVersion()))
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -266,13 +408,40 @@ func (g *APIGateway) updateProduct(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !applyIfMatch(c, &product.Version) {
+		return
+	}
 
 	resp, err := g.productClient.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Product: &product})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(versionStatus(c, err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(resp.Product.GetVersion()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// patchProduct applies a JSON Merge Patch: only the fields present in the
+// body change, and a null resets a field
+func (g *APIGateway) patchProduct(c *gin.Context) {
+	var product pb.Product
+	paths, ok := bindMergePatch(c, &product)
+	if !ok || !applyIfMatch(c, &product.Version) {
+		return
+	}
+	product.Id = c.Param("id")
+
+	resp, err := g.productClient.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+		Product:    &product,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		c.JSON(versionStatus(c, err), gin.H{"error": err.Error()})
 		return
 	}
 
+	c.Header("ETag", etag(resp.Product.GetVersion()))
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -280,7 +449,57 @@ func (g *APIGateway) deleteProduct(c *gin.Context) {
 	id := c.Param("id")
 	resp, err := g.productClient.DeleteProduct(context.Background(), &pb.DeleteProductRequest{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) restoreProduct(c *gin.Context) {
+	resp, err := g.productClient.RestoreProduct(context.Background(), &pb.RestoreProductRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(resp.GetProduct().GetVersion()))
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) getReorderReport(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+		return
+	}
+	// Без cover_days заказ покрывает столько же дней, сколько окно продаж
+	coverDays, err := strconv.Atoi(c.DefaultQuery("cover_days", strconv.Itoa(days)))
+	if err != nil || coverDays < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cover_days must be a positive integer"})
+		return
+	}
+
+	resp, err := g.productClient.GetReorderReport(context.Background(), &pb.ReorderReportRequest{
+		Days:      int32(days),
+		CoverDays: int32(coverDays),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) listArchivedProducts(c *gin.Context) {
+	resp, err := g.productClient.ListArchivedProducts(context.Background(), &pb.ListArchivedProductsRequest{
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -292,8 +511,10 @@ func (g *APIGateway) listProducts(c *gin.Context) {
 	brand := c.Query("brand")
 
 	resp, err := g.productClient.ListProducts(context.Background(), &pb.ListProductsRequest{
-		Category: category,
-		Brand:    brand,
+		Category:   category,
+		Brand:      brand,
+		SizeSystem: c.Query("size_system"),
+		Locale:     c.Query("locale"),
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -303,84 +524,1395 @@ func (g *APIGateway) listProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-// Order handlers
-func (g *APIGateway) createOrder(c *gin.Context) {
-	var order pb.Order
-	if err := c.ShouldBindJSON(&order); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+func (g *APIGateway) autocompleteProducts(c *gin.Context) {
+	prefix := c.Query("q")
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q parameter is required"})
 		return
 	}
 
-	resp, err := g.orderClient.CreateOrder(context.Background(), &pb.CreateOrderRequest{Order: &order})
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a number"})
 		return
 	}
 
-	// Send order confirmation email
-	_, err = g.userClient.GetUser(context.Background(), &pb.GetUserRequest{Id: order.UserId})
+	resp, err := g.productClient.Autocomplete(context.Background(), &pb.AutocompleteRequest{
+		Prefix: prefix,
+		Limit:  int32(limit),
+	})
 	if err != nil {
-		log.Printf("Failed to get user for order confirmation: %v", err)
-	} else {
-		err = g.sendOrderEmail(resp.Order)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// importChunkSize is the size of a file chunk sent in one import stream message
+const importChunkSize = 32 * 1024
+
+// importProducts accepts either a multipart upload in the "file" field or a raw
+// request body and streams it to product-service without buffering the whole file
+func (g *APIGateway) importProducts(c *gin.Context) {
+	var body io.Reader = c.Request.Body
+	format := c.Query("format")
+
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
 		if err != nil {
-			log.Printf("Failed to send order confirmation email: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+
+		body = f
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(file.Filename), ".")
+		}
+	}
+	if format == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format parameter is required"})
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be a boolean"})
+		return
+	}
+
+	// Streams use the request context so an aborted upload stops the import
+	stream, err := g.productClient.ImportProducts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	buf := make([]byte, importChunkSize)
+	first := true
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 || first {
+			req := &pb.ImportProductsRequest{Data: buf[:n]}
+			if first {
+				req.Format = format
+				req.DryRun = dryRun
+				req.SizeSystem = c.Query("size_system")
+				first = false
+			}
+			// On a send error the real status is returned by CloseAndRecv
+			if err := stream.Send(req); err != nil {
+				break
+			}
+		}
+		if readErr == io.EOF {
+			break
 		}
+		if readErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": readErr.Error()})
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
-func (g *APIGateway) getOrder(c *gin.Context) {
-	id := c.Param("id")
-	resp, err := g.orderClient.GetOrder(context.Background(), &pb.GetOrderRequest{Id: id})
+func (g *APIGateway) exportProducts(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+
+	stream, err := g.productClient.ExportProducts(c.Request.Context(), &pb.ExportProductsRequest{
+		Format:     format,
+		Category:   c.Query("category"),
+		Brand:      c.Query("brand"),
+		SizeSystem: c.Query("size_system"),
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Errors such as an unknown format arrive with the first message,
+	// so read it before committing to a file response
+	chunk, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format != "csv" {
+		contentType = "application/x-ndjson"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	c.Status(http.StatusOK)
+
+	for err == nil {
+		if _, writeErr := c.Writer.Write(chunk.GetData()); writeErr != nil {
+			return
+		}
+		c.Writer.Flush()
+		chunk, err = stream.Recv()
+	}
+	if err != io.EOF {
+		// Headers are already sent; all we can do is cut the download short
+		log.Printf("failed to export products: %v", err)
+		c.Abort()
+	}
+}
+
+// maxImageUploadSize caps the whole multipart request; product-service
+// enforces the exact limit on the image itself
+const maxImageUploadSize = 11 << 20
+
+func (g *APIGateway) uploadProductImage(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImageUploadSize)
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "image is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image file is required"})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	stream, err := g.productClient.UploadProductImage(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	buf := make([]byte, importChunkSize)
+	first := true
+	for {
+		n, readErr := f.Read(buf)
+		if n > 0 || first {
+			req := &pb.UploadProductImageRequest{Data: buf[:n]}
+			if first {
+				req.ProductId = c.Param("id")
+				req.AltText = c.PostForm("alt")
+				first = false
+			}
+			// On a send error the real status is returned by CloseAndRecv
+			if err := stream.Send(req); err != nil {
+				break
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": readErr.Error()})
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (g *APIGateway) updateProductImage(c *gin.Context) {
+	var req struct {
+		AltText   string `json:"alt_text"`
+		SortOrder int32  `json:"sort_order"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.productClient.UpdateProductImage(context.Background(), &pb.UpdateProductImageRequest{
+		ProductId: c.Param("id"),
+		ImageId:   c.Param("imageId"),
+		AltText:   req.AltText,
+		SortOrder: req.SortOrder,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
-func (g *APIGateway) listUserOrders(c *gin.Context) {
-	userID := c.Param("userId")
-	
-	resp, err := g.orderClient.ListOrders(context.Background(), &pb.ListOrdersRequest{
-		UserId: userID,
+func (g *APIGateway) deleteProductImage(c *gin.Context) {
+	resp, err := g.productClient.DeleteProductImage(context.Background(), &pb.DeleteProductImageRequest{
+		ProductId: c.Param("id"),
+		ImageId:   c.Param("imageId"),
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
-func (g *APIGateway) updateOrderStatus(c *gin.Context) {
+// getPriceHistory returns recent price changes and the lowest price over
+// the last `days` days (30 by default)
+func (g *APIGateway) getPriceHistory(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return
+	}
+
+	resp, err := g.productClient.GetPriceHistory(context.Background(), &pb.GetPriceHistoryRequest{
+		ProductId: c.Param("id"),
+		Days:      int32(days),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getProductRecommendations returns "customers also bought" for a product
+// page; with user_id the user's past purchases are left out
+func (g *APIGateway) getProductRecommendations(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return
+	}
+
+	resp, err := g.productClient.GetRecommendations(context.Background(), &pb.RecommendationsRequest{
+		ProductId:  c.Param("id"),
+		UserId:     c.Query("user_id"),
+		Limit:      int32(limit),
+		SizeSystem: c.Query("size_system"),
+		Locale:     c.Query("locale"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) listScheduledPrices(c *gin.Context) {
+	resp, err := g.productClient.ListScheduledPrices(context.Background(), &pb.ListScheduledPricesRequest{
+		ProductId: c.Param("id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// schedulePriceChange sets a future price; effective_at is RFC3339
+func (g *APIGateway) schedulePriceChange(c *gin.Context) {
 	var req struct {
-		Status string `json:"status"`
+		Price       float64 `json:"price"`
+		EffectiveAt string  `json:"effective_at"`
 	}
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	orderID := c.Param("id")
-	_, err := g.orderClient.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{
-		Id:     orderID,
-		Status: req.Status,
+	resp, err := g.productClient.SchedulePriceChange(context.Background(), &pb.SchedulePriceChangeRequest{
+		ProductId:   c.Param("id"),
+		Price:       req.Price,
+		EffectiveAt: req.EffectiveAt,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Order status updated successfully"})
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (g *APIGateway) cancelScheduledPrice(c *gin.Context) {
+	resp, err := g.productClient.CancelScheduledPrice(context.Background(), &pb.CancelScheduledPriceRequest{
+		Id:        c.Param("scheduleId"),
+		ProductId: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Category handlers
+func (g *APIGateway) listCategories(c *gin.Context) {
+	resp, err := g.productClient.ListCategories(context.Background(), &pb.ListCategoriesRequest{
+		Locale: c.Query("locale"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getCategory accepts either a category id or its slug
+func (g *APIGateway) getCategory(c *gin.Context) {
+	resp, err := g.productClient.GetCategory(context.Background(), &pb.GetCategoryRequest{
+		Id:     c.Param("id"),
+		Locale: c.Query("locale"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) createCategory(c *gin.Context) {
+	var category pb.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.productClient.CreateCategory(context.Background(), &pb.CreateCategoryRequest{
+		Category: &category,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (g *APIGateway) updateCategory(c *gin.Context) {
+	var category pb.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	category.Id = c.Param("id")
+
+	resp, err := g.productClient.UpdateCategory(context.Background(), &pb.UpdateCategoryRequest{
+		Category: &category,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) deleteCategory(c *gin.Context) {
+	resp, err := g.productClient.DeleteCategory(context.Background(), &pb.DeleteCategoryRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Brand handlers
+func (g *APIGateway) listBrands(c *gin.Context) {
+	resp, err := g.productClient.ListBrands(context.Background(), &pb.ListBrandsRequest{})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getBrand accepts either a brand id or its slug and returns the brand
+// together with its size chart
+func (g *APIGateway) getBrand(c *gin.Context) {
+	resp, err := g.productClient.GetBrand(context.Background(), &pb.GetBrandRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) createBrand(c *gin.Context) {
+	var brand pb.Brand
+	if err := c.ShouldBindJSON(&brand); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.productClient.CreateBrand(context.Background(), &pb.CreateBrandRequest{
+		Brand: &brand,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (g *APIGateway) updateBrand(c *gin.Context) {
+	var brand pb.Brand
+	if err := c.ShouldBindJSON(&brand); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	brand.Id = c.Param("id")
+
+	resp, err := g.productClient.UpdateBrand(context.Background(), &pb.UpdateBrandRequest{
+		Brand: &brand,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) deleteBrand(c *gin.Context) {
+	resp, err := g.productClient.DeleteBrand(context.Background(), &pb.DeleteBrandRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// httpStatus maps gRPC status codes that callers can act on to HTTP statuses
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// etag renders a document version as a strong entity tag
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// applyIfMatch copies the version from an If-Match header into version,
+// overriding the one in the body. Without the header, or with "*", the body
+// decides. Updates are always conditional: writes a 428 and returns false if
+// neither carries a version, and a 400 if the header is not a version tag
+func applyIfMatch(c *gin.Context, version *int64) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		if *version <= 0 {
+			c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header or version is required"})
+			return false
+		}
+		return true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	parsed, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || parsed <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid If-Match header %q", header)})
+		return false
+	}
+	*version = parsed
+	return true
+}
+
+// bindMergePatch decodes a JSON Merge Patch (RFC 7396) into msg and returns
+// the patched top-level fields as update mask paths. A null member resets
+// the field to its zero value. Arrays are replaced as a whole, as the RFC
+// requires. id and version identify the document and are not patched.
+// Writes a 400 and returns false if the body is not a non-empty JSON object
+func bindMergePatch(c *gin.Context, msg interface{}) ([]string, bool) {
+	var patch map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	paths := make([]string, 0, len(patch))
+	for field, value := range patch {
+		if string(bytes.TrimSpace(value)) == "null" {
+			delete(patch, field)
+		}
+		if field != "id" && field != "version" {
+			paths = append(paths, field)
+		}
+	}
+	if len(paths) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "patch changes no fields"})
+		return nil, false
+	}
+	sort.Strings(paths)
+
+	data, err := json.Marshal(patch)
+	if err == nil {
+		err = json.Unmarshal(data, msg)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return paths, true
+}
+
+// versionStatus answers a version mismatch with 412 when the client sent
+// If-Match and maps other errors like httpStatus
+func versionStatus(c *gin.Context, err error) int {
+	if status.Code(err) == codes.Aborted && c.GetHeader("If-Match") != "" {
+		return http.StatusPreconditionFailed
+	}
+	return httpStatus(err)
+}
+
+// Admin handlers
+func (g *APIGateway) getCacheStats(c *gin.Context) {
+	resp, err := g.productClient.GetCacheStats(context.Background(), &pb.GetCacheStatsRequest{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Review handlers
+func (g *APIGateway) listProductReviews(c *gin.Context) {
+	limit, offset, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	// Only approved reviews are public
+	resp, err := g.reviewClient.ListReviews(context.Background(), &pb.ListReviewsRequest{
+		ProductId:  c.Param("id"),
+		Status:     "APPROVED",
+		Limit:      limit,
+		Offset:     offset,
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) createReview(c *gin.Context) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		Rating     int32  `json:"rating"`
+		Text       string `json:"text"`
+		Size       string `json:"size"`
+		SizeSystem string `json:"size_system"`
+		Fit        string `json:"fit"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.reviewClient.CreateReview(context.Background(), &pb.CreateReviewRequest{
+		Review: &pb.Review{
+			ProductId:  c.Param("id"),
+			UserId:     req.UserID,
+			Rating:     req.Rating,
+			Text:       req.Text,
+			Size:       req.Size,
+			SizeSystem: req.SizeSystem,
+			Fit:        strings.ToUpper(req.Fit),
+		},
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Review)
+}
+
+func (g *APIGateway) subscribeBackInStock(c *gin.Context) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		Size       string `json:"size"`
+		SizeSystem string `json:"size_system"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	// Письмо уходит на адрес из профиля пользователя
+	user, err := g.userClient.GetUser(context.Background(), &pb.GetUserRequest{Id: req.UserID})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.productClient.SubscribeBackInStock(context.Background(), &pb.SubscribeBackInStockRequest{
+		UserId:     req.UserID,
+		Email:      user.GetUser().GetEmail(),
+		ProductId:  c.Param("id"),
+		Size:       req.Size,
+		SizeSystem: req.SizeSystem,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (g *APIGateway) listStockSubscriptions(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	resp, err := g.productClient.ListStockSubscriptions(context.Background(), &pb.ListStockSubscriptionsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) unsubscribeBackInStock(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	resp, err := g.productClient.UnsubscribeBackInStock(context.Background(), &pb.UnsubscribeBackInStockRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) recommendSize(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	resp, err := g.reviewClient.RecommendSize(context.Background(), &pb.RecommendSizeRequest{
+		UserId:     userID,
+		ProductId:  c.Param("id"),
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) listReviewsForModeration(c *gin.Context) {
+	limit, offset, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	resp, err := g.reviewClient.ListReviews(context.Background(), &pb.ListReviewsRequest{
+		ProductId:  c.Query("product_id"),
+		UserId:     c.Query("user_id"),
+		Status:     c.DefaultQuery("status", "PENDING"),
+		Limit:      limit,
+		Offset:     offset,
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) moderateReview(c *gin.Context) {
+	var req struct {
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.reviewClient.ModerateReview(context.Background(), &pb.ModerateReviewRequest{
+		Id:     c.Param("id"),
+		Status: strings.ToUpper(req.Status),
+		Note:   req.Note,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Review)
+}
+
+// pagination reads limit and offset query parameters, answering 400 on bad input
+func pagination(c *gin.Context) (limit, offset int32, ok bool) {
+	limitValue, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limitValue < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return 0, 0, false
+	}
+	offsetValue, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offsetValue < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+		return 0, 0, false
+	}
+	return int32(limitValue), int32(offsetValue), true
+}
+
+// Order handlers
+func (g *APIGateway) createOrder(c *gin.Context) {
+	var order pb.Order
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.CreateOrder(context.Background(), &pb.CreateOrderRequest{Order: &order})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// Send order confirmation email
+	user, err := g.userClient.GetUser(context.Background(), &pb.GetUserRequest{Id: order.UserId})
+	if err != nil {
+		log.Printf("Failed to get user for order confirmation: %v", err)
+	} else {
+		err = g.sendOrderEmail(resp.Order, user.GetUser().GetEmail())
+		if err != nil {
+			log.Printf("Failed to send order confirmation email: %v", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) getOrder(c *gin.Context) {
+	id := c.Param("id")
+	resp, err := g.orderClient.GetOrder(context.Background(), &pb.GetOrderRequest{
+		Id:         id,
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(resp.Order.GetVersion()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// patchOrder applies a JSON Merge Patch to an order
+func (g *APIGateway) patchOrder(c *gin.Context) {
+	var order pb.Order
+	paths, ok := bindMergePatch(c, &order)
+	if !ok || !applyIfMatch(c, &order.Version) {
+		return
+	}
+	order.Id = c.Param("id")
+
+	resp, err := g.orderClient.UpdateOrder(context.Background(), &pb.UpdateOrderRequest{
+		Order:      &order,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		c.JSON(versionStatus(c, err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", etag(resp.Order.GetVersion()))
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) listUserOrders(c *gin.Context) {
+	userID := c.Param("userId")
+	
+	resp, err := g.orderClient.ListOrders(context.Background(), &pb.ListOrdersRequest{
+		UserId:     userID,
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) updateOrderStatus(c *gin.Context) {
+	var req struct {
+		Status  string `json:"status"`
+		Version int64  `json:"version"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !applyIfMatch(c, &req.Version) {
+		return
+	}
+
+	orderID := c.Param("id")
+	_, err := g.orderClient.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{
+		Id:      orderID,
+		Status:  req.Status,
+		Version: req.Version,
+	})
+	if err != nil {
+		c.JSON(versionStatus(c, err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Order status updated successfully"})
+}
+
+func (g *APIGateway) returnOrderItem(c *gin.Context) {
+	var req struct {
+		ProductID  string `json:"product_id"`
+		Size       string `json:"size"`
+		SizeSystem string `json:"size_system"`
+		Reason     string `json:"reason"`
+		Fit        string `json:"fit"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.ReturnOrderItem(context.Background(), &pb.ReturnOrderItemRequest{
+		OrderId:    c.Param("id"),
+		ProductId:  req.ProductID,
+		Size:       req.Size,
+		SizeSystem: req.SizeSystem,
+		Reason:     req.Reason,
+		Fit:        strings.ToUpper(req.Fit),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Order)
+}
+
+// createShipment ships order items in one package with a carrier tracking number
+func (g *APIGateway) createShipment(c *gin.Context) {
+	var req struct {
+		Carrier        string             `json:"carrier"`
+		TrackingNumber string             `json:"tracking_number"`
+		Items          []*pb.ShipmentItem `json:"items"`
+		SizeSystem     string             `json:"size_system"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.CreateShipment(context.Background(), &pb.CreateShipmentRequest{
+		OrderId:        c.Param("id"),
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+		Items:          req.Items,
+		SizeSystem:     req.SizeSystem,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Order)
+}
+
+// getInvoice downloads the PDF invoice of a paid order
+func (g *APIGateway) getInvoice(c *gin.Context) {
+	resp, err := g.orderClient.GetInvoice(context.Background(), &pb.GetInvoiceRequest{
+		OrderId: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.Filename))
+	c.Data(http.StatusOK, "application/pdf", resp.Pdf)
+}
+
+// carrierWebhook records a carrier status update of a package. The body is
+// signed with CARRIER_WEBHOOK_SECRET in the X-Carrier-Signature header as
+// "sha256=" followed by the hex HMAC-SHA256 of the raw body. Without a
+// secret, webhooks are rejected unless CARRIER_WEBHOOK_ALLOW_UNSIGNED=true
+func (g *APIGateway) carrierWebhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !g.validCarrierSignature(body, c.GetHeader("X-Carrier-Signature")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	var req struct {
+		TrackingNumber string `json:"tracking_number"`
+		Status         string `json:"status"`
+		Description    string `json:"description"`
+		Location       string `json:"location"`
+		OccurredAt     string `json:"occurred_at"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.RecordShipmentEvent(context.Background(), &pb.RecordShipmentEventRequest{
+		Carrier:        c.Param("carrier"),
+		TrackingNumber: req.TrackingNumber,
+		Event: &pb.ShipmentEvent{
+			Status:      req.Status,
+			Description: req.Description,
+			Location:    req.Location,
+			OccurredAt:  req.OccurredAt,
+		},
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"order_id":     resp.Order.GetId(),
+		"order_status": resp.Order.GetStatus(),
+	})
+}
+
+func (g *APIGateway) validCarrierSignature(body []byte, header string) bool {
+	if len(g.carrierSecret) == 0 {
+		return g.allowUnsignedCarrier
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, g.carrierSecret)
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+// quoteCart prices cart items with current promotions without placing an order
+func (g *APIGateway) quoteCart(c *gin.Context) {
+	var order pb.Order
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.QuoteOrder(context.Background(), &pb.QuoteOrderRequest{Order: &order})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Order)
+}
+
+// Wishlist handlers
+func (g *APIGateway) getWishlist(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	resp, err := g.wishlistClient.GetWishlist(context.Background(), &pb.GetWishlistRequest{
+		UserId:     userID,
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Wishlist)
+}
+
+func (g *APIGateway) addWishlistItem(c *gin.Context) {
+	var req pb.AddWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.wishlistClient.AddWishlistItem(context.Background(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Wishlist)
+}
+
+func (g *APIGateway) removeWishlistItem(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+		return
+	}
+
+	resp, err := g.wishlistClient.RemoveWishlistItem(context.Background(), &pb.RemoveWishlistItemRequest{
+		UserId:     userID,
+		ProductId:  c.Param("productId"),
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Wishlist)
+}
+
+// moveWishlistItemToCart removes the item from the wishlist and returns the
+// cart line for the client cart; pass it to /cart/quote with the cart
+func (g *APIGateway) moveWishlistItemToCart(c *gin.Context) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		Size       string `json:"size"`
+		SizeSystem string `json:"size_system"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	resp, err := g.wishlistClient.MoveToCart(context.Background(), &pb.MoveToCartRequest{
+		UserId:     req.UserID,
+		ProductId:  c.Param("productId"),
+		Size:       req.Size,
+		SizeSystem: req.SizeSystem,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) shareWishlist(c *gin.Context) {
+	var req pb.ShareWishlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.wishlistClient.ShareWishlist(context.Background(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"share_token": resp.ShareToken,
+		"url":         "/api/wishlist/shared/" + resp.ShareToken,
+	})
+}
+
+func (g *APIGateway) getSharedWishlist(c *gin.Context) {
+	resp, err := g.wishlistClient.GetSharedWishlist(context.Background(), &pb.GetSharedWishlistRequest{
+		ShareToken: c.Param("token"),
+		SizeSystem: c.Query("size_system"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Wishlist)
+}
+
+// quoteShipping lists shipping methods for the cart; the body is the same
+// cart as for /cart/quote with shipping_country
+func (g *APIGateway) quoteShipping(c *gin.Context) {
+	var order pb.Order
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.QuoteShipping(context.Background(), &pb.QuoteShippingRequest{Order: &order})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// getCartRecommendations recommends products for the cart; the body is the
+// same cart as for /cart/quote. An empty cart with user_id gets
+// recommendations from the user's purchase history
+func (g *APIGateway) getCartRecommendations(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return
+	}
+
+	var cart pb.Order
+	if err := c.ShouldBindJSON(&cart); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	productIDs := make([]string, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		productIDs = append(productIDs, item.ProductId)
+	}
+
+	resp, err := g.productClient.GetRecommendations(context.Background(), &pb.RecommendationsRequest{
+		UserId:     cart.UserId,
+		ProductIds: productIDs,
+		Limit:      int32(limit),
+		SizeSystem: c.Query("size_system"),
+		Locale:     c.Query("locale"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Promotion handlers
+func (g *APIGateway) listPromotions(c *gin.Context) {
+	activeOnly, err := strconv.ParseBool(c.DefaultQuery("active", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "active must be a boolean"})
+		return
+	}
+
+	resp, err := g.orderClient.ListPromotions(context.Background(), &pb.ListPromotionsRequest{
+		ActiveOnly: activeOnly,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) createPromotion(c *gin.Context) {
+	var promotion pb.Promotion
+	if err := c.ShouldBindJSON(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.CreatePromotion(context.Background(), &pb.CreatePromotionRequest{
+		Promotion: &promotion,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Promotion)
+}
+
+func (g *APIGateway) updatePromotion(c *gin.Context) {
+	var promotion pb.Promotion
+	if err := c.ShouldBindJSON(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	promotion.Id = c.Param("id")
+
+	resp, err := g.orderClient.UpdatePromotion(context.Background(), &pb.UpdatePromotionRequest{
+		Promotion: &promotion,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Promotion)
+}
+
+func (g *APIGateway) deletePromotion(c *gin.Context) {
+	resp, err := g.orderClient.DeletePromotion(context.Background(), &pb.DeletePromotionRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Coupon handlers
+func (g *APIGateway) listCoupons(c *gin.Context) {
+	activeOnly, err := strconv.ParseBool(c.DefaultQuery("active", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "active must be a boolean"})
+		return
+	}
+
+	resp, err := g.orderClient.ListCoupons(context.Background(), &pb.ListCouponsRequest{
+		ActiveOnly: activeOnly,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (g *APIGateway) createCoupon(c *gin.Context) {
+	var coupon pb.Coupon
+	if err := c.ShouldBindJSON(&coupon); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.orderClient.CreateCoupon(context.Background(), &pb.CreateCouponRequest{
+		Coupon: &coupon,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Coupon)
+}
+
+func (g *APIGateway) updateCoupon(c *gin.Context) {
+	var coupon pb.Coupon
+	if err := c.ShouldBindJSON(&coupon); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	coupon.Code = c.Param("code")
+
+	resp, err := g.orderClient.UpdateCoupon(context.Background(), &pb.UpdateCouponRequest{
+		Coupon: &coupon,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Coupon)
+}
+
+// getCouponReport returns usage totals of a coupon and its latest redemptions
+func (g *APIGateway) getCouponReport(c *gin.Context) {
+	limit, offset, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	resp, err := g.orderClient.GetCouponReport(context.Background(), &pb.GetCouponReportRequest{
+		Code:   c.Param("code"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
-func (g *APIGateway) sendOrderEmail(order *pb.Order) error {
+func (g *APIGateway) sendOrderEmail(order *pb.Order, recipient string) error {
 	_, err := g.emailClient.SendOrderConfirmation(context.Background(), &pb.OrderEmailRequest{
-		Order: order,
+		Order:     order,
+		Recipient: recipient,
 	})
 	return err
 } 
\ No newline at end of file
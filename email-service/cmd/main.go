@@ -1,21 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	"shoeshop/email-service/internal/handler"
+	"shoeshop/email-service/internal/repository"
 	"shoeshop/email-service/internal/service"
 	pb "shoeshop/proto"
 )
 
+const (
+	// Как часто администраторы получают сводку товаров с низким остатком
+	lowStockDigestInterval = time.Hour
+	// Письма покупателям о поступлении и снижении цены уходят не чаще
+	// одного в этот интервал
+	customerEmailInterval = 200 * time.Millisecond
+)
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
@@ -39,6 +51,34 @@ func main() {
 		FromEmail: fromEmail,
 	})
 
+	// Сводка о низких остатках уходит администраторам из ADMIN_EMAILS
+	// (через запятую); без адресов сводка не рассылается
+	var adminEmails []string
+	for _, email := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			adminEmails = append(adminEmails, email)
+		}
+	}
+
+	natsClient, err := repository.NewNatsClient("nats://localhost:4222")
+	if err != nil {
+		log.Fatalf("Failed to connect to NATS: %v", err)
+	}
+
+	notifyCtx, stopNotifications := context.WithCancel(context.Background())
+	defer stopNotifications()
+	if len(adminEmails) > 0 {
+		if err := emailSvc.StartLowStockDigest(notifyCtx, natsClient, adminEmails, lowStockDigestInterval); err != nil {
+			log.Fatalf("Failed to start low stock digest: %v", err)
+		}
+	} else {
+		log.Println("ADMIN_EMAILS is not set, low stock digest is disabled")
+	}
+
+	if err := emailSvc.StartCustomerNotifications(notifyCtx, natsClient, customerEmailInterval); err != nil {
+		log.Fatalf("Failed to start customer notifications: %v", err)
+	}
+
 	// Инициализация gRPC handler
 	grpcHandler := handler.NewGRPCHandler(emailSvc)
 
@@ -73,5 +113,8 @@ func main() {
 
 	// Graceful shutdown
 	server.GracefulStop()
+	if err := natsClient.Close(); err != nil {
+		log.Printf("Error closing NATS connection: %v", err)
+	}
 	log.Println("Server stopped gracefully")
 } 
\ No newline at end of file
@@ -32,7 +32,10 @@ func (h *GRPCHandler) SendRegistrationConfirmation(ctx context.Context, req *pb.
 }
 
 func (h *GRPCHandler) SendOrderConfirmation(ctx context.Context, req *pb.OrderEmailRequest) (*pb.EmailResponse, error) {
-	if err := h.emailService.SendOrderConfirmation(ctx, req.GetOrder()); err != nil {
+	if req.GetRecipient() == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipient is required")
+	}
+	if err := h.emailService.SendOrderConfirmation(ctx, req.GetOrder(), req.GetRecipient(), req.GetAttachments()); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to send order confirmation: %v", err)
 	}
 
@@ -42,7 +45,10 @@ func (h *GRPCHandler) SendOrderConfirmation(ctx context.Context, req *pb.OrderEm
 }
 
 func (h *GRPCHandler) SendOrderStatusUpdate(ctx context.Context, req *pb.OrderEmailRequest) (*pb.EmailResponse, error) {
-	if err := h.emailService.SendOrderStatusUpdate(ctx, req.GetOrder()); err != nil {
+	if req.GetRecipient() == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipient is required")
+	}
+	if err := h.emailService.SendOrderStatusUpdate(ctx, req.GetOrder(), req.GetRecipient(), req.GetAttachments()); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to send order status update: %v", err)
 	}
 
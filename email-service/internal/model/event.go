@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// LowStockEvent - событие product.low_stock, которое публикует product-service
+type LowStockEvent struct {
+	ProductID string
+	SKU       string
+	Name      string
+	Stock     int32
+	Threshold int32
+	At        time.Time
+}
+
+// BackInStockEvent - событие product.back_in_stock: товар из подписки
+// пользователя снова в наличии
+type BackInStockEvent struct {
+	SubscriptionID string
+	UserID         string
+	Email          string
+	ProductID      string
+	ProductName    string
+	Size           string
+	Price          float64
+	At             time.Time
+}
+
+// PriceDroppedEvent - событие wishlist.price_dropped: цена товара из списка
+// желаний пользователя снизилась
+type PriceDroppedEvent struct {
+	UserID      string
+	Email       string
+	ProductID   string
+	ProductName string
+	Size        string
+	OldPrice    float64
+	NewPrice    float64
+	At          time.Time
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"shoeshop/email-service/internal/model"
+)
+
+type EventPublisher interface {
+	// PublishBackInStockSent подтверждает product-service отправку письма
+	// по подписке на поступление
+	PublishBackInStockSent(subscriptionID string) error
+}
+
+type EventSubscriber interface {
+	SubscribeLowStock(handler func(event *model.LowStockEvent)) error
+	// SubscribeBackInStock отвечает отправителю ошибкой handler: так
+	// product-service узнает, что письмо не принято
+	SubscribeBackInStock(handler func(event *model.BackInStockEvent) error) error
+	SubscribePriceDropped(handler func(event *model.PriceDroppedEvent)) error
+	Close() error
+}
+
+type EventBus interface {
+	EventPublisher
+	EventSubscriber
+}
+
+type natsClient struct {
+	conn *nats.Conn
+}
+
+func NewNatsClient(url string) (EventBus, error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natsClient{
+		conn: nc,
+	}, nil
+}
+
+func (n *natsClient) SubscribeLowStock(handler func(event *model.LowStockEvent)) error {
+	_, err := n.conn.Subscribe("product.low_stock", func(msg *nats.Msg) {
+		var event model.LowStockEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode product.low_stock event: %v", err)
+			return
+		}
+		handler(&event)
+	})
+	return err
+}
+
+// SubscribeBackInStock делит события между экземплярами сервиса, чтобы
+// письмо уходило один раз
+func (n *natsClient) SubscribeBackInStock(handler func(event *model.BackInStockEvent) error) error {
+	_, err := n.conn.QueueSubscribe("product.back_in_stock", "email-service", func(msg *nats.Msg) {
+		var event model.BackInStockEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode product.back_in_stock event: %v", err)
+			respond(msg, err)
+			return
+		}
+		respond(msg, handler(&event))
+	})
+	return err
+}
+
+func (n *natsClient) PublishBackInStockSent(subscriptionID string) error {
+	return n.conn.Publish("product.back_in_stock.sent", []byte(subscriptionID))
+}
+
+// respond отвечает на запрос: пустой ответ - событие принято, иначе текст ошибки
+func respond(msg *nats.Msg, err error) {
+	if msg.Reply == "" {
+		return
+	}
+	var data []byte
+	if err != nil {
+		data = []byte(err.Error())
+	}
+	if err := msg.Respond(data); err != nil {
+		log.Printf("failed to reply to %s: %v", msg.Subject, err)
+	}
+}
+
+func (n *natsClient) SubscribePriceDropped(handler func(event *model.PriceDroppedEvent)) error {
+	_, err := n.conn.Subscribe("wishlist.price_dropped", func(msg *nats.Msg) {
+		var event model.PriceDroppedEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode wishlist.price_dropped event: %v", err)
+			return
+		}
+		handler(&event)
+	})
+	return err
+}
+
+func (n *natsClient) Close() error {
+	n.conn.Close()
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+
+	pb "shoeshop/proto"
+)
+
+// Длина строки base64 по RFC 2045
+const base64LineLength = 76
+
+// withAttachments собирает письмо multipart/mixed из HTML-тела и вложений.
+// Возвращает заголовки MIME и тело письма
+func withAttachments(html []byte, attachments []*pb.Attachment) (string, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {`text/html; charset="UTF-8"`},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := part.Write(html); err != nil {
+		return "", "", err
+	}
+
+	for _, attachment := range attachments {
+		contentType := attachment.GetContentType()
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": attachment.GetFilename()})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.GetFilename()})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return "", "", err
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.GetContent())
+		for len(encoded) > 0 {
+			n := min(base64LineLength, len(encoded))
+			if _, err := fmt.Fprintf(part, "%s\r\n", encoded[:n]); err != nil {
+				return "", "", err
+			}
+			encoded = encoded[n:]
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	headers := fmt.Sprintf("MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n", writer.Boundary())
+	return headers, body.String(), nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"shoeshop/email-service/internal/model"
+)
+
+func (s *emailService) SendBackInStock(ctx context.Context, event *model.BackInStockEvent) error {
+	subject := fmt.Sprintf("%s is back in stock", event.ProductName)
+	templateData := map[string]interface{}{
+		"ProductName": event.ProductName,
+		"Size":        event.Size,
+		"Price":       event.Price,
+	}
+
+	body := `
+	<h2>Back in Stock</h2>
+	<p>Good news! {{.ProductName}}{{if .Size}} in size {{.Size}}{{end}} is available again for ${{.Price}}.</p>
+	<p>Stock is limited, so order soon.</p>
+	<p>You were unsubscribed from this alert automatically. Subscribe again if you miss it this time.</p>
+	<p>Best regards,<br>ShoeShop Team</p>
+	`
+
+	return s.sendEmail(event.Email, subject, body, templateData)
+}
@@ -6,15 +6,23 @@ import (
 	"fmt"
 	"html/template"
 	"net/smtp"
+	"time"
 
+	"shoeshop/email-service/internal/model"
+	"shoeshop/email-service/internal/repository"
 	pb "shoeshop/proto"
 )
 
 type EmailService interface {
 	SendRegistrationConfirmation(ctx context.Context, user *pb.User) error
-	SendOrderConfirmation(ctx context.Context, order *pb.Order) error
-	SendOrderStatusUpdate(ctx context.Context, order *pb.Order) error
+	SendOrderConfirmation(ctx context.Context, order *pb.Order, recipient string, attachments []*pb.Attachment) error
+	SendOrderStatusUpdate(ctx context.Context, order *pb.Order, recipient string, attachments []*pb.Attachment) error
 	SendPasswordReset(ctx context.Context, user *pb.User, resetToken string) error
+	SendLowStockDigest(ctx context.Context, to string, events []*model.LowStockEvent) error
+	StartLowStockDigest(ctx context.Context, subscriber repository.EventSubscriber, recipients []string, interval time.Duration) error
+	SendBackInStock(ctx context.Context, event *model.BackInStockEvent) error
+	SendPriceDrop(ctx context.Context, event *model.PriceDroppedEvent) error
+	StartCustomerNotifications(ctx context.Context, events repository.EventBus, interval time.Duration) error
 }
 
 type EmailConfig struct {
@@ -26,7 +34,8 @@ type EmailConfig struct {
 }
 
 type emailService struct {
-	config EmailConfig
+	config   EmailConfig
+	lowStock lowStockDigest
 }
 
 func NewEmailService(config EmailConfig) EmailService {
@@ -51,12 +60,17 @@ func (s *emailService) SendRegistrationConfirmation(ctx context.Context, user *p
 	return s.sendEmail(user.Email, subject, body, templateData)
 }
 
-func (s *emailService) SendOrderConfirmation(ctx context.Context, order *pb.Order) error {
+func (s *emailService) SendOrderConfirmation(ctx context.Context, order *pb.Order, recipient string, attachments []*pb.Attachment) error {
 	subject := fmt.Sprintf("Order Confirmation #%s", order.Id)
 	templateData := map[string]interface{}{
-		"OrderID":     order.Id,
-		"TotalAmount": order.TotalAmount,
-		"Items":       order.Items,
+		"OrderID":        order.Id,
+		"TotalAmount":    order.TotalAmount,
+		"DiscountTotal":  order.DiscountTotal,
+		"ShippingCost":   order.ShippingCost,
+		"ShippingMethod": order.ShippingMethod,
+		"TaxTotal":       order.TaxTotal,
+		"TaxInclusive":   order.TaxInclusive,
+		"Items":          order.Items,
 	}
 
 	body := `
@@ -65,32 +79,47 @@ func (s *emailService) SendOrderConfirmation(ctx context.Context, order *pb.Orde
 	<h3>Order Details:</h3>
 	<ul>
 	{{range .Items}}
-		<li>{{.ProductId}} - Quantity: {{.Quantity}} - Price: ${{.Price}}</li>
+		<li>{{.ProductId}} - Quantity: {{.Quantity}} - Price: ${{.Price}}{{range .Promotions}} - {{.Name}}: -${{.Amount}}{{end}}{{if .Tax}} - Tax: ${{.Tax}}{{end}}</li>
 	{{end}}
 	</ul>
+	{{if .DiscountTotal}}<p>Discount: -${{.DiscountTotal}}</p>{{end}}
+	{{if .ShippingMethod}}<p>Shipping ({{.ShippingMethod}}): {{if .ShippingCost}}${{.ShippingCost}}{{else}}free{{end}}</p>{{end}}
+	{{if and .TaxTotal (not .TaxInclusive)}}<p>Tax: ${{.TaxTotal}}</p>{{end}}
 	<p><strong>Total Amount: ${{.TotalAmount}}</strong></p>
+	{{if and .TaxTotal .TaxInclusive}}<p>Includes tax: ${{.TaxTotal}}</p>{{end}}
 	<p>We'll notify you when your order ships.</p>
 	<p>Best regards,<br>ShoeShop Team</p>
 	`
 
-	return s.sendEmail(order.ShippingAddress, subject, body, templateData)
+	return s.sendEmail(recipient, subject, body, templateData, attachments...)
 }
 
-func (s *emailService) SendOrderStatusUpdate(ctx context.Context, order *pb.Order) error {
+func (s *emailService) SendOrderStatusUpdate(ctx context.Context, order *pb.Order, recipient string, attachments []*pb.Attachment) error {
 	subject := fmt.Sprintf("Order Status Update #%s", order.Id)
 	templateData := map[string]interface{}{
-		"OrderID": order.Id,
-		"Status":  order.Status,
+		"OrderID":   order.Id,
+		"Status":    order.Status,
+		"Shipments": order.Shipments,
+		"Attached":  len(attachments) > 0,
 	}
 
 	body := `
 	<h2>Order Status Update</h2>
 	<p>Your order #{{.OrderID}} status has been updated to: {{.Status}}</p>
+	{{if .Shipments}}
+	<h3>Tracking:</h3>
+	<ul>
+	{{range .Shipments}}
+		<li>{{.Carrier}} {{.TrackingNumber}} - {{.Status}}</li>
+	{{end}}
+	</ul>
+	{{end}}
+	{{if .Attached}}<p>Your invoice is attached to this email.</p>{{end}}
 	<p>If you have any questions, please contact our support team.</p>
 	<p>Best regards,<br>ShoeShop Team</p>
 	`
 
-	return s.sendEmail(order.ShippingAddress, subject, body, templateData)
+	return s.sendEmail(recipient, subject, body, templateData, attachments...)
 }
 
 func (s *emailService) SendPasswordReset(ctx context.Context, user *pb.User, resetToken string) error {
@@ -112,7 +141,7 @@ func (s *emailService) SendPasswordReset(ctx context.Context, user *pb.User, res
 	return s.sendEmail(user.Email, subject, body, templateData)
 }
 
-func (s *emailService) sendEmail(to, subject, bodyTemplate string, data map[string]interface{}) error {
+func (s *emailService) sendEmail(to, subject, bodyTemplate string, data map[string]interface{}, attachments ...*pb.Attachment) error {
 	// Парсим шаблон
 	tmpl, err := template.New("email").Parse(bodyTemplate)
 	if err != nil {
@@ -127,8 +156,15 @@ func (s *emailService) sendEmail(to, subject, bodyTemplate string, data map[stri
 
 	// Формируем email сообщение
 	mime := "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";\n\n"
+	content := body.String()
+	if len(attachments) > 0 {
+		mime, content, err = withAttachments(body.Bytes(), attachments)
+		if err != nil {
+			return fmt.Errorf("failed to attach files: %w", err)
+		}
+	}
 	msg := fmt.Sprintf("To: %s\r\nFrom: %s\r\nSubject: %s\r\n%s\r\n%s",
-		to, s.config.FromEmail, subject, mime, body.String())
+		to, s.config.FromEmail, subject, mime, content)
 
 	// Отправляем email
 	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.SMTPHost)
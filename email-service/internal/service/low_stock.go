@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"shoeshop/email-service/internal/model"
+	"shoeshop/email-service/internal/repository"
+)
+
+// lowStockDigest копит события product.low_stock между рассылками;
+// по каждому товару хранится последнее событие
+type lowStockDigest struct {
+	mu     sync.Mutex
+	events map[string]*model.LowStockEvent
+}
+
+func (d *lowStockDigest) add(event *model.LowStockEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.events == nil {
+		d.events = make(map[string]*model.LowStockEvent)
+	}
+	if current, ok := d.events[event.ProductID]; ok && current.At.After(event.At) {
+		return
+	}
+	d.events[event.ProductID] = event
+}
+
+// take забирает накопленные события, самые малые остатки первыми
+func (d *lowStockDigest) take() []*model.LowStockEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := make([]*model.LowStockEvent, 0, len(d.events))
+	for _, event := range d.events {
+		events = append(events, event)
+	}
+	d.events = nil
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Stock != events[j].Stock {
+			return events[i].Stock < events[j].Stock
+		}
+		return events[i].Name < events[j].Name
+	})
+	return events
+}
+
+// StartLowStockDigest подписывается на product.low_stock и раз в interval
+// отправляет администраторам сводку товаров, которые пора дозаказать
+func (s *emailService) StartLowStockDigest(ctx context.Context, subscriber repository.EventSubscriber, recipients []string, interval time.Duration) error {
+	if err := subscriber.SubscribeLowStock(s.lowStock.add); err != nil {
+		return fmt.Errorf("failed to subscribe to product.low_stock: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.sendLowStockDigest(ctx, recipients)
+			}
+		}
+	}()
+
+	log.Printf("Low stock digest started, sending every %s", interval)
+	return nil
+}
+
+func (s *emailService) sendLowStockDigest(ctx context.Context, recipients []string) {
+	events := s.lowStock.take()
+	if len(events) == 0 {
+		return
+	}
+
+	sent := 0
+	for _, to := range recipients {
+		if err := s.SendLowStockDigest(ctx, to, events); err != nil {
+			fmt.Printf("failed to send low stock digest to %s: %v\n", to, err)
+			continue
+		}
+		sent++
+	}
+
+	// Если сводку не получил никто, события попадут в следующую рассылку
+	if sent == 0 {
+		for _, event := range events {
+			s.lowStock.add(event)
+		}
+	}
+}
+
+func (s *emailService) SendLowStockDigest(ctx context.Context, to string, events []*model.LowStockEvent) error {
+	subject := fmt.Sprintf("Low stock: %d products need restocking", len(events))
+	templateData := map[string]interface{}{
+		"Events": events,
+	}
+
+	body := `
+	<h2>Low Stock Digest</h2>
+	<p>These products dropped to their reorder threshold:</p>
+	<table border="1" cellpadding="4" cellspacing="0">
+		<tr><th>SKU</th><th>Product</th><th>Stock</th><th>Threshold</th></tr>
+		{{range .Events}}
+		<tr><td>{{.SKU}}</td><td>{{.Name}}</td><td>{{.Stock}}</td><td>{{.Threshold}}</td></tr>
+		{{end}}
+	</table>
+	<p>See the reorder report in the admin panel for suggested quantities.</p>
+	<p>ShoeShop</p>
+	`
+
+	return s.sendEmail(to, subject, body, templateData)
+}
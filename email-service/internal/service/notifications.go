@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"shoeshop/email-service/internal/model"
+	"shoeshop/email-service/internal/repository"
+)
+
+// Сколько писем покупателям может ждать отправки. Когда очередь полна,
+// письмо о поступлении отклоняется, и product-service повторит его позже,
+// а обработчик снижения цены ждет места: каждая подписка NATS получает
+// события в своей горутине, поэтому ожидание задерживает только ее
+const notificationQueueSize = 1000
+
+// ErrQueueFull - очередь писем покупателям заполнена
+var ErrQueueFull = errors.New("notification queue is full")
+
+// notification - письмо покупателю, ожидающее отправки
+type notification struct {
+	kind   string
+	userID string
+	send   func(ctx context.Context) error
+}
+
+// StartCustomerNotifications подписывается на события для писем покупателям
+// (product.back_in_stock, wishlist.price_dropped) и отправляет письма из
+// общей очереди не чаще одного в interval: поступление или скидка на
+// популярный товар не должны упираться в лимиты SMTP-сервера. Об отправке
+// письма о поступлении сообщается product.back_in_stock.sent
+func (s *emailService) StartCustomerNotifications(ctx context.Context, events repository.EventBus, interval time.Duration) error {
+	queue := make(chan notification, notificationQueueSize)
+	enqueue := func(n notification) {
+		select {
+		case queue <- n:
+		case <-ctx.Done():
+		}
+	}
+
+	err := events.SubscribeBackInStock(func(event *model.BackInStockEvent) error {
+		n := notification{
+			kind:   "back in stock",
+			userID: event.UserID,
+			send: func(ctx context.Context) error {
+				if err := s.SendBackInStock(ctx, event); err != nil {
+					return err
+				}
+				// Без подтверждения product-service не удаляет подписку
+				if err := events.PublishBackInStockSent(event.SubscriptionID); err != nil {
+					fmt.Printf("failed to publish back in stock sent event: %v\n", err)
+				}
+				return nil
+			},
+		}
+		select {
+		case queue <- n:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to product.back_in_stock: %w", err)
+	}
+
+	err = events.SubscribePriceDropped(func(event *model.PriceDroppedEvent) {
+		enqueue(notification{
+			kind:   "price drop",
+			userID: event.UserID,
+			send:   func(ctx context.Context) error { return s.SendPriceDrop(ctx, event) },
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to wishlist.price_dropped: %w", err)
+	}
+
+	go func() {
+		limiter := time.NewTicker(interval)
+		defer limiter.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-queue:
+				if err := n.send(ctx); err != nil {
+					fmt.Printf("failed to send %s email to user %s: %v\n", n.kind, n.userID, err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-limiter.C:
+			}
+		}
+	}()
+
+	log.Printf("Customer notifications started, at most one email every %s", interval)
+	return nil
+}
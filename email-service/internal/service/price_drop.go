@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"shoeshop/email-service/internal/model"
+)
+
+func (s *emailService) SendPriceDrop(ctx context.Context, event *model.PriceDroppedEvent) error {
+	subject := fmt.Sprintf("Price drop: %s", event.ProductName)
+	templateData := map[string]interface{}{
+		"ProductName": event.ProductName,
+		"Size":        event.Size,
+		"OldPrice":    fmt.Sprintf("%.2f", event.OldPrice),
+		"NewPrice":    fmt.Sprintf("%.2f", event.NewPrice),
+	}
+
+	body := `
+	<h2>Price Drop</h2>
+	<p>{{.ProductName}} from your wishlist is now ${{.NewPrice}} (was ${{.OldPrice}}).</p>
+	{{if .Size}}<p>Your preferred size: {{.Size}}.</p>{{end}}
+	<p>Move it to your cart from the wishlist before the price changes again.</p>
+	<p>Best regards,<br>ShoeShop Team</p>
+	`
+
+	return s.sendEmail(event.Email, subject, body, templateData)
+}
@@ -0,0 +1,85 @@
+// Package ids выдает ID документов всех сервисов
+//
+// ID - это ULID: 48 бит времени в миллисекундах и 80 случайных бит,
+// записанные 26 символами base32 Крокфорда. Как строки они сортируются по
+// времени создания и, в отличие от коллекции-счетчика, не требуют
+// согласования между экземплярами сервисов. ID, выданные до появления
+// пакета, остаются действительными: сервисы считают любой ID просто строкой
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// Length - длина ID в символах
+const Length = 26
+
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	mu       sync.Mutex
+	lastTime uint64
+	lastRand [10]byte
+)
+
+// New возвращает ID для текущего момента
+func New() string {
+	return NewAt(time.Now())
+}
+
+// NewAt возвращает ID для момента t. ID, выданные одним процессом в одну
+// миллисекунду, все равно идут в порядке выдачи: случайная часть
+// предыдущего ID увеличивается на единицу, а не выбирается заново
+func NewAt(t time.Time) string {
+	ms := uint64(t.UnixMilli())
+
+	mu.Lock()
+	if ms != lastTime || !increment(&lastRand) {
+		if _, err := rand.Read(lastRand[:]); err != nil {
+			panic("ids: failed to read random bytes: " + err.Error())
+		}
+		lastTime = ms
+	}
+	random := lastRand
+	mu.Unlock()
+
+	return fromParts(ms, random)
+}
+
+// fromParts собирает ID из времени в миллисекундах и случайной части
+func fromParts(ms uint64, random [10]byte) string {
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[4:6], uint16(ms))
+	binary.BigEndian.PutUint32(id[0:4], uint32(ms>>16))
+	copy(id[6:], random[:])
+	return encode(id)
+}
+
+// increment прибавляет единицу к случайной части; false - переполнение
+func increment(b *[10]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode записывает 128 бит 26 символами base32; первый символ несет
+// только старшие 3 бита
+func encode(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+
+	var out [Length]byte
+	for i := Length - 1; i >= 0; i-- {
+		out[i] = alphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
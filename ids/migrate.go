@@ -0,0 +1,47 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateObjectIDs выдает строковый ID документам, которым Mongo выдал
+// ObjectID при вставке без ID: поиск по строковому ID их не находит. Новый
+// ID - hex-запись ObjectID: под ним API всегда отдавал эти документы и по
+// нему на них ссылаются другие коллекции, поэтому все ссылки остаются
+// верными. Тот же ID позволяет миграции, прерванной между копированием и
+// удалением, продолжиться без дубликатов
+func MigrateObjectIDs(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$type": "objectId"}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		oldID, ok := doc["_id"].(primitive.ObjectID)
+		if !ok {
+			continue
+		}
+
+		// _id нельзя изменить на месте: вставляем копию и удаляем оригинал.
+		// Дубликат значит, что прошлый запуск скопировал документ и не успел
+		// удалить оригинал; копия с тех пор могла измениться, поэтому остается она
+		doc["_id"] = oldID.Hex()
+		if _, err := collection.InsertOne(ctx, doc); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to copy document %s: %w", oldID.Hex(), err)
+		}
+		if _, err := collection.DeleteOne(ctx, bson.M{"_id": oldID}); err != nil {
+			return fmt.Errorf("failed to delete document %s: %w", oldID.Hex(), err)
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,102 @@
+// carrier-stub изображает перевозчика: отправляет в вебхук шлюза события
+// посылки так же, как это делает настоящий перевозчик. Например, довезти
+// посылку до покупателя:
+//
+//	go run ./order-service/cmd/carrier-stub -carrier ups -tracking 1Z999 \
+//		-status IN_TRANSIT,OUT_FOR_DELIVERY,DELIVERED
+//
+// События подписываются CARRIER_WEBHOOK_SECRET, как и в шлюзе. Без секрета
+// шлюз примет их, только если запущен с CARRIER_WEBHOOK_ALLOW_UNSIGNED=true
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+type carrierEvent struct {
+	TrackingNumber string `json:"tracking_number"`
+	Status         string `json:"status"`
+	Description    string `json:"description,omitempty"`
+	Location       string `json:"location,omitempty"`
+	OccurredAt     string `json:"occurred_at"`
+}
+
+func main() {
+	gateway := flag.String("gateway", "http://localhost:8080", "API gateway address")
+	carrier := flag.String("carrier", "UPS", "carrier code")
+	tracking := flag.String("tracking", "", "tracking number of the shipment")
+	statuses := flag.String("status", "IN_TRANSIT", "comma-separated statuses to send in order")
+	location := flag.String("location", "", "location reported with every event")
+	secret := flag.String("secret", os.Getenv("CARRIER_WEBHOOK_SECRET"), "webhook signing secret")
+	interval := flag.Duration("interval", time.Hour, "time between reported events")
+	flag.Parse()
+
+	if *tracking == "" {
+		log.Fatal("-tracking is required")
+	}
+
+	// События датируются в прошлом с шагом interval, последнее - сейчас
+	list := strings.Split(*statuses, ",")
+	occurredAt := time.Now().Add(-time.Duration(len(list)-1) * *interval)
+	url := fmt.Sprintf("%s/api/webhooks/carriers/%s", strings.TrimRight(*gateway, "/"), *carrier)
+
+	for _, status := range list {
+		event := carrierEvent{
+			TrackingNumber: *tracking,
+			Status:         strings.TrimSpace(status),
+			Description:    "Stub carrier event",
+			Location:       *location,
+			OccurredAt:     occurredAt.UTC().Format(time.RFC3339),
+		}
+		if err := send(url, []byte(*secret), event); err != nil {
+			log.Fatalf("Failed to send %s: %v", event.Status, err)
+		}
+		occurredAt = occurredAt.Add(*interval)
+	}
+}
+
+func send(url string, secret []byte, event carrierEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(secret) > 0 {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		req.Header.Set("X-Carrier-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	answer, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s -> %d %s\n", event.Status, resp.StatusCode, bytes.TrimSpace(answer))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gateway answered %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 	"shoeshop/order-service/internal/handler"
 	"shoeshop/order-service/internal/repository"
 	"shoeshop/order-service/internal/service"
+	"shoeshop/order-service/internal/shipping"
+	"shoeshop/order-service/internal/tax"
 	pb "shoeshop/proto"
 )
 
@@ -26,6 +28,22 @@ func main() {
 		log.Fatalf("Failed to create MongoDB repository: %v", err)
 	}
 
+	// Акции и купоны хранятся в той же базе, что и заказы
+	promotionRepo, err := repository.NewPromotionRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create promotion repository: %v", err)
+	}
+
+	couponRepo, err := repository.NewCouponRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create coupon repository: %v", err)
+	}
+
+	invoiceRepo, err := repository.NewInvoiceRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create invoice repository: %v", err)
+	}
+
 	// Инициализация NATS для событий
 	natsClient, err := repository.NewNatsClient("nats://localhost:4222")
 	if err != nil {
@@ -56,11 +74,33 @@ func main() {
 	defer emailConn.Close()
 	emailClient := pb.NewEmailServiceClient(emailConn)
 
+	// Тарифы доставки по зонам: из JSON-файла SHIPPING_RATES_FILE или встроенные
+	rates := shipping.DefaultRates()
+	if path := os.Getenv("SHIPPING_RATES_FILE"); path != "" {
+		rates, err = shipping.LoadRates(path)
+		if err != nil {
+			log.Fatalf("Failed to load shipping rates: %v", err)
+		}
+		log.Printf("Shipping rates loaded from %s", path)
+	}
+
+	// Налоговые ставки по регионам: из JSON-файла TAX_RATES_FILE или встроенные
+	taxes := tax.DefaultRates()
+	if path := os.Getenv("TAX_RATES_FILE"); path != "" {
+		taxes, err = tax.LoadRates(path)
+		if err != nil {
+			log.Fatalf("Failed to load tax rates: %v", err)
+		}
+		log.Printf("Tax rates loaded from %s", path)
+	}
+
 	// Инициализация сервиса
-	svc := service.NewOrderService(repo, natsClient, productClient, userClient, emailClient)
+	svc := service.NewOrderService(repo, promotionRepo, couponRepo, invoiceRepo, natsClient, productClient, userClient, emailClient, rates, taxes)
+	promotionSvc := service.NewPromotionService(promotionRepo)
+	couponSvc := service.NewCouponService(couponRepo)
 
 	// Инициализация gRPC handler
-	grpcHandler := handler.NewGRPCHandler(svc)
+	grpcHandler := handler.NewGRPCHandler(svc, promotionSvc, couponSvc)
 
 	// Создание gRPC сервера
 	server := grpc.NewServer()
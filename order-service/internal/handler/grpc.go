@@ -2,23 +2,36 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"shoeshop/order-service/internal/model"
 	"shoeshop/order-service/internal/service"
+	"shoeshop/order-service/internal/shipping"
 	pb "shoeshop/proto"
+	"shoeshop/sizing"
 )
 
 type GRPCHandler struct {
 	pb.UnimplementedOrderServiceServer
-	orderService service.OrderService
+	orderService     service.OrderService
+	promotionService service.PromotionService
+	couponService    service.CouponService
 }
 
-func NewGRPCHandler(orderService service.OrderService) *GRPCHandler {
+func NewGRPCHandler(
+	orderService service.OrderService,
+	promotionService service.PromotionService,
+	couponService service.CouponService,
+) *GRPCHandler {
 	return &GRPCHandler{
-		orderService: orderService,
+		orderService:     orderService,
+		promotionService: promotionService,
+		couponService:    couponService,
 	}
 }
 
@@ -30,42 +43,59 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReques
 
 	createdOrder, err := h.orderService.CreateOrder(ctx, order)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+		return nil, pricingError("failed to create order", err)
 	}
 
 	return &pb.OrderResponse{
-		Order: createdOrder.ToProto(),
+		Order: createdOrder.ToProto(""),
 	}, nil
 }
 
 func (h *GRPCHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.OrderResponse, error) {
+	system, err := requestedSizeSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, err
+	}
+
 	order, err := h.orderService.GetOrder(ctx, req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
 	return &pb.OrderResponse{
-		Order: order.ToProto(),
+		Order: order.ToProto(system),
 	}, nil
 }
 
 func (h *GRPCHandler) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*pb.OrderResponse, error) {
+	if req.GetOrder() == nil {
+		return nil, status.Error(codes.InvalidArgument, "order is required")
+	}
+	if !req.GetUpdateMask().IsValid(req.GetOrder()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update mask: %v", req.GetUpdateMask().GetPaths())
+	}
+
 	order, err := model.FromProto(req.GetOrder())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid order data: %v", err)
 	}
 
-	updatedOrder, err := h.orderService.UpdateOrder(ctx, order)
+	updatedOrder, err := h.orderService.UpdateOrder(ctx, order, req.GetUpdateMask().GetPaths())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+		return nil, orderError("failed to update order", err)
 	}
 
 	return &pb.OrderResponse{
-		Order: updatedOrder.ToProto(),
+		Order: updatedOrder.ToProto(""),
 	}, nil
 }
 
 func (h *GRPCHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	system, err := requestedSizeSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, err
+	}
+
 	orders, err := h.orderService.ListOrders(ctx, req.GetUserId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
@@ -73,7 +103,7 @@ func (h *GRPCHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest)
 
 	pbOrders := make([]*pb.Order, len(orders))
 	for i, order := range orders {
-		pbOrders[i] = order.ToProto()
+		pbOrders[i] = order.ToProto(system)
 	}
 
 	return &pb.ListOrdersResponse{
@@ -82,12 +112,385 @@ func (h *GRPCHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest)
 }
 
 func (h *GRPCHandler) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
-	err := h.orderService.UpdateOrderStatus(ctx, req.GetId(), model.OrderStatus(req.GetStatus()))
+	err := h.orderService.UpdateOrderStatus(ctx, req.GetId(), model.OrderStatus(req.GetStatus()), req.GetVersion())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+		return nil, orderError("failed to update order status", err)
 	}
 
 	return &pb.UpdateOrderStatusResponse{
 		Success: true,
 	}, nil
-} 
\ No newline at end of file
+}
+
+func (h *GRPCHandler) VerifyPurchase(ctx context.Context, req *pb.VerifyPurchaseRequest) (*pb.VerifyPurchaseResponse, error) {
+	if req.GetUserId() == "" || req.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and product_id are required")
+	}
+
+	order, err := h.orderService.VerifyPurchase(ctx, req.GetUserId(), req.GetProductId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify purchase: %v", err)
+	}
+	if order == nil {
+		return &pb.VerifyPurchaseResponse{Verified: false}, nil
+	}
+
+	return &pb.VerifyPurchaseResponse{
+		Verified: true,
+		OrderId:  order.ID,
+	}, nil
+}
+
+func (h *GRPCHandler) ReferencedProducts(ctx context.Context, req *pb.ReferencedProductsRequest) (*pb.ReferencedProductsResponse, error) {
+	referenced, err := h.orderService.ReferencedProducts(ctx, req.GetProductIds())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find referenced products: %v", err)
+	}
+
+	return &pb.ReferencedProductsResponse{
+		ProductIds: referenced,
+	}, nil
+}
+
+func (h *GRPCHandler) ReturnOrderItem(ctx context.Context, req *pb.ReturnOrderItemRequest) (*pb.OrderResponse, error) {
+	system, err := requestedSizeSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := h.orderService.ReturnOrderItem(
+		ctx,
+		req.GetOrderId(),
+		req.GetProductId(),
+		req.GetSize(),
+		system,
+		req.GetReason(),
+		model.Fit(req.GetFit()),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidReturn):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, service.ErrNotReturnable):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to return order item: %v", err)
+		}
+	}
+
+	return &pb.OrderResponse{
+		Order: order.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.OrderResponse, error) {
+	order, err := model.FromProto(req.GetOrder())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order data: %v", err)
+	}
+
+	quotedOrder, err := h.orderService.QuoteOrder(ctx, order)
+	if err != nil {
+		return nil, pricingError("failed to quote order", err)
+	}
+
+	return &pb.OrderResponse{
+		Order: quotedOrder.ToProto(""),
+	}, nil
+}
+
+func (h *GRPCHandler) QuoteShipping(ctx context.Context, req *pb.QuoteShippingRequest) (*pb.QuoteShippingResponse, error) {
+	order, err := model.FromProto(req.GetOrder())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order data: %v", err)
+	}
+
+	options, err := h.orderService.QuoteShipping(ctx, order)
+	if err != nil {
+		return nil, pricingError("failed to quote shipping", err)
+	}
+
+	pbOptions := make([]*pb.ShippingOption, len(options))
+	for i, option := range options {
+		pbOptions[i] = &pb.ShippingOption{
+			Method:  option.Method,
+			Name:    option.Name,
+			Zone:    option.Zone,
+			Cost:    option.Cost,
+			MinDays: int32(option.MinDays),
+			MaxDays: int32(option.MaxDays),
+		}
+	}
+
+	return &pb.QuoteShippingResponse{
+		ShippingCountry: options[0].Country,
+		Options:         pbOptions,
+	}, nil
+}
+
+func (h *GRPCHandler) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.OrderResponse, error) {
+	system, err := requestedSizeSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.ShipmentItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = model.ShipmentItem{
+			ProductID: item.GetProductId(),
+			SizeLabel: item.GetSize(),
+			Quantity:  item.GetQuantity(),
+		}
+	}
+
+	order, err := h.orderService.CreateShipment(ctx, req.GetOrderId(), req.GetCarrier(), req.GetTrackingNumber(), items, system)
+	if err != nil {
+		return nil, shipmentError("failed to create shipment", err)
+	}
+
+	return &pb.OrderResponse{
+		Order: order.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) RecordShipmentEvent(ctx context.Context, req *pb.RecordShipmentEventRequest) (*pb.OrderResponse, error) {
+	event, err := model.ShipmentEventFromProto(req.GetEvent())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	order, err := h.orderService.RecordShipmentEvent(ctx, req.GetCarrier(), req.GetTrackingNumber(), event)
+	if err != nil {
+		return nil, shipmentError("failed to record shipment event", err)
+	}
+
+	return &pb.OrderResponse{
+		Order: order.ToProto(""),
+	}, nil
+}
+
+func (h *GRPCHandler) GetInvoice(ctx context.Context, req *pb.GetInvoiceRequest) (*pb.InvoiceResponse, error) {
+	invoice, pdf, err := h.orderService.GetInvoice(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, invoiceError("failed to get invoice", err)
+	}
+
+	return &pb.InvoiceResponse{
+		Number:   invoice.Number,
+		IssuedAt: invoice.IssuedAt.Format(time.RFC3339),
+		Filename: invoice.Filename(),
+		Pdf:      pdf,
+	}, nil
+}
+
+func (h *GRPCHandler) CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.PromotionResponse, error) {
+	promotion, err := model.PromotionFromProto(req.GetPromotion())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	createdPromotion, err := h.promotionService.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return nil, promotionError("failed to create promotion", err)
+	}
+
+	return &pb.PromotionResponse{
+		Promotion: createdPromotion.ToProto(),
+	}, nil
+}
+
+func (h *GRPCHandler) UpdatePromotion(ctx context.Context, req *pb.UpdatePromotionRequest) (*pb.PromotionResponse, error) {
+	promotion, err := model.PromotionFromProto(req.GetPromotion())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	updatedPromotion, err := h.promotionService.UpdatePromotion(ctx, promotion)
+	if err != nil {
+		return nil, promotionError("failed to update promotion", err)
+	}
+
+	return &pb.PromotionResponse{
+		Promotion: updatedPromotion.ToProto(),
+	}, nil
+}
+
+func (h *GRPCHandler) DeletePromotion(ctx context.Context, req *pb.DeletePromotionRequest) (*pb.DeletePromotionResponse, error) {
+	if err := h.promotionService.DeletePromotion(ctx, req.GetId()); err != nil {
+		return nil, promotionError("failed to delete promotion", err)
+	}
+
+	return &pb.DeletePromotionResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *GRPCHandler) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	promotions, err := h.promotionService.ListPromotions(ctx, req.GetActiveOnly())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list promotions: %v", err)
+	}
+
+	pbPromotions := make([]*pb.Promotion, len(promotions))
+	for i, promotion := range promotions {
+		pbPromotions[i] = promotion.ToProto()
+	}
+
+	return &pb.ListPromotionsResponse{
+		Promotions: pbPromotions,
+	}, nil
+}
+
+func (h *GRPCHandler) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.CouponResponse, error) {
+	coupon, err := model.CouponFromProto(req.GetCoupon())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	createdCoupon, err := h.couponService.CreateCoupon(ctx, coupon)
+	if err != nil {
+		return nil, couponError("failed to create coupon", err)
+	}
+
+	return &pb.CouponResponse{
+		Coupon: createdCoupon.ToProto(),
+	}, nil
+}
+
+func (h *GRPCHandler) UpdateCoupon(ctx context.Context, req *pb.UpdateCouponRequest) (*pb.CouponResponse, error) {
+	coupon, err := model.CouponFromProto(req.GetCoupon())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	updatedCoupon, err := h.couponService.UpdateCoupon(ctx, coupon)
+	if err != nil {
+		return nil, couponError("failed to update coupon", err)
+	}
+
+	return &pb.CouponResponse{
+		Coupon: updatedCoupon.ToProto(),
+	}, nil
+}
+
+func (h *GRPCHandler) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	coupons, err := h.couponService.ListCoupons(ctx, req.GetActiveOnly())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list coupons: %v", err)
+	}
+
+	pbCoupons := make([]*pb.Coupon, len(coupons))
+	for i, coupon := range coupons {
+		pbCoupons[i] = coupon.ToProto()
+	}
+
+	return &pb.ListCouponsResponse{
+		Coupons: pbCoupons,
+	}, nil
+}
+
+func (h *GRPCHandler) GetCouponReport(ctx context.Context, req *pb.GetCouponReportRequest) (*pb.CouponReport, error) {
+	report, err := h.couponService.GetCouponReport(ctx, req.GetCode(), int64(req.GetLimit()), int64(req.GetOffset()))
+	if err != nil {
+		return nil, couponError("failed to get coupon report", err)
+	}
+
+	return report.ToProto(), nil
+}
+
+func orderError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrVersionConflict):
+		return status.Errorf(codes.Aborted, "%s: %v", message, err)
+	case errors.Is(err, model.ErrInvalidUpdateMask), errors.Is(err, service.ErrInvalidQuantity):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrShipmentsPending), errors.Is(err, model.ErrVersionRequired):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "order not found")
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+// pricingError отделяет ошибки покупателя в купоне от внутренних ошибок расчета заказа
+func pricingError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrCouponNotApplicable), errors.Is(err, service.ErrInvalidQuantity):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrCouponUsedUp):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, shipping.ErrUnknownMethod):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, shipping.ErrUndeliverable):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func shipmentError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidShipment):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrShipmentExists):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, service.ErrNotShippable):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, model.ErrVersionConflict):
+		return status.Errorf(codes.Aborted, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// Не найден заказ или посылка с таким трек-номером
+		return status.Errorf(codes.NotFound, "%s: %v", message, err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func invoiceError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrNotInvoiced):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "order not found")
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func couponError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidCoupon):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrCouponExists):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "coupon not found")
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func promotionError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidPromotion):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "promotion not found")
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+// requestedSizeSystem разбирает систему размеров из запроса; пустая строка
+// означает систему, в которой был оформлен заказ
+func requestedSizeSystem(value string) (sizing.System, error) {
+	if value == "" {
+		return "", nil
+	}
+	system, err := sizing.ParseSystem(value)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return system, nil
+}
@@ -0,0 +1,324 @@
+package invoice
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Размер страницы A4 в пунктах
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// Шрифты документа; номер - индекс в списке шрифтов
+const (
+	regular = iota
+	bold
+)
+
+// face - шрифт Go, который встраивается в PDF целиком. Шрифты Go
+// покрывают латиницу и кириллицу, так что имена и адреса покупателей
+// выводятся без внешних файлов шрифтов
+type face struct {
+	name    string
+	ttf     []byte
+	font    *sfnt.Font
+	metrics font.Metrics
+	bounds  fixed.Rectangle26_6
+}
+
+// Метрики шрифтов считаются в единицах 1/1000 кегля, как принято в PDF
+var unitsPerEm = fixed.I(1000)
+
+var loadFaces = sync.OnceValues(func() ([]*face, error) {
+	faces := []*face{
+		{name: "GoRegular", ttf: goregular.TTF},
+		{name: "GoBold", ttf: gobold.TTF},
+	}
+	var buf sfnt.Buffer
+	for _, f := range faces {
+		parsed, err := sfnt.Parse(f.ttf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font %s: %w", f.name, err)
+		}
+		f.font = parsed
+		if f.metrics, err = parsed.Metrics(&buf, unitsPerEm, font.HintingNone); err != nil {
+			return nil, fmt.Errorf("failed to read metrics of font %s: %w", f.name, err)
+		}
+		if f.bounds, err = parsed.Bounds(&buf, unitsPerEm, font.HintingNone); err != nil {
+			return nil, fmt.Errorf("failed to read bounds of font %s: %w", f.name, err)
+		}
+	}
+	return faces, nil
+})
+
+// glyph - использованный в документе глиф: символ для ToUnicode и ширина
+type glyph struct {
+	r     rune
+	width int
+}
+
+// document - PDF из страниц A4 с текстом и линиями. Координаты в пунктах,
+// начало - левый нижний угол страницы
+type document struct {
+	faces  []*face
+	glyphs []map[sfnt.GlyphIndex]glyph
+	pages  []*bytes.Buffer
+	buf    sfnt.Buffer
+}
+
+func newDocument() (*document, error) {
+	faces, err := loadFaces()
+	if err != nil {
+		return nil, err
+	}
+	glyphs := make([]map[sfnt.GlyphIndex]glyph, len(faces))
+	for i := range glyphs {
+		glyphs[i] = make(map[sfnt.GlyphIndex]glyph)
+	}
+	return &document{faces: faces, glyphs: glyphs}, nil
+}
+
+func (d *document) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// glyph возвращает глиф символа и запоминает его для встраивания
+func (d *document) glyph(fontIndex int, r rune) (sfnt.GlyphIndex, glyph) {
+	f := d.faces[fontIndex]
+	index, err := f.font.GlyphIndex(&d.buf, r)
+	if err != nil {
+		index = 0
+	}
+	if g, ok := d.glyphs[fontIndex][index]; ok {
+		return index, g
+	}
+
+	advance, err := f.font.GlyphAdvance(&d.buf, index, unitsPerEm, font.HintingNone)
+	if err != nil {
+		advance = 0
+	}
+	g := glyph{r: r, width: advance.Round()}
+	d.glyphs[fontIndex][index] = g
+	return index, g
+}
+
+// width - ширина текста в пунктах
+func (d *document) width(fontIndex int, size float64, text string) float64 {
+	var units int
+	for _, r := range text {
+		_, g := d.glyph(fontIndex, r)
+		units += g.width
+	}
+	return float64(units) * size / 1000
+}
+
+// fit обрезает текст с многоточием, чтобы он поместился в maxWidth
+func (d *document) fit(fontIndex int, size float64, text string, maxWidth float64) string {
+	if d.width(fontIndex, size, text) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if d.width(fontIndex, size, candidate) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (d *document) text(fontIndex int, size, x, y float64, text string) {
+	var hex strings.Builder
+	for _, r := range text {
+		index, _ := d.glyph(fontIndex, r)
+		fmt.Fprintf(&hex, "%04X", uint16(index))
+	}
+	fmt.Fprintf(d.page(), "BT /F%d %s Tf %s %s Td <%s> Tj ET\n",
+		fontIndex+1, number(size), number(x), number(y), hex.String())
+}
+
+// textRight выводит текст, выровненный по правому краю right
+func (d *document) textRight(fontIndex int, size, right, y float64, text string) {
+	d.text(fontIndex, size, right-d.width(fontIndex, size, text), y, text)
+}
+
+func (d *document) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page(), "%s w %s %s m %s %s l S\n",
+		number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+// rect закрашивает прямоугольник оттенком серого gray (0 - черный, 1 - белый)
+func (d *document) rect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.page(), "%s g %s %s %s %s re f 0 g\n",
+		number(gray), number(x), number(y), number(w), number(h))
+}
+
+// bytes собирает PDF: каталог, страницы и шрифты в виде Type0 с
+// CID-шрифтом TrueType и таблицей ToUnicode, чтобы текст можно было копировать
+func (d *document) bytes() ([]byte, error) {
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	// Номера объектов: 1 - каталог, 2 - дерево страниц, затем по пять
+	// объектов на шрифт и по два на страницу
+	const fontObjects = 5
+	firstFont := 3
+	firstPage := firstFont + len(d.faces)*fontObjects
+
+	w.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	w.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	var fonts strings.Builder
+	for i, f := range d.faces {
+		id := firstFont + i*fontObjects
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, id)
+		if err := d.writeFont(w, id, f, d.glyphs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, content := range d.pages {
+		id := firstPage + i*2
+		w.object(id, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			number(pageWidth), number(pageHeight), fonts.String(), id+1))
+		if err := w.stream(id+1, "", content.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	w.finish(1)
+	return w.buf.Bytes(), nil
+}
+
+func (d *document) writeFont(w *pdfWriter, id int, f *face, used map[sfnt.GlyphIndex]glyph) error {
+	indexes := make([]sfnt.GlyphIndex, 0, len(used))
+	for index := range used {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	var widths strings.Builder
+	for _, index := range indexes {
+		fmt.Fprintf(&widths, "%d [%d] ", index, used[index].width)
+	}
+
+	w.object(id, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, id+1, id+4))
+	w.object(id+1, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>",
+		f.name, id+2, widths.String()))
+
+	// В sfnt ось Y направлена вниз, в PDF - вверх
+	w.object(id+2, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.bounds.Min.X.Round(), -f.bounds.Max.Y.Round(), f.bounds.Max.X.Round(), -f.bounds.Min.Y.Round(),
+		f.metrics.Ascent.Round(), -f.metrics.Descent.Round(), f.metrics.CapHeight.Round(), id+3))
+	if err := w.stream(id+3, fmt.Sprintf("/Length1 %d ", len(f.ttf)), f.ttf); err != nil {
+		return err
+	}
+
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// В одном блоке bfchar не больше 100 записей
+	for start := 0; start < len(indexes); start += 100 {
+		end := min(start+100, len(indexes))
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", end-start)
+		for _, index := range indexes[start:end] {
+			fmt.Fprintf(&cmap, "<%04X> <%s>\n", uint16(index), utf16Hex(used[index].r))
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return w.stream(id+4, "", []byte(cmap.String()))
+}
+
+// pdfWriter пишет объекты PDF и запоминает их смещения для таблицы xref
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *pdfWriter) object(id int, body string) {
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "%s\nendobj\n", body)
+}
+
+func (w *pdfWriter) begin(id int) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int)
+	}
+	w.offsets[id] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", id)
+}
+
+// stream пишет сжатый поток; extra - дополнительные записи словаря потока
+func (w *pdfWriter) stream(id int, extra string, data []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "<< %s/Length %d /Filter /FlateDecode >>\nstream\n", extra, compressed.Len())
+	if _, err := io.Copy(&w.buf, &compressed); err != nil {
+		return err
+	}
+	w.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+func (w *pdfWriter) finish(root int) {
+	size := len(w.offsets) + 1
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[id])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, root, xref)
+}
+
+// number выводит число без лишних нулей: PDF не понимает экспоненту
+func number(value float64) string {
+	s := fmt.Sprintf("%.2f", value)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func utf16Hex(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
@@ -0,0 +1,194 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"shoeshop/order-service/internal/model"
+)
+
+const (
+	margin = 50.0
+	// Нижняя граница строк таблицы; ниже остается место под итоги
+	tableBottom = margin + 40
+	rowHeight   = 30.0
+	totalsRows  = 7
+)
+
+// Правые края колонок таблицы позиций
+var (
+	colItem     = margin
+	colQuantity = 330.0
+	colPrice    = 400.0
+	colDiscount = 460.0
+	colTax      = 505.0
+	colAmount   = pageWidth - margin
+)
+
+// Render строит PDF счета из снимка заказа
+func Render(invoice *model.Invoice) ([]byte, error) {
+	doc, err := newDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	doc.addPage()
+	y := renderHeader(doc, invoice)
+	y = renderTableHeader(doc, y)
+	for _, line := range invoice.Lines {
+		if y-rowHeight < tableBottom {
+			doc.addPage()
+			y = renderTableHeader(doc, pageHeight-margin)
+		}
+		renderLine(doc, line, y)
+		y -= rowHeight
+	}
+
+	// Итоги не переносятся и не наезжают на подпись внизу страницы
+	if y-float64(totalsRows)*16 < margin+20 {
+		doc.addPage()
+		y = pageHeight - margin
+	}
+	renderTotals(doc, invoice, y)
+
+	return doc.bytes()
+}
+
+func renderHeader(doc *document, invoice *model.Invoice) float64 {
+	top := pageHeight - margin
+	right := pageWidth - margin
+
+	doc.text(bold, 22, margin, top-20, "ShoeShop")
+	doc.textRight(bold, 18, right, top-20, "INVOICE")
+
+	details := [][2]string{
+		{"Invoice number", invoice.Number},
+		{"Issue date", invoice.IssuedAt.Format("January 2, 2006")},
+		{"Order", invoice.OrderID},
+	}
+	if invoice.PaymentMethod != "" {
+		details = append(details, [2]string{"Payment", strings.TrimSpace(invoice.PaymentMethod + " " + invoice.PaymentID)})
+	}
+	y := top - 45
+	for _, detail := range details {
+		doc.textRight(regular, 9, right-130, y, detail[0]+":")
+		doc.textRight(bold, 9, right, y, doc.fit(bold, 9, detail[1], 125))
+		y -= 14
+	}
+
+	// Покупатель
+	customerY := top - 45
+	doc.text(bold, 10, margin, customerY, "Bill to")
+	customerY -= 15
+	for _, value := range []string{invoice.Customer.Name, invoice.Customer.Email, invoice.Customer.Phone} {
+		if value == "" {
+			continue
+		}
+		doc.text(regular, 10, margin, customerY, doc.fit(regular, 10, value, 250))
+		customerY -= 13
+	}
+	for _, value := range strings.Split(invoice.Customer.Address, "\n") {
+		if value = strings.TrimSpace(value); value != "" {
+			doc.text(regular, 10, margin, customerY, doc.fit(regular, 10, value, 250))
+			customerY -= 13
+		}
+	}
+
+	return min(y, customerY) - 25
+}
+
+func renderTableHeader(doc *document, y float64) float64 {
+	doc.rect(margin, y-6, pageWidth-2*margin, 20, 0.92)
+	doc.text(bold, 9, colItem+4, y, "Item")
+	doc.textRight(bold, 9, colQuantity, y, "Qty")
+	doc.textRight(bold, 9, colPrice, y, "Unit price")
+	doc.textRight(bold, 9, colDiscount, y, "Discount")
+	doc.textRight(bold, 9, colTax, y, "Tax")
+	doc.textRight(bold, 9, colAmount-4, y, "Amount")
+	return y - 24
+}
+
+func renderLine(doc *document, line model.InvoiceLine, y float64) {
+	name := line.Name
+	if name == "" {
+		name = line.ProductID
+	}
+	doc.text(regular, 10, colItem+4, y, doc.fit(regular, 10, name, colQuantity-colItem-40))
+
+	var details []string
+	if line.Brand != "" {
+		details = append(details, line.Brand)
+	}
+	if line.Size != "" {
+		details = append(details, "size "+line.Size)
+	}
+	if line.SKU != "" {
+		details = append(details, "SKU "+line.SKU)
+	}
+	if len(details) > 0 {
+		doc.text(regular, 8, colItem+4, y-11, doc.fit(regular, 8, strings.Join(details, " · "), colQuantity-colItem-40))
+	}
+
+	doc.textRight(regular, 10, colQuantity, y, strconv.Itoa(int(line.Quantity)))
+	doc.textRight(regular, 10, colPrice, y, money(line.UnitPrice))
+	if line.Discount > 0 {
+		doc.textRight(regular, 10, colDiscount, y, "-"+money(line.Discount))
+	}
+	if line.TaxRate > 0 {
+		doc.textRight(regular, 10, colTax, y, money(line.Tax))
+		doc.textRight(regular, 8, colTax, y-11, percent(line.TaxRate))
+	}
+	doc.textRight(regular, 10, colAmount-4, y, money(line.Amount))
+	doc.line(margin, y-17, pageWidth-margin, y-17, 0.3)
+}
+
+func renderTotals(doc *document, invoice *model.Invoice, y float64) {
+	labelRight := colTax
+	valueRight := colAmount - 4
+	row := func(label, value string, fontIndex int) {
+		doc.textRight(fontIndex, 10, labelRight, y, label)
+		doc.textRight(fontIndex, 10, valueRight, y, value)
+		y -= 16
+	}
+
+	row("Subtotal", money(invoice.Subtotal), regular)
+	if invoice.DiscountTotal > 0 {
+		label := "Discount"
+		if invoice.CouponCode != "" {
+			label += " (" + invoice.CouponCode + ")"
+		}
+		row(label, "-"+money(invoice.DiscountTotal), regular)
+	}
+	if invoice.ShippingMethod != "" {
+		shipping := money(invoice.ShippingCost)
+		if invoice.ShippingCost == 0 {
+			shipping = "free"
+		}
+		row("Shipping ("+invoice.ShippingMethod+")", shipping, regular)
+	}
+	if invoice.TaxTotal > 0 && !invoice.TaxInclusive {
+		row("Tax", money(invoice.TaxTotal), regular)
+	}
+
+	doc.line(labelRight-120, y+10, valueRight, y+10, 0.8)
+	y -= 4
+	row("Total", money(invoice.Total), bold)
+	if invoice.TaxTotal > 0 && invoice.TaxInclusive {
+		row("Includes tax", money(invoice.TaxTotal), regular)
+	}
+	if invoice.ShippingTax > 0 {
+		row("incl. tax on shipping", money(invoice.ShippingTax), regular)
+	}
+
+	doc.text(regular, 9, margin, margin, "Thank you for shopping with ShoeShop.")
+}
+
+func money(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
+
+func percent(rate float64) string {
+	return strconv.FormatFloat(math.Round(rate*10000)/100, 'f', -1, 64) + "%"
+}
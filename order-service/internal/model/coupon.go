@@ -0,0 +1,172 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "shoeshop/proto"
+)
+
+var ErrInvalidCoupon = errors.New("invalid coupon")
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Coupon - код скидки. Счетчики использований меняются только атомарными
+// операциями репозитория, поэтому при обновлении купона они не перезаписываются
+type Coupon struct {
+	Code           string        `bson:"_id"`
+	Description    string        `bson:"description"`
+	Type           PromotionType `bson:"type"`
+	Value          float64       `bson:"value"`
+	MinOrderAmount float64       `bson:"min_order_amount"`
+	// Пустые списки означают, что купон действует на все товары
+	Brands       []string  `bson:"brands,omitempty"`
+	Categories   []string  `bson:"categories,omitempty"`
+	UsageLimit   int32     `bson:"usage_limit"`
+	PerUserLimit int32     `bson:"per_user_limit"`
+	UsedCount    int32     `bson:"used_count"`
+	Active       bool      `bson:"active"`
+	ExpiresAt    time.Time `bson:"expires_at,omitempty"`
+	CreatedAt    time.Time `bson:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+// CouponRedemption - использование купона в заказе, хранится для отчетов
+type CouponRedemption struct {
+	ID         string    `bson:"_id"`
+	CouponCode string    `bson:"coupon_code"`
+	OrderID    string    `bson:"order_id"`
+	UserID     string    `bson:"user_id"`
+	Discount   float64   `bson:"discount"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+// CouponReport - сводка использований купона
+type CouponReport struct {
+	Coupon        *Coupon
+	Redemptions   int64
+	UniqueUsers   int64
+	TotalDiscount float64
+	Recent        []*CouponRedemption
+}
+
+// NormalizeCouponCode приводит код к виду, в котором он хранится
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate проверяет купон и нормализует код и тип скидки
+func (c *Coupon) Validate() error {
+	c.Code = NormalizeCouponCode(c.Code)
+	c.Type = PromotionType(strings.ToUpper(string(c.Type)))
+
+	if !couponCodePattern.MatchString(c.Code) {
+		return fmt.Errorf("%w: code must be 3-32 letters, digits, '-' or '_'", ErrInvalidCoupon)
+	}
+	switch c.Type {
+	case PromotionPercentage:
+		if c.Value <= 0 || c.Value > 100 {
+			return fmt.Errorf("%w: percentage must be in (0, 100]", ErrInvalidCoupon)
+		}
+	case PromotionFixed:
+		if c.Value <= 0 {
+			return fmt.Errorf("%w: fixed discount must be positive", ErrInvalidCoupon)
+		}
+	default:
+		return fmt.Errorf("%w: type must be %s or %s", ErrInvalidCoupon, PromotionPercentage, PromotionFixed)
+	}
+	if c.MinOrderAmount < 0 {
+		return fmt.Errorf("%w: min_order_amount must not be negative", ErrInvalidCoupon)
+	}
+	if c.UsageLimit < 0 || c.PerUserLimit < 0 {
+		return fmt.Errorf("%w: usage limits must not be negative", ErrInvalidCoupon)
+	}
+	return nil
+}
+
+// UsableAt сообщает, включен ли купон и не истек ли он к моменту now
+func (c *Coupon) UsableAt(now time.Time) bool {
+	return c.Active && (c.ExpiresAt.IsZero() || now.Before(c.ExpiresAt))
+}
+
+// Applies сообщает, распространяется ли купон на товар
+func (c *Coupon) Applies(brand string, categories []string) bool {
+	if len(c.Brands) == 0 && len(c.Categories) == 0 {
+		return true
+	}
+	for _, b := range c.Brands {
+		if strings.EqualFold(b, brand) {
+			return true
+		}
+	}
+	return matchesCategory(c.Categories, categories)
+}
+
+// ToProto конвертирует доменную модель в protobuf модель
+func (c *Coupon) ToProto() *pb.Coupon {
+	return &pb.Coupon{
+		Code:           c.Code,
+		Description:    c.Description,
+		Type:           string(c.Type),
+		Value:          c.Value,
+		MinOrderAmount: c.MinOrderAmount,
+		Brands:         c.Brands,
+		Categories:     c.Categories,
+		UsageLimit:     c.UsageLimit,
+		PerUserLimit:   c.PerUserLimit,
+		UsedCount:      c.UsedCount,
+		Active:         c.Active,
+		ExpiresAt:      formatOptionalTime(c.ExpiresAt),
+		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// CouponFromProto конвертирует protobuf модель в доменную модель
+func CouponFromProto(pbCoupon *pb.Coupon) (*Coupon, error) {
+	expiresAt, err := parseOptionalTime(pbCoupon.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: expires_at: %v", ErrInvalidCoupon, err)
+	}
+
+	return &Coupon{
+		Code:           pbCoupon.Code,
+		Description:    pbCoupon.Description,
+		Type:           PromotionType(pbCoupon.Type),
+		Value:          pbCoupon.Value,
+		MinOrderAmount: pbCoupon.MinOrderAmount,
+		Brands:         pbCoupon.Brands,
+		Categories:     pbCoupon.Categories,
+		UsageLimit:     pbCoupon.UsageLimit,
+		PerUserLimit:   pbCoupon.PerUserLimit,
+		Active:         pbCoupon.Active,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+func (r *CouponRedemption) ToProto() *pb.CouponRedemption {
+	return &pb.CouponRedemption{
+		OrderId:   r.OrderID,
+		UserId:    r.UserID,
+		Discount:  r.Discount,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (r *CouponReport) ToProto() *pb.CouponReport {
+	recent := make([]*pb.CouponRedemption, len(r.Recent))
+	for i, redemption := range r.Recent {
+		recent[i] = redemption.ToProto()
+	}
+
+	return &pb.CouponReport{
+		Coupon:        r.Coupon.ToProto(),
+		Redemptions:   r.Redemptions,
+		UniqueUsers:   r.UniqueUsers,
+		TotalDiscount: r.TotalDiscount,
+		Recent:        recent,
+	}
+}
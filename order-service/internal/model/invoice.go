@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotInvoiced - счет выставляется только после оплаты заказа
+var ErrNotInvoiced = errors.New("order is not paid yet")
+
+// InvoiceCustomer - покупатель на момент выставления счета
+type InvoiceCustomer struct {
+	UserID  string `bson:"user_id"`
+	Name    string `bson:"name"`
+	Email   string `bson:"email"`
+	Phone   string `bson:"phone,omitempty"`
+	Address string `bson:"address,omitempty"`
+}
+
+// InvoiceLine - позиция счета с названием товара на момент оплаты
+type InvoiceLine struct {
+	ProductID string `bson:"product_id"`
+	Name      string `bson:"name"`
+	Brand     string `bson:"brand,omitempty"`
+	SKU       string `bson:"sku,omitempty"`
+	// Size - размер в системе, в которой оформлен заказ
+	Size      string  `bson:"size,omitempty"`
+	Quantity  int32   `bson:"quantity"`
+	UnitPrice float64 `bson:"unit_price"`
+	Discount  float64 `bson:"discount,omitempty"`
+	TaxRate   float64 `bson:"tax_rate,omitempty"`
+	Tax       float64 `bson:"tax,omitempty"`
+	// Amount - сумма позиции после скидок, без налога сверху цены
+	Amount float64 `bson:"amount"`
+}
+
+// Invoice - снимок оплаченного заказа, покупателя и товаров. PDF
+// строится из снимка, поэтому счет не меняется, даже если потом
+// поменяются товары или профиль покупателя. Номер присваивается после
+// того, как счет заказа зарезервирован, до этого Number пуст
+type Invoice struct {
+	ID       string          `bson:"_id"`
+	Number   string          `bson:"number,omitempty"`
+	OrderID  string          `bson:"order_id"`
+	IssuedAt time.Time       `bson:"issued_at"`
+	Customer InvoiceCustomer `bson:"customer"`
+	Lines    []InvoiceLine   `bson:"lines"`
+
+	Subtotal       float64 `bson:"subtotal"`
+	DiscountTotal  float64 `bson:"discount_total"`
+	CouponCode     string  `bson:"coupon_code,omitempty"`
+	ShippingMethod string  `bson:"shipping_method,omitempty"`
+	ShippingCost   float64 `bson:"shipping_cost"`
+	ShippingTax    float64 `bson:"shipping_tax,omitempty"`
+	TaxTotal       float64 `bson:"tax_total"`
+	TaxInclusive   bool    `bson:"tax_inclusive,omitempty"`
+	Total          float64 `bson:"total"`
+	PaymentMethod  string  `bson:"payment_method,omitempty"`
+	PaymentID      string  `bson:"payment_id,omitempty"`
+}
+
+// InvoiceNumber форматирует номер счета: год выставления и порядковый номер в году
+func InvoiceNumber(year int, sequence int64) string {
+	return fmt.Sprintf("INV-%d-%06d", year, sequence)
+}
+
+// Filename - имя PDF-файла счета
+func (i *Invoice) Filename() string {
+	return "invoice-" + i.Number + ".pdf"
+}
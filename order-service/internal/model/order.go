@@ -1,8 +1,12 @@
 package model
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 	pb "shoeshop/proto"
+	"shoeshop/sizing"
 )
 
 type OrderStatus string
@@ -15,10 +19,106 @@ const (
 	OrderStatusCanceled OrderStatus = "CANCELED"
 )
 
+// Fit - отзыв покупателя о соответствии размера
+type Fit string
+
+const (
+	FitRunsSmall  Fit = "RUNS_SMALL"
+	FitTrueToSize Fit = "TRUE_TO_SIZE"
+	FitRunsLarge  Fit = "RUNS_LARGE"
+)
+
+func (f Fit) Valid() bool {
+	switch f {
+	case FitRunsSmall, FitTrueToSize, FitRunsLarge:
+		return true
+	}
+	return false
+}
+
 type OrderItem struct {
-	ProductID string  `bson:"product_id"`
-	Quantity  int32   `bson:"quantity"`
-	Price     float64 `bson:"price"`
+	ProductID string      `bson:"product_id"`
+	Quantity  int32       `bson:"quantity"`
+	Price     float64     `bson:"price"`
+	Size      sizing.Size `bson:"size,omitempty"`
+	// Бренд и смещение его таблицы размеров на момент заказа: по ним размер
+	// выводится в нужной системе, даже если бренд потом поменяет таблицу
+	Brand        string       `bson:"brand,omitempty"`
+	SizeOffset   *sizing.Size `bson:"size_offset,omitempty"`
+	Returned     bool   `bson:"returned,omitempty"`
+	ReturnReason string `bson:"return_reason,omitempty"`
+	Fit          Fit    `bson:"fit,omitempty"`
+	// Скидка на всю позицию; Price остается ценой единицы по прайсу
+	Discount   float64            `bson:"discount,omitempty"`
+	Promotions []AppliedPromotion `bson:"promotions,omitempty"`
+	// Налог позиции после скидок и его ставка
+	Tax     float64 `bson:"tax,omitempty"`
+	TaxRate float64 `bson:"tax_rate,omitempty"`
+	// SizeLabel - размер в том виде, в каком его прислал клиент
+	SizeLabel string `bson:"-"`
+}
+
+// SizeTable возвращает таблицу размеров позиции. У старых заказов
+// смещения нет - для них берется встроенная таблица бренда
+func (i OrderItem) SizeTable() *sizing.Table {
+	if i.SizeOffset != nil {
+		return sizing.WithOffset(*i.SizeOffset)
+	}
+	return sizing.TableFor(i.Brand)
+}
+
+// ItemReturnedEvent - событие order.item_returned
+type ItemReturnedEvent struct {
+	OrderID   string
+	UserID    string
+	ProductID string
+	Size      sizing.Size
+	Reason    string
+	Fit       Fit
+}
+
+var (
+	// ErrVersionConflict - заказ изменили после того, как клиент его прочитал
+	ErrVersionConflict   = errors.New("order was modified concurrently")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+	// ErrVersionRequired - изменение без версии, которую клиент прочитал
+	ErrVersionRequired = errors.New("order version is required")
+)
+
+// updatableFields - поля заказа, которые можно указать в маске обновления,
+// и поля документа, которые они меняют
+var updatableFields = map[string]string{
+	"items":            "items",
+	"total_amount":     "total_amount",
+	"status":           "status",
+	"shipping_address": "shipping_address",
+	"payment_method":   "payment_method",
+	"payment_id":       "payment_id",
+	"size_system":      "size_system",
+	"subtotal":         "subtotal",
+	"discount_total":   "discount_total",
+	"coupon_code":      "coupon_code",
+	"shipping_country": "shipping_country",
+	"shipping_method":  "shipping_method",
+	"shipping_cost":    "shipping_cost",
+	"shipping_region":  "shipping_region",
+	"tax_total":        "tax_total",
+	"tax_inclusive":    "tax_inclusive",
+	"shipping_tax":     "shipping_tax",
+}
+
+// UpdateFields переводит пути маски в поля документа. Пустая маска -
+// пустой список, то есть обновление всех полей
+func UpdateFields(paths []string) ([]string, error) {
+	fields := make([]string, 0, len(paths))
+	for _, path := range paths {
+		field, ok := updatableFields[path]
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
 }
 
 type Order struct {
@@ -32,19 +132,70 @@ type Order struct {
 	UpdatedAt       time.Time   `bson:"updated_at"`
 	PaymentMethod   string      `bson:"payment_method"`
 	PaymentID       string      `bson:"payment_id,omitempty"`
+	// Система размеров, в которой покупатель оформил заказ
+	SizeSystem sizing.System `bson:"size_system,omitempty"`
+	// TotalAmount = Subtotal - DiscountTotal + ShippingCost + TaxTotal;
+	// налог, включенный в цены, к итогу не добавляется
+	Subtotal      float64 `bson:"subtotal"`
+	DiscountTotal float64 `bson:"discount_total"`
+	CouponCode    string  `bson:"coupon_code,omitempty"`
+	// Version растет при каждой записи; изменение применяется, только если
+	// заказ все еще в версии, которую прочитал клиент
+	Version int64 `bson:"version"`
+	// Страна назначения (ISO 3166-1 alpha-2) и выбранный способ доставки
+	ShippingCountry string  `bson:"shipping_country,omitempty"`
+	ShippingMethod  string  `bson:"shipping_method,omitempty"`
+	ShippingCost    float64 `bson:"shipping_cost"`
+	// Посылки меняются только через отгрузку и события перевозчика
+	Shipments []Shipment `bson:"shipments,omitempty"`
+	// Регион (штат, провинция) назначения; вместе со страной определяет налог
+	ShippingRegion string  `bson:"shipping_region,omitempty"`
+	TaxTotal       float64 `bson:"tax_total"`
+	TaxInclusive   bool    `bson:"tax_inclusive,omitempty"`
+	// Часть TaxTotal, начисленная на доставку
+	ShippingTax float64 `bson:"shipping_tax,omitempty"`
 }
 
-// ToProto конвертирует доменную модель в protobuf модель
-func (o *Order) ToProto() *pb.Order {
+// ToProto конвертирует доменную модель в protobuf модель. Размеры
+// выводятся в system, а если она не задана - в системе заказа
+func (o *Order) ToProto(system sizing.System) *pb.Order {
+	if system == "" {
+		system = o.SizeSystem
+	}
+	if system == "" {
+		system = sizing.DefaultSystem
+	}
+
 	items := make([]*pb.OrderItem, len(o.Items))
 	for i, item := range o.Items {
+		size := ""
+		if item.Size > 0 {
+			size = item.SizeTable().Format(item.Size, system)
+		}
+		promotions := make([]*pb.AppliedPromotion, len(item.Promotions))
+		for j, promotion := range item.Promotions {
+			promotions[j] = promotion.ToProto()
+		}
 		items[i] = &pb.OrderItem{
-			ProductId: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:    item.Price,
+			ProductId:    item.ProductID,
+			Quantity:     item.Quantity,
+			Price:        item.Price,
+			Size:         size,
+			Returned:     item.Returned,
+			ReturnReason: item.ReturnReason,
+			Fit:          string(item.Fit),
+			Discount:     item.Discount,
+			Promotions:   promotions,
+			Tax:          item.Tax,
+			TaxRate:      item.TaxRate,
 		}
 	}
 
+	shipments := make([]*pb.Shipment, len(o.Shipments))
+	for i := range o.Shipments {
+		shipments[i] = o.Shipments[i].toProto(o, system)
+	}
+
 	return &pb.Order{
 		Id:              o.ID,
 		UserId:          o.UserID,
@@ -56,17 +207,53 @@ func (o *Order) ToProto() *pb.Order {
 		UpdatedAt:       o.UpdatedAt.Format(time.RFC3339),
 		PaymentMethod:   o.PaymentMethod,
 		PaymentId:       o.PaymentID,
+		SizeSystem:      string(system),
+		Subtotal:        o.Subtotal,
+		DiscountTotal:   o.DiscountTotal,
+		CouponCode:      o.CouponCode,
+		Version:         o.Version,
+		ShippingCountry: o.ShippingCountry,
+		ShippingMethod:  o.ShippingMethod,
+		ShippingCost:    o.ShippingCost,
+		Shipments:       shipments,
+		ShippingRegion:  o.ShippingRegion,
+		TaxTotal:        o.TaxTotal,
+		TaxInclusive:    o.TaxInclusive,
+		ShippingTax:     o.ShippingTax,
 	}
 }
 
 // FromProto конвертирует protobuf модель в доменную модель
 func FromProto(pbOrder *pb.Order) (*Order, error) {
+	system, err := sizing.ParseSystem(pbOrder.SizeSystem)
+	if err != nil {
+		return nil, err
+	}
+
+	// Размеры разбираются в сервисе: для этого нужна таблица бренда товара
 	items := make([]OrderItem, len(pbOrder.Items))
 	for i, item := range pbOrder.Items {
+		var promotions []AppliedPromotion
+		for _, promotion := range item.Promotions {
+			promotions = append(promotions, AppliedPromotion{
+				PromotionID: promotion.PromotionId,
+				CouponCode:  promotion.CouponCode,
+				Name:        promotion.Name,
+				Amount:      promotion.Amount,
+			})
+		}
 		items[i] = OrderItem{
-			ProductID: item.ProductId,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+			ProductID:    item.ProductId,
+			Quantity:     item.Quantity,
+			Price:        item.Price,
+			SizeLabel:    item.Size,
+			Returned:     item.Returned,
+			ReturnReason: item.ReturnReason,
+			Fit:          Fit(item.Fit),
+			Discount:     item.Discount,
+			Promotions:   promotions,
+			Tax:          item.Tax,
+			TaxRate:      item.TaxRate,
 		}
 	}
 
@@ -91,5 +278,17 @@ func FromProto(pbOrder *pb.Order) (*Order, error) {
 		UpdatedAt:       updatedAt,
 		PaymentMethod:   pbOrder.PaymentMethod,
 		PaymentID:       pbOrder.PaymentId,
+		SizeSystem:      system,
+		Subtotal:        pbOrder.Subtotal,
+		DiscountTotal:   pbOrder.DiscountTotal,
+		CouponCode:      NormalizeCouponCode(pbOrder.CouponCode),
+		Version:         pbOrder.Version,
+		ShippingCountry: strings.ToUpper(strings.TrimSpace(pbOrder.ShippingCountry)),
+		ShippingMethod:  strings.ToUpper(strings.TrimSpace(pbOrder.ShippingMethod)),
+		ShippingCost:    pbOrder.ShippingCost,
+		ShippingRegion:  strings.ToUpper(strings.TrimSpace(pbOrder.ShippingRegion)),
+		TaxTotal:        pbOrder.TaxTotal,
+		TaxInclusive:    pbOrder.TaxInclusive,
+		ShippingTax:     pbOrder.ShippingTax,
 	}, nil
-} 
\ No newline at end of file
+}
@@ -0,0 +1,224 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "shoeshop/proto"
+)
+
+var ErrInvalidPromotion = errors.New("invalid promotion")
+
+// PromotionType - способ расчета скидки
+type PromotionType string
+
+const (
+	// PromotionPercentage - процент от оставшейся суммы позиции
+	PromotionPercentage PromotionType = "PERCENTAGE"
+	// PromotionFixed - фиксированная сумма с каждой единицы товара
+	PromotionFixed PromotionType = "FIXED"
+	// PromotionBuyXGetY - из каждых BuyQuantity+GetQuantity единиц самые дешевые GetQuantity бесплатно
+	PromotionBuyXGetY PromotionType = "BUY_X_GET_Y"
+)
+
+// Stacking - правило совмещения с другими акциями
+type Stacking string
+
+const (
+	// StackingStackable - акция суммируется с другими суммируемыми акциями
+	StackingStackable Stacking = "STACKABLE"
+	// StackingExclusive - акция применяется только к позициям без других скидок
+	// и после нее другие акции к позиции не применяются
+	StackingExclusive Stacking = "EXCLUSIVE"
+)
+
+type Promotion struct {
+	ID          string        `bson:"_id"`
+	Name        string        `bson:"name"`
+	Campaign    string        `bson:"campaign,omitempty"`
+	Type        PromotionType `bson:"type"`
+	Value       float64       `bson:"value"`
+	BuyQuantity int32         `bson:"buy_quantity,omitempty"`
+	GetQuantity int32         `bson:"get_quantity,omitempty"`
+	// Пустые списки означают акцию на весь каталог
+	ProductIDs []string  `bson:"product_ids,omitempty"`
+	Brands     []string  `bson:"brands,omitempty"`
+	Categories []string  `bson:"categories,omitempty"`
+	Priority   int32     `bson:"priority"`
+	Stacking   Stacking  `bson:"stacking"`
+	Active     bool      `bson:"active"`
+	StartsAt   time.Time `bson:"starts_at,omitempty"`
+	EndsAt     time.Time `bson:"ends_at,omitempty"`
+	CreatedAt  time.Time `bson:"created_at"`
+	UpdatedAt  time.Time `bson:"updated_at"`
+}
+
+// AppliedPromotion - акция или купон, примененные к позиции заказа, и сумма
+// скидки. Для купона вместо PromotionID заполняется CouponCode
+type AppliedPromotion struct {
+	PromotionID string  `bson:"promotion_id,omitempty"`
+	CouponCode  string  `bson:"coupon_code,omitempty"`
+	Name        string  `bson:"name"`
+	Amount      float64 `bson:"amount"`
+}
+
+// Validate проверяет акцию и приводит тип и правило совмещения к верхнему регистру
+func (p *Promotion) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Type = PromotionType(strings.ToUpper(string(p.Type)))
+	p.Stacking = Stacking(strings.ToUpper(string(p.Stacking)))
+	if p.Stacking == "" {
+		p.Stacking = StackingStackable
+	}
+
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPromotion)
+	}
+	switch p.Type {
+	case PromotionPercentage:
+		if p.Value <= 0 || p.Value > 100 {
+			return fmt.Errorf("%w: percentage must be in (0, 100]", ErrInvalidPromotion)
+		}
+	case PromotionFixed:
+		if p.Value <= 0 {
+			return fmt.Errorf("%w: fixed discount must be positive", ErrInvalidPromotion)
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return fmt.Errorf("%w: buy_quantity and get_quantity must be positive", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidPromotion, p.Type)
+	}
+	if p.Stacking != StackingStackable && p.Stacking != StackingExclusive {
+		return fmt.Errorf("%w: unknown stacking %q", ErrInvalidPromotion, p.Stacking)
+	}
+	if !p.StartsAt.IsZero() && !p.EndsAt.IsZero() && !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+// ActiveAt сообщает, действует ли акция в момент now
+func (p *Promotion) ActiveAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if !p.StartsAt.IsZero() && now.Before(p.StartsAt) {
+		return false
+	}
+	if !p.EndsAt.IsZero() && !now.Before(p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Applies сообщает, распространяется ли акция на товар. Товар подходит,
+// если попадает хотя бы в один из заданных списков. categories - категория
+// товара вместе с родительскими, поэтому акция на раздел действует на подразделы
+func (p *Promotion) Applies(productID, brand string, categories []string) bool {
+	if len(p.ProductIDs) == 0 && len(p.Brands) == 0 && len(p.Categories) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+	for _, b := range p.Brands {
+		if strings.EqualFold(b, brand) {
+			return true
+		}
+	}
+	return matchesCategory(p.Categories, categories)
+}
+
+// matchesCategory сообщает, есть ли среди категорий товара одна из заданных
+func matchesCategory(allowed, categories []string) bool {
+	for _, a := range allowed {
+		for _, c := range categories {
+			if strings.EqualFold(a, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToProto конвертирует доменную модель в protobuf модель
+func (p *Promotion) ToProto() *pb.Promotion {
+	return &pb.Promotion{
+		Id:          p.ID,
+		Name:        p.Name,
+		Campaign:    p.Campaign,
+		Type:        string(p.Type),
+		Value:       p.Value,
+		BuyQuantity: p.BuyQuantity,
+		GetQuantity: p.GetQuantity,
+		ProductIds:  p.ProductIDs,
+		Brands:      p.Brands,
+		Categories:  p.Categories,
+		Priority:    p.Priority,
+		Stacking:    string(p.Stacking),
+		Active:      p.Active,
+		StartsAt:    formatOptionalTime(p.StartsAt),
+		EndsAt:      formatOptionalTime(p.EndsAt),
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// PromotionFromProto конвертирует protobuf модель в доменную модель
+func PromotionFromProto(pbPromotion *pb.Promotion) (*Promotion, error) {
+	startsAt, err := parseOptionalTime(pbPromotion.StartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: starts_at: %v", ErrInvalidPromotion, err)
+	}
+	endsAt, err := parseOptionalTime(pbPromotion.EndsAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ends_at: %v", ErrInvalidPromotion, err)
+	}
+
+	return &Promotion{
+		ID:          pbPromotion.Id,
+		Name:        pbPromotion.Name,
+		Campaign:    pbPromotion.Campaign,
+		Type:        PromotionType(pbPromotion.Type),
+		Value:       pbPromotion.Value,
+		BuyQuantity: pbPromotion.BuyQuantity,
+		GetQuantity: pbPromotion.GetQuantity,
+		ProductIDs:  pbPromotion.ProductIds,
+		Brands:      pbPromotion.Brands,
+		Categories:  pbPromotion.Categories,
+		Priority:    pbPromotion.Priority,
+		Stacking:    Stacking(pbPromotion.Stacking),
+		Active:      pbPromotion.Active,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+	}, nil
+}
+
+func (a AppliedPromotion) ToProto() *pb.AppliedPromotion {
+	return &pb.AppliedPromotion{
+		PromotionId: a.PromotionID,
+		CouponCode:  a.CouponCode,
+		Name:        a.Name,
+		Amount:      a.Amount,
+	}
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
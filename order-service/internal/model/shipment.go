@@ -0,0 +1,228 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+// ShipmentStatus - статус посылки по последнему событию перевозчика
+type ShipmentStatus string
+
+const (
+	ShipmentStatusLabelCreated   ShipmentStatus = "LABEL_CREATED"
+	ShipmentStatusInTransit      ShipmentStatus = "IN_TRANSIT"
+	ShipmentStatusOutForDelivery ShipmentStatus = "OUT_FOR_DELIVERY"
+	ShipmentStatusDelivered      ShipmentStatus = "DELIVERED"
+	ShipmentStatusException      ShipmentStatus = "EXCEPTION"
+)
+
+func (s ShipmentStatus) Valid() bool {
+	switch s {
+	case ShipmentStatusLabelCreated, ShipmentStatusInTransit, ShipmentStatusOutForDelivery,
+		ShipmentStatusDelivered, ShipmentStatusException:
+		return true
+	}
+	return false
+}
+
+// ErrInvalidShipment - в отгрузке или событии перевозчика ошибка клиента
+var ErrInvalidShipment = errors.New("invalid shipment")
+
+type ShipmentItem struct {
+	ProductID string      `bson:"product_id"`
+	Size      sizing.Size `bson:"size,omitempty"`
+	Quantity  int32       `bson:"quantity"`
+	// SizeLabel - размер в том виде, в каком его прислал клиент
+	SizeLabel string `bson:"-"`
+}
+
+type ShipmentEvent struct {
+	Status      ShipmentStatus `bson:"status"`
+	Description string         `bson:"description,omitempty"`
+	Location    string         `bson:"location,omitempty"`
+	OccurredAt  time.Time      `bson:"occurred_at"`
+}
+
+// Shipment - посылка заказа с трек-номером перевозчика
+type Shipment struct {
+	ID             string          `bson:"id"`
+	Carrier        string          `bson:"carrier"`
+	TrackingNumber string          `bson:"tracking_number"`
+	Items          []ShipmentItem  `bson:"items"`
+	Status         ShipmentStatus  `bson:"status"`
+	Events         []ShipmentEvent `bson:"events"`
+	CreatedAt      time.Time       `bson:"created_at"`
+	DeliveredAt    *time.Time      `bson:"delivered_at,omitempty"`
+}
+
+// NormalizeCarrier приводит код перевозчика к виду, в котором он хранится
+func NormalizeCarrier(carrier string) string {
+	return strings.ToUpper(strings.TrimSpace(carrier))
+}
+
+// Record добавляет событие в историю посылки в порядке времени и
+// пересчитывает статус. Повтор уже записанного события (перевозчики
+// присылают их повторно) ничего не меняет и возвращает false
+func (s *Shipment) Record(event ShipmentEvent) bool {
+	for _, recorded := range s.Events {
+		if recorded.Status == event.Status && recorded.OccurredAt.Equal(event.OccurredAt) {
+			return false
+		}
+	}
+
+	s.Events = append(s.Events, event)
+	sort.SliceStable(s.Events, func(i, j int) bool {
+		return s.Events[i].OccurredAt.Before(s.Events[j].OccurredAt)
+	})
+
+	latest := s.Events[len(s.Events)-1]
+	s.Status = latest.Status
+	if s.Status == ShipmentStatusDelivered {
+		deliveredAt := latest.OccurredAt
+		s.DeliveredAt = &deliveredAt
+	} else {
+		s.DeliveredAt = nil
+	}
+	return true
+}
+
+// Shipment находит посылку заказа по перевозчику и трек-номеру
+func (o *Order) Shipment(carrier, trackingNumber string) *Shipment {
+	for i := range o.Shipments {
+		if o.Shipments[i].Carrier == carrier && o.Shipments[i].TrackingNumber == trackingNumber {
+			return &o.Shipments[i]
+		}
+	}
+	return nil
+}
+
+// Unshipped возвращает позиции заказа с количеством, которое еще не
+// попало ни в одну посылку
+func (o *Order) Unshipped() []ShipmentItem {
+	shipped := make(map[shipmentKey]int32)
+	for _, shipment := range o.Shipments {
+		for _, item := range shipment.Items {
+			shipped[shipmentKey{item.ProductID, item.Size}] += item.Quantity
+		}
+	}
+
+	var unshipped []ShipmentItem
+	for _, item := range o.Items {
+		key := shipmentKey{item.ProductID, item.Size}
+		// Одна и та же пара товар-размер может быть в заказе несколькими позициями
+		quantity := item.Quantity - shipped[key]
+		shipped[key] = max(shipped[key]-item.Quantity, 0)
+		if quantity > 0 {
+			unshipped = append(unshipped, ShipmentItem{
+				ProductID: item.ProductID,
+				Size:      item.Size,
+				Quantity:  quantity,
+			})
+		}
+	}
+	return unshipped
+}
+
+// Delivered сообщает, что все позиции заказа отправлены и все посылки доставлены
+func (o *Order) Delivered() bool {
+	if len(o.Shipments) == 0 || len(o.Unshipped()) > 0 {
+		return false
+	}
+	for _, shipment := range o.Shipments {
+		if shipment.Status != ShipmentStatusDelivered {
+			return false
+		}
+	}
+	return true
+}
+
+type shipmentKey struct {
+	productID string
+	size      sizing.Size
+}
+
+func (s *Shipment) toProto(order *Order, system sizing.System) *pb.Shipment {
+	items := make([]*pb.ShipmentItem, len(s.Items))
+	for i, item := range s.Items {
+		items[i] = &pb.ShipmentItem{
+			ProductId: item.ProductID,
+			Size:      order.formatSize(item.ProductID, item.Size, system),
+			Quantity:  item.Quantity,
+		}
+	}
+
+	events := make([]*pb.ShipmentEvent, len(s.Events))
+	for i, event := range s.Events {
+		events[i] = &pb.ShipmentEvent{
+			Status:      string(event.Status),
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt.Format(time.RFC3339),
+		}
+	}
+
+	deliveredAt := ""
+	if s.DeliveredAt != nil {
+		deliveredAt = s.DeliveredAt.Format(time.RFC3339)
+	}
+
+	return &pb.Shipment{
+		Id:             s.ID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Items:          items,
+		Status:         string(s.Status),
+		Events:         events,
+		CreatedAt:      s.CreatedAt.Format(time.RFC3339),
+		DeliveredAt:    deliveredAt,
+	}
+}
+
+// formatSize выводит размер посылки по таблице бренда из позиции заказа
+func (o *Order) formatSize(productID string, size sizing.Size, system sizing.System) string {
+	if size == 0 {
+		return ""
+	}
+	for _, item := range o.Items {
+		if item.ProductID == productID && item.Size == size {
+			return item.SizeTable().Format(size, system)
+		}
+	}
+	return sizing.Default().Format(size, system)
+}
+
+// ShipmentEventFromProto проверяет событие перевозчика; без времени
+// событие считается случившимся сейчас
+func ShipmentEventFromProto(event *pb.ShipmentEvent) (ShipmentEvent, error) {
+	if event == nil {
+		return ShipmentEvent{}, fmt.Errorf("%w: event is required", ErrInvalidShipment)
+	}
+
+	status := ShipmentStatus(strings.ToUpper(strings.TrimSpace(event.Status)))
+	if !status.Valid() {
+		return ShipmentEvent{}, fmt.Errorf("%w: unknown status %q", ErrInvalidShipment, event.Status)
+	}
+
+	occurredAt := time.Now()
+	if event.OccurredAt != "" {
+		var err error
+		occurredAt, err = time.Parse(time.RFC3339, event.OccurredAt)
+		if err != nil {
+			return ShipmentEvent{}, fmt.Errorf("%w: occurred_at: %v", ErrInvalidShipment, err)
+		}
+	}
+
+	return ShipmentEvent{
+		Status:      status,
+		Description: event.Description,
+		Location:    event.Location,
+		// В Mongo время хранится с точностью до миллисекунд
+		OccurredAt: occurredAt.UTC().Truncate(time.Millisecond),
+	}, nil
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/order-service/internal/model"
+)
+
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error)
+	Update(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error)
+	GetByCode(ctx context.Context, code string) (*model.Coupon, error)
+	List(ctx context.Context, activeAt *time.Time) ([]*model.Coupon, error)
+	UserRedemptions(ctx context.Context, code, userID string) (int32, error)
+	Redeem(ctx context.Context, coupon *model.Coupon, userID string, now time.Time) (bool, error)
+	Release(ctx context.Context, code, userID string) error
+	RecordRedemption(ctx context.Context, redemption *model.CouponRedemption) error
+	Report(ctx context.Context, code string, limit, offset int64) (*model.CouponReport, error)
+}
+
+type mongoCouponRepository struct {
+	client      *mongo.Client
+	coupons     *mongo.Collection
+	usage       *mongo.Collection
+	redemptions *mongo.Collection
+}
+
+// couponUsage - счетчик использований купона одним пользователем
+type couponUsage struct {
+	ID         string `bson:"_id"`
+	CouponCode string `bson:"coupon_code"`
+	UserID     string `bson:"user_id"`
+	Count      int32  `bson:"count"`
+}
+
+func NewCouponRepository(uri string) (CouponRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	redemptions := db.Collection("coupon_redemptions")
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "coupon_code", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err = redemptions.Indexes().CreateMany(context.Background(), indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoCouponRepository{
+		client:      client,
+		coupons:     db.Collection("coupons"),
+		usage:       db.Collection("coupon_usage"),
+		redemptions: redemptions,
+	}, nil
+}
+
+func (r *mongoCouponRepository) Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	now := time.Now()
+	coupon.UsedCount = 0
+	coupon.CreatedAt = now
+	coupon.UpdatedAt = now
+
+	if _, err := r.coupons.InsertOne(ctx, coupon); err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// Update меняет условия купона; счетчик использований и дата создания
+// не трогаются, чтобы не затереть параллельные списания
+func (r *mongoCouponRepository) Update(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	set := bson.M{
+		"description":      coupon.Description,
+		"type":             coupon.Type,
+		"value":            coupon.Value,
+		"min_order_amount": coupon.MinOrderAmount,
+		"brands":           coupon.Brands,
+		"categories":       coupon.Categories,
+		"usage_limit":      coupon.UsageLimit,
+		"per_user_limit":   coupon.PerUserLimit,
+		"active":           coupon.Active,
+		"updated_at":       time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if coupon.ExpiresAt.IsZero() {
+		update["$unset"] = bson.M{"expires_at": ""}
+	} else {
+		set["expires_at"] = coupon.ExpiresAt
+	}
+
+	var updatedCoupon model.Coupon
+	err := r.coupons.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": coupon.Code},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updatedCoupon)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedCoupon, nil
+}
+
+func (r *mongoCouponRepository) GetByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	var coupon model.Coupon
+	if err := r.coupons.FindOne(ctx, bson.M{"_id": code}).Decode(&coupon); err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// List возвращает все купоны или, если задан activeAt, только действующие в этот момент
+func (r *mongoCouponRepository) List(ctx context.Context, activeAt *time.Time) ([]*model.Coupon, error) {
+	filter := bson.M{}
+	if activeAt != nil {
+		filter["active"] = true
+		filter["$or"] = bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": *activeAt}},
+		}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.coupons.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	coupons := []*model.Coupon{}
+	if err := cursor.All(ctx, &coupons); err != nil {
+		return nil, err
+	}
+	return coupons, nil
+}
+
+func (r *mongoCouponRepository) UserRedemptions(ctx context.Context, code, userID string) (int32, error) {
+	var usage couponUsage
+	err := r.usage.FindOne(ctx, bson.M{"_id": usageID(code, userID)}).Decode(&usage)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return usage.Count, nil
+}
+
+// Redeem списывает одно использование купона. Оба лимита проверяются в
+// условии атомарного обновления, поэтому параллельные заказы их не превысят.
+// Возвращает false, если купон исчерпан, истек или выключен
+func (r *mongoCouponRepository) Redeem(ctx context.Context, coupon *model.Coupon, userID string, now time.Time) (bool, error) {
+	if coupon.PerUserLimit > 0 {
+		// Если счетчик уже на лимите, фильтр не совпадет и upsert попробует
+		// вставить документ с тем же _id - это и есть отказ
+		_, err := r.usage.UpdateOne(
+			ctx,
+			bson.M{"_id": usageID(coupon.Code, userID), "count": bson.M{"$lt": coupon.PerUserLimit}},
+			bson.M{
+				"$inc":         bson.M{"count": 1},
+				"$setOnInsert": bson.M{"coupon_code": coupon.Code, "user_id": userID},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	filter := bson.M{
+		"_id":    coupon.Code,
+		"active": true,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": now}},
+		},
+		"$expr": bson.M{"$or": bson.A{
+			bson.M{"$lte": bson.A{"$usage_limit", 0}},
+			bson.M{"$lt": bson.A{"$used_count", "$usage_limit"}},
+		}},
+	}
+	result, err := r.coupons.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"used_count": 1}})
+	if err == nil && result.MatchedCount == 1 {
+		return true, nil
+	}
+
+	// Общий лимит не пустил - возвращаем использование пользователя
+	if coupon.PerUserLimit > 0 {
+		if releaseErr := r.releaseUser(ctx, coupon.Code, userID); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}
+	return false, err
+}
+
+// Release возвращает использование купона, если заказ так и не был создан
+func (r *mongoCouponRepository) Release(ctx context.Context, code, userID string) error {
+	_, err := r.coupons.UpdateOne(
+		ctx,
+		bson.M{"_id": code, "used_count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"used_count": -1}},
+	)
+	if err != nil {
+		return err
+	}
+	return r.releaseUser(ctx, code, userID)
+}
+
+func (r *mongoCouponRepository) releaseUser(ctx context.Context, code, userID string) error {
+	_, err := r.usage.UpdateOne(
+		ctx,
+		bson.M{"_id": usageID(code, userID), "count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"count": -1}},
+	)
+	return err
+}
+
+func (r *mongoCouponRepository) RecordRedemption(ctx context.Context, redemption *model.CouponRedemption) error {
+	redemption.CreatedAt = time.Now()
+	_, err := r.redemptions.InsertOne(ctx, redemption)
+	return err
+}
+
+func (r *mongoCouponRepository) Report(ctx context.Context, code string, limit, offset int64) (*model.CouponReport, error) {
+	coupon, err := r.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	report := &model.CouponReport{Coupon: coupon}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"coupon_code": code}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":            nil,
+			"redemptions":    bson.M{"$sum": 1},
+			"total_discount": bson.M{"$sum": "$discount"},
+			"users":          bson.M{"$addToSet": "$user_id"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"redemptions":    1,
+			"total_discount": 1,
+			"unique_users":   bson.M{"$size": "$users"},
+		}}},
+	}
+	cursor, err := r.redemptions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var totals []struct {
+		Redemptions   int64   `bson:"redemptions"`
+		TotalDiscount float64 `bson:"total_discount"`
+		UniqueUsers   int64   `bson:"unique_users"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+	if len(totals) > 0 {
+		report.Redemptions = totals[0].Redemptions
+		report.TotalDiscount = totals[0].TotalDiscount
+		report.UniqueUsers = totals[0].UniqueUsers
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	recentCursor, err := r.redemptions.Find(ctx, bson.M{"coupon_code": code}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer recentCursor.Close(ctx)
+
+	report.Recent = []*model.CouponRedemption{}
+	if err := recentCursor.All(ctx, &report.Recent); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func usageID(code, userID string) string {
+	return code + ":" + userID
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/order-service/internal/model"
+)
+
+type InvoiceRepository interface {
+	// Reserve сохраняет счет без номера, если у заказа счета еще нет;
+	// иначе возвращает имеющийся счет
+	Reserve(ctx context.Context, invoice *model.Invoice) (*model.Invoice, error)
+	// AssignNumber присваивает счету следующий номер года, если номера у
+	// него еще нет, и возвращает пронумерованный счет
+	AssignNumber(ctx context.Context, invoice *model.Invoice) (*model.Invoice, error)
+	GetByOrder(ctx context.Context, orderID string) (*model.Invoice, error)
+}
+
+type mongoInvoiceRepository struct {
+	client   *mongo.Client
+	invoices *mongo.Collection
+	counters *mongo.Collection
+}
+
+func NewInvoiceRepository(uri string) (InvoiceRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	invoices := db.Collection("invoices")
+	counters := db.Collection("invoice_counters")
+
+	_, err = invoices.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// Зарезервированные счета еще без номера
+			Keys: bson.D{{Key: "number", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"number": bson.M{"$type": "string"}}),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoInvoiceRepository{
+		client:   client,
+		invoices: invoices,
+		counters: counters,
+	}, nil
+}
+
+// Reserve вставляет счет по уникальному order_id, поэтому из параллельных
+// запросов счет создает только один, а остальные получают его счет
+func (r *mongoInvoiceRepository) Reserve(ctx context.Context, invoice *model.Invoice) (*model.Invoice, error) {
+	data, err := bson.Marshal(invoice)
+	if err != nil {
+		return nil, err
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "order_id")
+	delete(fields, "number")
+
+	var reserved model.Invoice
+	err = r.invoices.FindOneAndUpdate(
+		ctx,
+		bson.M{"order_id": invoice.OrderID},
+		bson.M{"$setOnInsert": fields},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&reserved)
+	if mongo.IsDuplicateKeyError(err) {
+		// Счет этого заказа успел вставить параллельный запрос
+		return r.GetByOrder(ctx, invoice.OrderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reserved, nil
+}
+
+// invoiceCounter - счетчик номеров счетов за год. Pending - номер, взятый
+// для заказа, но еще не записанный в его счет: пока он есть, новый номер
+// никто не берет
+type invoiceCounter struct {
+	Sequence int64          `bson:"sequence"`
+	Pending  *pendingNumber `bson:"pending,omitempty"`
+}
+
+type pendingNumber struct {
+	OrderID  string `bson:"order_id"`
+	Sequence int64  `bson:"sequence"`
+}
+
+// AssignNumber берет следующий номер года и записывает его в счет.
+// Номер увеличивается одной операцией вместе с записью, для какого он
+// заказа, поэтому номер, взятый упавшим или параллельным запросом, не
+// теряется: следующий запрос сначала записывает его, а потом берет свой
+func (r *mongoInvoiceRepository) AssignNumber(ctx context.Context, invoice *model.Invoice) (*model.Invoice, error) {
+	year := invoice.IssuedAt.Year()
+	counterID := fmt.Sprintf("invoice-%d", year)
+	next := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$sequence", 0}}, 1}}
+
+	for {
+		current, err := r.GetByOrder(ctx, invoice.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if current.Number != "" {
+			return current, nil
+		}
+
+		var counter invoiceCounter
+		err = r.counters.FindOneAndUpdate(
+			ctx,
+			bson.M{"_id": counterID, "pending": bson.M{"$exists": false}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"sequence": next,
+				"pending":  bson.M{"order_id": bson.M{"$literal": invoice.OrderID}, "sequence": next},
+			}}}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if mongo.IsDuplicateKeyError(err) {
+			// Номер уже взят для другого заказа и еще не записан
+			err = r.counters.FindOne(ctx, bson.M{"_id": counterID}).Decode(&counter)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if counter.Pending == nil {
+			continue
+		}
+
+		if err := r.completePending(ctx, counterID, year, counter.Pending); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// completePending записывает взятый номер в счет заказа и освобождает
+// счетчик. Если у счета уже другой номер, взятый номер возвращается в
+// счетчик: пока он взят, других номеров никто не брал
+func (r *mongoInvoiceRepository) completePending(ctx context.Context, counterID string, year int, pending *pendingNumber) error {
+	number := model.InvoiceNumber(year, pending.Sequence)
+	result, err := r.invoices.UpdateOne(ctx,
+		bson.M{"order_id": pending.OrderID, "number": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"number": number}},
+	)
+	if err != nil {
+		return err
+	}
+
+	release := bson.M{"$unset": bson.M{"pending": ""}}
+	if result.MatchedCount == 0 {
+		// Номер мог записать параллельный запрос; иначе он не понадобился
+		owner, err := r.GetByOrder(ctx, pending.OrderID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		if owner == nil || owner.Number != number {
+			release["$inc"] = bson.M{"sequence": -1}
+		}
+	}
+
+	_, err = r.counters.UpdateOne(ctx,
+		bson.M{"_id": counterID, "pending.order_id": pending.OrderID, "pending.sequence": pending.Sequence},
+		release,
+	)
+	return err
+}
+
+func (r *mongoInvoiceRepository) GetByOrder(ctx context.Context, orderID string) (*model.Invoice, error) {
+	var invoice model.Invoice
+	if err := r.invoices.FindOne(ctx, bson.M{"order_id": orderID}).Decode(&invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
@@ -8,16 +8,22 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"shoeshop/ids"
 	"shoeshop/order-service/internal/model"
+	"shoeshop/sizing"
 )
 
 type OrderRepository interface {
 	Create(ctx context.Context, order *model.Order) (*model.Order, error)
 	GetByID(ctx context.Context, id string) (*model.Order, error)
-	Update(ctx context.Context, order *model.Order) (*model.Order, error)
+	Update(ctx context.Context, order *model.Order, fields []string) (*model.Order, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, userID string) ([]*model.Order, error)
-	UpdateStatus(ctx context.Context, id string, status model.OrderStatus) error
+	UpdateStatus(ctx context.Context, id string, status model.OrderStatus, version int64) error
+	FindDelivered(ctx context.Context, userID, productID string) (*model.Order, error)
+	ReferencedProducts(ctx context.Context, productIDs []string) ([]string, error)
+	MarkItemReturned(ctx context.Context, orderID, productID string, size sizing.Size, reason string, fit model.Fit) (*model.Order, error)
+	FindByTracking(ctx context.Context, carrier, trackingNumber string) (*model.Order, error)
 }
 
 type mongoRepository struct {
@@ -44,6 +50,15 @@ func NewMongoRepository(uri string) (OrderRepository, error) {
 		{
 			Keys: bson.D{{Key: "created_at", Value: -1}},
 		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "items.product_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "items.product_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "shipments.tracking_number", Value: 1}, {Key: "shipments.carrier", Value: 1}},
+		},
 	}
 
 	_, err = collection.Indexes().CreateMany(context.Background(), indexes)
@@ -51,23 +66,40 @@ func NewMongoRepository(uri string) (OrderRepository, error) {
 		return nil, err
 	}
 
-	return &mongoRepository{
+	repo := &mongoRepository{
 		client:     client,
 		collection: collection,
-	}, nil
+	}
+	// Заказы, которым ID выдал Mongo, получают строковый ID
+	if err := ids.MigrateObjectIDs(context.Background(), collection); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateVersions(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// migrateVersions выдает версию 1 заказам, созданным до появления версий
+func (r *mongoRepository) migrateVersions(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": 1}},
+	)
+	return err
 }
 
 func (r *mongoRepository) Create(ctx context.Context, order *model.Order) (*model.Order, error) {
 	now := time.Now()
 	order.CreatedAt = now
 	order.UpdatedAt = now
+	order.Version = 1
+	order.ID = ids.New()
 
-	result, err := r.collection.InsertOne(ctx, order)
-	if err != nil {
+	if _, err := r.collection.InsertOne(ctx, order); err != nil {
 		return nil, err
 	}
-
-	order.ID = result.InsertedID.(string)
 	return order, nil
 }
 
@@ -80,24 +112,61 @@ func (r *mongoRepository) GetByID(ctx context.Context, id string) (*model.Order,
 	return &order, nil
 }
 
-func (r *mongoRepository) Update(ctx context.Context, order *model.Order) (*model.Order, error) {
+// Update записывает поля fields, а при пустом списке - весь заказ, кроме посылок.
+// Запись применяется, только если заказ все еще в версии order.Version
+func (r *mongoRepository) Update(ctx context.Context, order *model.Order, fields []string) (*model.Order, error) {
 	order.UpdatedAt = time.Now()
 
+	data, err := bson.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+	var set bson.M
+	if err := bson.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	delete(set, "_id")
+	delete(set, "version")
+	if len(fields) > 0 {
+		selected := bson.M{"updated_at": set["updated_at"]}
+		for _, field := range fields {
+			selected[field] = set[field]
+		}
+		set = selected
+	} else {
+		delete(set, "shipments")
+	}
+
 	result := r.collection.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": order.ID},
-		bson.M{"$set": order},
+		bson.M{"_id": order.ID, "version": order.Version},
+		bson.M{"$set": set, "$inc": bson.M{"version": 1}},
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
 
 	var updatedOrder model.Order
 	if err := result.Decode(&updatedOrder); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.versionError(ctx, order.ID)
+		}
 		return nil, err
 	}
 
 	return &updatedOrder, nil
 }
 
+// versionError отличает устаревшую версию от несуществующего заказа
+func (r *mongoRepository) versionError(ctx context.Context, id string) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return model.ErrVersionConflict
+}
+
 func (r *mongoRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
@@ -124,14 +193,117 @@ func (r *mongoRepository) List(ctx context.Context, userID string) ([]*model.Ord
 	return orders, nil
 }
 
-func (r *mongoRepository) UpdateStatus(ctx context.Context, id string, status model.OrderStatus) error {
+func (r *mongoRepository) UpdateStatus(ctx context.Context, id string, status model.OrderStatus, version int64) error {
 	update := bson.M{
 		"$set": bson.M{
 			"status":     status,
 			"updated_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
-	return err
-} 
\ No newline at end of file
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "version": version}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return r.versionError(ctx, id)
+	}
+	return nil
+}
+
+// FindDelivered возвращает последний доставленный заказ пользователя с этим товаром
+func (r *mongoRepository) FindDelivered(ctx context.Context, userID, productID string) (*model.Order, error) {
+	filter := bson.M{
+		"user_id":          userID,
+		"items.product_id": productID,
+		"status":           model.OrderStatusDelivered,
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var order model.Order
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&order)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ReferencedProducts возвращает те из productIDs, что есть хотя бы в одном заказе
+func (r *mongoRepository) ReferencedProducts(ctx context.Context, productIDs []string) ([]string, error) {
+	filter := bson.M{"items.product_id": bson.M{"$in": productIDs}}
+	values, err := r.collection.Distinct(ctx, "items.product_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Distinct отдает все товары найденных заказов, оставляем только запрошенные
+	requested := make(map[string]bool, len(productIDs))
+	for _, id := range productIDs {
+		requested[id] = true
+	}
+	var referenced []string
+	for _, value := range values {
+		if id, ok := value.(string); ok && requested[id] {
+			referenced = append(referenced, id)
+		}
+	}
+	return referenced, nil
+}
+
+// MarkItemReturned отмечает возврат позиции доставленного заказа. Если
+// подходящей невозвращенной позиции нет, возвращает mongo.ErrNoDocuments
+func (r *mongoRepository) MarkItemReturned(ctx context.Context, orderID, productID string, size sizing.Size, reason string, fit model.Fit) (*model.Order, error) {
+	item := bson.M{
+		"product_id": productID,
+		"returned":   bson.M{"$ne": true},
+	}
+	if size > 0 {
+		item["size"] = size
+	}
+	filter := bson.M{
+		"_id":    orderID,
+		"status": model.OrderStatusDelivered,
+		"items":  bson.M{"$elemMatch": item},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"items.$.returned":      true,
+			"items.$.return_reason": reason,
+			"items.$.fit":           fit,
+			"updated_at":            time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var order model.Order
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&order)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindByTracking находит заказ с посылкой перевозчика carrier с этим трек-номером
+func (r *mongoRepository) FindByTracking(ctx context.Context, carrier, trackingNumber string) (*model.Order, error) {
+	filter := bson.M{
+		"shipments": bson.M{"$elemMatch": bson.M{
+			"carrier":         carrier,
+			"tracking_number": trackingNumber,
+		}},
+	}
+
+	var order model.Order
+	if err := r.collection.FindOne(ctx, filter).Decode(&order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
@@ -11,6 +11,7 @@ type EventPublisher interface {
 	PublishOrderCreated(order *model.Order) error
 	PublishOrderUpdated(order *model.Order) error
 	PublishOrderStatusChanged(orderID string, status model.OrderStatus) error
+	PublishItemReturned(event *model.ItemReturnedEvent) error
 	Close() error
 }
 
@@ -56,6 +57,14 @@ func (n *natsClient) PublishOrderStatusChanged(orderID string, status model.Orde
 	return n.conn.Publish("order.status_changed", data)
 }
 
+func (n *natsClient) PublishItemReturned(event *model.ItemReturnedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.conn.Publish("order.item_returned", data)
+}
+
 func (n *natsClient) Close() error {
 	n.conn.Close()
 	return nil
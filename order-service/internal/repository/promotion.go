@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/order-service/internal/model"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error)
+	Update(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*model.Promotion, error)
+	ListActive(ctx context.Context, now time.Time) ([]*model.Promotion, error)
+}
+
+type mongoPromotionRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewPromotionRepository(uri string) (PromotionRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	collection := client.Database("shoeshop").Collection("promotions")
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "active", Value: 1}, {Key: "priority", Value: -1}},
+		},
+	}
+
+	_, err = collection.Indexes().CreateMany(context.Background(), indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoPromotionRepository{
+		client:     client,
+		collection: collection,
+	}, nil
+}
+
+func (r *mongoPromotionRepository) Create(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	now := time.Now()
+	promotion.CreatedAt = now
+	promotion.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// Update заменяет акцию целиком, сохраняя дату создания
+func (r *mongoPromotionRepository) Update(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	var existing model.Promotion
+	if err := r.collection.FindOne(ctx, bson.M{"_id": promotion.ID}).Decode(&existing); err != nil {
+		return nil, err
+	}
+	promotion.CreatedAt = existing.CreatedAt
+	promotion.UpdatedAt = time.Now()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": promotion.ID}, promotion)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return promotion, nil
+}
+
+func (r *mongoPromotionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoPromotionRepository) List(ctx context.Context) ([]*model.Promotion, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// ListActive возвращает включенные акции, срок которых охватывает now
+func (r *mongoPromotionRepository) ListActive(ctx context.Context, now time.Time) ([]*model.Promotion, error) {
+	filter := bson.M{
+		"active": true,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"starts_at": bson.M{"$exists": false}},
+				bson.M{"starts_at": bson.M{"$lte": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"ends_at": bson.M{"$exists": false}},
+				bson.M{"ends_at": bson.M{"$gt": now}},
+			}},
+		},
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *mongoPromotionRepository) find(ctx context.Context, filter bson.M) ([]*model.Promotion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	promotions := []*model.Promotion{}
+	if err := cursor.All(ctx, &promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
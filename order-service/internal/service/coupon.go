@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/order-service/internal/model"
+	"shoeshop/order-service/internal/repository"
+)
+
+const (
+	defaultReportSize = 20
+	maxReportSize     = 100
+)
+
+var (
+	ErrCouponExists        = errors.New("coupon code already exists")
+	ErrCouponNotApplicable = errors.New("coupon cannot be applied")
+	ErrCouponUsedUp        = errors.New("coupon usage limit reached")
+)
+
+type CouponService interface {
+	CreateCoupon(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error)
+	ListCoupons(ctx context.Context, activeOnly bool) ([]*model.Coupon, error)
+	GetCouponReport(ctx context.Context, code string, limit, offset int64) (*model.CouponReport, error)
+}
+
+type couponService struct {
+	repo repository.CouponRepository
+}
+
+func NewCouponService(repo repository.CouponRepository) CouponService {
+	return &couponService{
+		repo: repo,
+	}
+}
+
+func (s *couponService) CreateCoupon(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	if err := coupon.Validate(); err != nil {
+		return nil, err
+	}
+
+	createdCoupon, err := s.repo.Create(ctx, coupon)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrCouponExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+	return createdCoupon, nil
+}
+
+// UpdateCoupon меняет условия купона. Уменьшение лимита ниже числа
+// использований просто закрывает купон для новых заказов
+func (s *couponService) UpdateCoupon(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	if err := coupon.Validate(); err != nil {
+		return nil, err
+	}
+
+	updatedCoupon, err := s.repo.Update(ctx, coupon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+	return updatedCoupon, nil
+}
+
+func (s *couponService) ListCoupons(ctx context.Context, activeOnly bool) ([]*model.Coupon, error) {
+	var activeAt *time.Time
+	if activeOnly {
+		now := time.Now()
+		activeAt = &now
+	}
+
+	coupons, err := s.repo.List(ctx, activeAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coupons: %w", err)
+	}
+	return coupons, nil
+}
+
+func (s *couponService) GetCouponReport(ctx context.Context, code string, limit, offset int64) (*model.CouponReport, error) {
+	if limit <= 0 {
+		limit = defaultReportSize
+	}
+	if limit > maxReportSize {
+		limit = maxReportSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	report, err := s.repo.Report(ctx, model.NormalizeCouponCode(code), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build coupon report: %w", err)
+	}
+	return report, nil
+}
+
+// checkCoupon находит купон заказа и проверяет, что его еще можно
+// использовать. Окончательно лимиты проверяет атомарный Redeem
+func checkCoupon(ctx context.Context, repo repository.CouponRepository, code, userID string, now time.Time) (*model.Coupon, error) {
+	coupon, err := repo.GetByCode(ctx, code)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("%w: unknown coupon %s", ErrCouponNotApplicable, code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	if !coupon.UsableAt(now) {
+		return nil, fmt.Errorf("%w: coupon %s is expired or inactive", ErrCouponNotApplicable, code)
+	}
+	if coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit {
+		return nil, ErrCouponUsedUp
+	}
+	if coupon.PerUserLimit > 0 && userID != "" {
+		used, err := repo.UserRedemptions(ctx, code, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get coupon usage: %w", err)
+		}
+		if used >= coupon.PerUserLimit {
+			return nil, ErrCouponUsedUp
+		}
+	}
+	return coupon, nil
+}
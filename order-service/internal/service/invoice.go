@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/ids"
+	"shoeshop/order-service/internal/invoice"
+	"shoeshop/order-service/internal/model"
+	pb "shoeshop/proto"
+)
+
+// GetInvoice возвращает счет заказа и его PDF. Заказы, оплаченные до
+// появления счетов, получают счет при первом запросе
+func (s *orderService) GetInvoice(ctx context.Context, orderID string) (*model.Invoice, []byte, error) {
+	inv, err := s.invoices.GetByOrder(ctx, orderID)
+	if err == mongo.ErrNoDocuments || err == nil && inv.Number == "" {
+		order, err := s.repo.GetByID(ctx, orderID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get order: %w", err)
+		}
+		inv, err = s.issueInvoice(ctx, order)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	pdf, err := invoice.Render(inv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render invoice %s: %w", inv.Number, err)
+	}
+	return inv, pdf, nil
+}
+
+// invoiceAttachment выставляет счет оплаченного заказа и возвращает его
+// вместе с PDF как вложением письма
+func (s *orderService) invoiceAttachment(ctx context.Context, order *model.Order) (*model.Invoice, *pb.Attachment, error) {
+	inv, err := s.issueInvoice(ctx, order)
+	if err != nil {
+		return nil, nil, err
+	}
+	pdf, err := invoice.Render(inv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render invoice %s: %w", inv.Number, err)
+	}
+	return inv, &pb.Attachment{
+		Filename:    inv.Filename(),
+		ContentType: "application/pdf",
+		Content:     pdf,
+	}, nil
+}
+
+// issueInvoice выставляет счет оплаченного заказа, если его еще нет.
+// Сначала счет резервируется за заказом, а номер присваивается уже
+// зарезервированному счету: ошибка получения покупателя и параллельные
+// запросы не оставляют пропусков в нумерации
+func (s *orderService) issueInvoice(ctx context.Context, order *model.Order) (*model.Invoice, error) {
+	inv, err := s.invoices.GetByOrder(ctx, order.ID)
+	if err == mongo.ErrNoDocuments {
+		switch order.Status {
+		case model.OrderStatusPaid, model.OrderStatusShipped, model.OrderStatusDelivered:
+		default:
+			return nil, fmt.Errorf("%w: order is %s", model.ErrNotInvoiced, order.Status)
+		}
+
+		snapshot, err := s.invoiceSnapshot(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		inv, err = s.invoices.Reserve(ctx, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve invoice: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if inv.Number != "" {
+		return inv, nil
+	}
+
+	// Номер присваивает любой запрос, заставший счет без номера: так счет
+	// упавшего запроса тоже получает номер
+	numbered, err := s.invoices.AssignNumber(ctx, inv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign invoice number: %w", err)
+	}
+	return numbered, nil
+}
+
+// invoiceSnapshot собирает счет из заказа, профиля покупателя и товаров
+func (s *orderService) invoiceSnapshot(ctx context.Context, order *model.Order) (*model.Invoice, error) {
+	user, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	customer := user.GetUser()
+	name := strings.TrimSpace(customer.GetFirstName() + " " + customer.GetLastName())
+	if name == "" {
+		name = customer.GetUsername()
+	}
+
+	// Размеры выводятся в системе, в которой оформлен заказ
+	pbOrder := order.ToProto("")
+	products := make(map[string]*pb.Product)
+	lines := make([]model.InvoiceLine, len(order.Items))
+	for i, item := range order.Items {
+		product, ok := products[item.ProductID]
+		if !ok {
+			resp, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductID})
+			if err != nil {
+				// Счет все равно выставляется, вместо названия будет ID товара
+				fmt.Printf("failed to get product %s for invoice: %v\n", item.ProductID, err)
+			}
+			product = resp.GetProduct()
+			products[item.ProductID] = product
+		}
+
+		lines[i] = model.InvoiceLine{
+			ProductID: item.ProductID,
+			Name:      product.GetName(),
+			Brand:     product.GetBrand(),
+			SKU:       product.GetSku(),
+			Size:      pbOrder.Items[i].Size,
+			Quantity:  item.Quantity,
+			UnitPrice: item.Price,
+			Discount:  item.Discount,
+			TaxRate:   item.TaxRate,
+			Tax:       item.Tax,
+			Amount:    roundMoney(item.Price*float64(item.Quantity) - item.Discount),
+		}
+	}
+
+	return &model.Invoice{
+		ID:       ids.New(),
+		OrderID:  order.ID,
+		IssuedAt: time.Now().UTC(),
+		Customer: model.InvoiceCustomer{
+			UserID:  order.UserID,
+			Name:    name,
+			Email:   customer.GetEmail(),
+			Phone:   customer.GetPhone(),
+			Address: customer.GetShippingAddress(),
+		},
+		Lines:          lines,
+		Subtotal:       order.Subtotal,
+		DiscountTotal:  order.DiscountTotal,
+		CouponCode:     order.CouponCode,
+		ShippingMethod: order.ShippingMethod,
+		ShippingCost:   order.ShippingCost,
+		ShippingTax:    order.ShippingTax,
+		TaxTotal:       order.TaxTotal,
+		TaxInclusive:   order.TaxInclusive,
+		Total:          order.TotalAmount,
+		PaymentMethod:  order.PaymentMethod,
+		PaymentID:      order.PaymentID,
+	}, nil
+}
@@ -2,77 +2,130 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"shoeshop/order-service/internal/model"
 	"shoeshop/order-service/internal/repository"
+	"shoeshop/order-service/internal/shipping"
+	"shoeshop/order-service/internal/tax"
 	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+var (
+	ErrInvalidReturn   = errors.New("invalid return request")
+	ErrNotReturnable   = errors.New("order item cannot be returned")
+	ErrInvalidQuantity = errors.New("item quantity must be positive")
 )
 
 type OrderService interface {
 	CreateOrder(ctx context.Context, order *model.Order) (*model.Order, error)
 	GetOrder(ctx context.Context, id string) (*model.Order, error)
-	UpdateOrder(ctx context.Context, order *model.Order) (*model.Order, error)
+	UpdateOrder(ctx context.Context, order *model.Order, paths []string) (*model.Order, error)
 	ListOrders(ctx context.Context, userID string) ([]*model.Order, error)
-	UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) error
+	UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus, version int64) error
+	VerifyPurchase(ctx context.Context, userID, productID string) (*model.Order, error)
+	ReferencedProducts(ctx context.Context, productIDs []string) ([]string, error)
+	ReturnOrderItem(ctx context.Context, orderID, productID, size string, system sizing.System, reason string, fit model.Fit) (*model.Order, error)
+	QuoteOrder(ctx context.Context, order *model.Order) (*model.Order, error)
+	QuoteShipping(ctx context.Context, order *model.Order) ([]shipping.Option, error)
+	CreateShipment(ctx context.Context, orderID, carrier, trackingNumber string, items []model.ShipmentItem, system sizing.System) (*model.Order, error)
+	RecordShipmentEvent(ctx context.Context, carrier, trackingNumber string, event model.ShipmentEvent) (*model.Order, error)
+	GetInvoice(ctx context.Context, orderID string) (*model.Invoice, []byte, error)
 }
 
 type orderService struct {
 	repo          repository.OrderRepository
+	promotions    repository.PromotionRepository
+	coupons       repository.CouponRepository
+	invoices      repository.InvoiceRepository
 	publisher     repository.EventPublisher
 	productClient pb.ProductServiceClient
 	userClient    pb.UserServiceClient
 	emailClient   pb.EmailServiceClient
+	rates         *shipping.RateTable
+	taxes         *tax.RateTable
 }
 
 func NewOrderService(
 	repo repository.OrderRepository,
+	promotions repository.PromotionRepository,
+	coupons repository.CouponRepository,
+	invoices repository.InvoiceRepository,
 	publisher repository.EventPublisher,
 	productClient pb.ProductServiceClient,
 	userClient pb.UserServiceClient,
 	emailClient pb.EmailServiceClient,
+	rates *shipping.RateTable,
+	taxes *tax.RateTable,
 ) OrderService {
 	return &orderService{
 		repo:          repo,
+		promotions:    promotions,
+		coupons:       coupons,
+		invoices:      invoices,
 		publisher:     publisher,
 		productClient: productClient,
 		userClient:    userClient,
 		emailClient:   emailClient,
+		rates:         rates,
+		taxes:         taxes,
 	}
 }
 
 func (s *orderService) CreateOrder(ctx context.Context, order *model.Order) (*model.Order, error) {
 	// Проверяем существование пользователя
-	_, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
+	user, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify user: %w", err)
 	}
 
-	// Проверяем наличие товаров и их цены
-	var totalAmount float64
-	for _, item := range order.Items {
-		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductID})
+	coupon, couponDiscount, err := s.priceOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = model.OrderStatusPending
+
+	// Купон списываем до создания заказа, чтобы лимит не превысили параллельные заказы
+	if coupon != nil {
+		redeemed, err := s.coupons.Redeem(ctx, coupon, order.UserID, time.Now())
 		if err != nil {
-			return nil, fmt.Errorf("failed to get product %s: %w", item.ProductID, err)
+			return nil, fmt.Errorf("failed to redeem coupon: %w", err)
 		}
-
-		if product.Product.Stock < item.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product %s", item.ProductID)
+		if !redeemed {
+			return nil, ErrCouponUsedUp
 		}
-
-		item.Price = product.Product.Price
-		totalAmount += item.Price * float64(item.Quantity)
 	}
 
-	order.TotalAmount = totalAmount
-	order.Status = model.OrderStatusPending
-
 	// Создаем заказ
 	createdOrder, err := s.repo.Create(ctx, order)
 	if err != nil {
+		if coupon != nil {
+			if releaseErr := s.coupons.Release(ctx, coupon.Code, order.UserID); releaseErr != nil {
+				fmt.Printf("failed to release coupon %s: %v\n", coupon.Code, releaseErr)
+			}
+		}
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	if coupon != nil {
+		err := s.coupons.RecordRedemption(ctx, &model.CouponRedemption{
+			ID:         createdOrder.ID,
+			CouponCode: coupon.Code,
+			OrderID:    createdOrder.ID,
+			UserID:     createdOrder.UserID,
+			Discount:   couponDiscount,
+		})
+		if err != nil {
+			fmt.Printf("failed to record coupon redemption: %v\n", err)
+		}
+	}
+
 	// Публикуем событие
 	if err := s.publisher.PublishOrderCreated(createdOrder); err != nil {
 		// Логируем ошибку, но не прерываем выполнение
@@ -81,7 +134,8 @@ func (s *orderService) CreateOrder(ctx context.Context, order *model.Order) (*mo
 
 	// Отправляем email о создании заказа
 	_, err = s.emailClient.SendOrderConfirmation(ctx, &pb.OrderEmailRequest{
-		Order: createdOrder.ToProto(),
+		Order:     createdOrder.ToProto(""),
+		Recipient: user.GetUser().GetEmail(),
 	})
 	if err != nil {
 		fmt.Printf("failed to send order confirmation email: %v\n", err)
@@ -90,12 +144,132 @@ func (s *orderService) CreateOrder(ctx context.Context, order *model.Order) (*mo
 	return createdOrder, nil
 }
 
+// QuoteOrder считает цены и скидки так же, как CreateOrder, но заказ не
+// сохраняет и купон не списывает - так корзина показывает итог до оформления
+func (s *orderService) QuoteOrder(ctx context.Context, order *model.Order) (*model.Order, error) {
+	if _, _, err := s.priceOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// priceOrder считает позиции заказа со скидками и добавляет к итогу
+// доставку выбранным способом и налог. Возвращает примененный купон и его скидку
+func (s *orderService) priceOrder(ctx context.Context, order *model.Order) (*model.Coupon, float64, error) {
+	lines, coupon, discount, err := s.priceItems(ctx, order)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.applyShipping(order, lines); err != nil {
+		return nil, 0, err
+	}
+	s.applyTax(order, lines)
+	return coupon, discount, nil
+}
+
+// priceItems проверяет наличие товаров, берет цены из каталога и применяет
+// действующие акции, а затем купон заказа; примененные скидки запоминаются
+// в каждой позиции
+func (s *orderService) priceItems(ctx context.Context, order *model.Order) ([]*pricingLine, *model.Coupon, float64, error) {
+	lines := make([]*pricingLine, 0, len(order.Items))
+	for i := range order.Items {
+		item := &order.Items[i]
+		// Отрицательное количество вернуло бы товар на склад и дало бы
+		// отрицательную сумму позиции
+		if item.Quantity <= 0 {
+			return nil, nil, 0, fmt.Errorf("%w: product %s", ErrInvalidQuantity, item.ProductID)
+		}
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{
+			Id:         item.ProductID,
+			SizeSystem: string(sizing.CM),
+		})
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to get product %s: %w", item.ProductID, err)
+		}
+
+		// Архивный товар находится по ID, но больше не продается
+		if product.Product.DeletedAt != "" {
+			return nil, nil, 0, fmt.Errorf("product %s is no longer available", item.ProductID)
+		}
+
+		if product.Product.Stock < item.Quantity {
+			return nil, nil, 0, fmt.Errorf("insufficient stock for product %s", item.ProductID)
+		}
+
+		// Размер нужен для возвратов и рекомендаций размера
+		if err := resolveSize(item, product.Product, order.SizeSystem); err != nil {
+			return nil, nil, 0, err
+		}
+
+		item.Price = product.Product.Price
+		lines = append(lines, &pricingLine{
+			item:       item,
+			brand:      product.Product.Brand,
+			categories: productCategories(product.Product),
+			markdown:   product.Product.Discount,
+			parcel:     parcelOf(item, product.Product),
+		})
+	}
+
+	now := time.Now()
+	promotions, err := s.promotions.ListActive(ctx, now)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get promotions: %w", err)
+	}
+	applyPromotions(order, lines, promotions, now)
+
+	if order.CouponCode == "" {
+		return lines, nil, 0, nil
+	}
+	coupon, err := checkCoupon(ctx, s.coupons, order.CouponCode, order.UserID, now)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	discount, err := applyCoupon(order, lines, coupon)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return lines, coupon, discount, nil
+}
+
 func (s *orderService) GetOrder(ctx context.Context, id string) (*model.Order, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *orderService) UpdateOrder(ctx context.Context, order *model.Order) (*model.Order, error) {
-	updatedOrder, err := s.repo.Update(ctx, order)
+// UpdateOrder меняет поля из маски paths; пустая маска перезаписывает заказ целиком
+func (s *orderService) UpdateOrder(ctx context.Context, order *model.Order, paths []string) (*model.Order, error) {
+	fields, err := model.UpdateFields(paths)
+	if err != nil {
+		return nil, err
+	}
+	if order.Version == 0 {
+		return nil, model.ErrVersionRequired
+	}
+	if order.Status == model.OrderStatusDelivered && (len(fields) == 0 || slices.Contains(fields, "status")) {
+		if err := s.checkDelivered(ctx, order.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Позиции перезаписываются целиком, поэтому размеры разбираем заново
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: product %s", ErrInvalidQuantity, item.ProductID)
+		}
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{
+			Id:         item.ProductID,
+			SizeSystem: string(sizing.CM),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product %s: %w", item.ProductID, err)
+		}
+		if err := resolveSize(item, product.Product, order.SizeSystem); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedOrder, err := s.repo.Update(ctx, order, fields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order: %w", err)
 	}
@@ -111,27 +285,184 @@ func (s *orderService) ListOrders(ctx context.Context, userID string) ([]*model.
 	return s.repo.List(ctx, userID)
 }
 
-func (s *orderService) UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) error {
-	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
+// UpdateOrderStatus меняет статус; version - ожидаемая версия заказа
+func (s *orderService) UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus, version int64) error {
+	if version == 0 {
+		return model.ErrVersionRequired
+	}
+	if status == model.OrderStatusDelivered {
+		if err := s.checkDelivered(ctx, id); err != nil {
+			return err
+		}
 	}
 
-	if err := s.publisher.PublishOrderStatusChanged(id, status); err != nil {
-		fmt.Printf("failed to publish order status changed event: %v\n", err)
+	if err := s.repo.UpdateStatus(ctx, id, status, version); err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
 	}
 
-	// Отправляем email о изменении статуса заказа
 	order, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get order for email notification: %w", err)
 	}
+	s.notifyStatusChanged(ctx, order)
 
-	_, err = s.emailClient.SendOrderStatusUpdate(ctx, &pb.OrderEmailRequest{
-		Order: order.ToProto(),
+	return nil
+}
+
+// notifyStatusChanged публикует новый статус заказа и сообщает его покупателю
+func (s *orderService) notifyStatusChanged(ctx context.Context, order *model.Order) {
+	if err := s.publisher.PublishOrderStatusChanged(order.ID, order.Status); err != nil {
+		fmt.Printf("failed to publish order status changed event: %v\n", err)
+	}
+
+	// К письму об оплате прикладываем счет; без счета письмо все равно уходит.
+	// Адрес покупателя берем из счета, а без счета - из профиля
+	var attachments []*pb.Attachment
+	var recipient string
+	if order.Status == model.OrderStatusPaid {
+		inv, attachment, err := s.invoiceAttachment(ctx, order)
+		if err != nil {
+			fmt.Printf("failed to issue invoice for order %s: %v\n", order.ID, err)
+		} else {
+			attachments = append(attachments, attachment)
+			recipient = inv.Customer.Email
+		}
+	}
+	if recipient == "" {
+		user, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
+		if err != nil {
+			fmt.Printf("failed to get customer of order %s: %v\n", order.ID, err)
+			return
+		}
+		recipient = user.GetUser().GetEmail()
+	}
+
+	// Отправляем email о изменении статуса заказа
+	_, err := s.emailClient.SendOrderStatusUpdate(ctx, &pb.OrderEmailRequest{
+		Order:       order.ToProto(""),
+		Attachments: attachments,
+		Recipient:   recipient,
 	})
 	if err != nil {
 		fmt.Printf("failed to send order status update email: %v\n", err)
 	}
+}
+
+// VerifyPurchase возвращает доставленный заказ пользователя с товаром или nil, если такого нет
+func (s *orderService) VerifyPurchase(ctx context.Context, userID, productID string) (*model.Order, error) {
+	order, err := s.repo.FindDelivered(ctx, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify purchase: %w", err)
+	}
+	return order, nil
+}
+
+// ReferencedProducts возвращает товары, на которые ссылаются заказы; такие
+// товары product-service не удаляет окончательно
+func (s *orderService) ReferencedProducts(ctx context.Context, productIDs []string) ([]string, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	referenced, err := s.repo.ReferencedProducts(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find referenced products: %w", err)
+	}
+	return referenced, nil
+}
+
+// ReturnOrderItem оформляет возврат позиции доставленного заказа вместе с
+// отзывом о посадке, который review-service учитывает в рекомендациях размера
+func (s *orderService) ReturnOrderItem(ctx context.Context, orderID, productID, size string, system sizing.System, reason string, fit model.Fit) (*model.Order, error) {
+	if orderID == "" || productID == "" {
+		return nil, fmt.Errorf("%w: order_id and product_id are required", ErrInvalidReturn)
+	}
+	if fit != "" && !fit.Valid() {
+		return nil, fmt.Errorf("%w: unknown fit %q", ErrInvalidReturn, fit)
+	}
+
+	// Размер из запроса сопоставляем с позициями заказа по таблице их бренда
+	var itemSize sizing.Size
+	if size != "" {
+		order, err := s.repo.GetByID(ctx, orderID)
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotReturnable
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order: %w", err)
+		}
+		if system == "" {
+			system = order.SizeSystem
+		}
+		itemSize, err = itemSizeOf(order, productID, size, system)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidReturn, err)
+		}
+	}
 
+	order, err := s.repo.MarkItemReturned(ctx, orderID, productID, itemSize, reason, fit)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotReturnable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to return order item: %w", err)
+	}
+
+	// Размер берем из заказа: в запросе его могли не указать
+	returnedSize := itemSize
+	for _, item := range order.Items {
+		if item.ProductID == productID && item.Returned && (itemSize == 0 || item.Size == itemSize) {
+			returnedSize = item.Size
+			break
+		}
+	}
+
+	event := &model.ItemReturnedEvent{
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		ProductID: productID,
+		Size:      returnedSize,
+		Reason:    reason,
+		Fit:       fit,
+	}
+	if err := s.publisher.PublishItemReturned(event); err != nil {
+		fmt.Printf("failed to publish item returned event: %v\n", err)
+	}
+
+	return order, nil
+}
+
+// resolveSize сопоставляет размер из запроса с размерами товара и
+// запоминает бренд и таблицу, по которой размер будет выводиться
+func resolveSize(item *model.OrderItem, product *pb.Product, system sizing.System) error {
+	offset := sizing.Size(product.SizeOffset)
+	item.Brand = product.Brand
+	item.SizeOffset = &offset
+	if item.SizeLabel == "" {
+		return nil
+	}
+
+	// Сантиметры от бренда не зависят, поэтому подойдет любая таблица
+	available, err := sizing.Default().ParseAll(product.Sizes, sizing.CM)
+	if err != nil {
+		return fmt.Errorf("invalid sizes of product %s: %w", product.Id, err)
+	}
+	size, err := item.SizeTable().Resolve(item.SizeLabel, system, available)
+	if err != nil {
+		return fmt.Errorf("product %s: %w", product.Id, err)
+	}
+	item.Size = size
 	return nil
-} 
\ No newline at end of file
+}
+
+func itemSizeOf(order *model.Order, productID, label string, system sizing.System) (sizing.Size, error) {
+	for _, item := range order.Items {
+		if item.ProductID != productID || item.Size == 0 {
+			continue
+		}
+		size, err := item.SizeTable().Resolve(label, system, []sizing.Size{item.Size})
+		if err == nil {
+			return size, nil
+		}
+	}
+	return 0, fmt.Errorf("order has no item of product %s in size %s", productID, label)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"shoeshop/order-service/internal/model"
+	"shoeshop/order-service/internal/shipping"
+	pb "shoeshop/proto"
+)
+
+// pricingLine - позиция заказа вместе с данными товара, по которым
+// определяется, какие акции к ней относятся
+type pricingLine struct {
+	item  *model.OrderItem
+	brand string
+	// categories - slug категории товара и всех ее родителей
+	categories []string
+	// markdown - уценка товара долей цены из каталога
+	markdown float64
+	// promoted - к позиции применена хотя бы одна акция
+	promoted bool
+	// exclusive - к позиции применена эксклюзивная акция, другие не применяются
+	exclusive bool
+	// parcel - упаковка позиции для расчета доставки
+	parcel shipping.Item
+}
+
+func (l *pricingLine) remaining() float64 {
+	return l.item.Price*float64(l.item.Quantity) - l.item.Discount
+}
+
+// productDiscountName - название уценки товара в списке скидок позиции
+const productDiscountName = "Product discount"
+
+// applyPromotions рассчитывает скидки позиций и итоги заказа. Сначала
+// применяется уценка товара, затем акции по убыванию приоритета, каждая к
+// сумме, оставшейся после предыдущих, поэтому процентные скидки не
+// превращаются в сумму процентов
+func applyPromotions(order *model.Order, lines []*pricingLine, promotions []*model.Promotion, now time.Time) {
+	active := make([]*model.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.ActiveAt(now) {
+			active = append(active, promotion)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].Priority != active[j].Priority {
+			return active[i].Priority > active[j].Priority
+		}
+		return active[i].ID < active[j].ID
+	})
+
+	for _, line := range lines {
+		line.item.Discount = 0
+		line.item.Promotions = nil
+		line.promoted = false
+		line.exclusive = false
+
+		// Уценка - часть цены товара, а не акция: эксклюзивные акции ее не отменяют.
+		// Больше суммы позиции она не бывает, даже если в каталоге неверная доля
+		amount := roundMoney(line.item.Price * float64(line.item.Quantity) * line.markdown)
+		amount = math.Min(amount, roundMoney(line.remaining()))
+		if amount > 0 {
+			line.item.Discount = amount
+			line.item.Promotions = []model.AppliedPromotion{{
+				Name:   productDiscountName,
+				Amount: amount,
+			}}
+		}
+	}
+
+	for _, promotion := range active {
+		var eligible []*pricingLine
+		for _, line := range lines {
+			if line.exclusive || line.remaining() <= 0 {
+				continue
+			}
+			if promotion.Stacking == model.StackingExclusive && line.promoted {
+				continue
+			}
+			if promotion.Applies(line.item.ProductID, line.brand, line.categories) {
+				eligible = append(eligible, line)
+			}
+		}
+
+		for line, amount := range promotionDiscounts(promotion, eligible) {
+			amount = math.Min(roundMoney(amount), roundMoney(line.remaining()))
+			if amount <= 0 {
+				continue
+			}
+			line.item.Discount = roundMoney(line.item.Discount + amount)
+			line.item.Promotions = append(line.item.Promotions, model.AppliedPromotion{
+				PromotionID: promotion.ID,
+				Name:        promotion.Name,
+				Amount:      amount,
+			})
+			line.promoted = true
+			if promotion.Stacking == model.StackingExclusive {
+				line.exclusive = true
+			}
+		}
+	}
+
+	updateTotals(order, lines)
+}
+
+// applyCoupon применяется после акций к оставшейся сумме подходящих позиций;
+// позиции с эксклюзивной акцией купон не затрагивает. Скидка распределяется
+// по позициям пропорционально их сумме. Возвращает сумму скидки купона
+func applyCoupon(order *model.Order, lines []*pricingLine, coupon *model.Coupon) (float64, error) {
+	if order.TotalAmount < coupon.MinOrderAmount {
+		return 0, fmt.Errorf("%w: order total must be at least %.2f", ErrCouponNotApplicable, coupon.MinOrderAmount)
+	}
+
+	var eligible []*pricingLine
+	var base float64
+	for _, line := range lines {
+		if line.exclusive || line.remaining() <= 0 || !coupon.Applies(line.brand, line.categories) {
+			continue
+		}
+		eligible = append(eligible, line)
+		base += line.remaining()
+	}
+	if len(eligible) == 0 {
+		return 0, fmt.Errorf("%w: no eligible items in the order", ErrCouponNotApplicable)
+	}
+
+	amount := coupon.Value
+	if coupon.Type == model.PromotionPercentage {
+		amount = base * coupon.Value / 100
+	}
+	amount = roundMoney(math.Min(amount, base))
+
+	// Последняя позиция забирает остаток, чтобы сумма частей совпала после округления
+	left := amount
+	for i, line := range eligible {
+		share := roundMoney(amount * line.remaining() / base)
+		if i == len(eligible)-1 || share > left {
+			share = left
+		}
+		left = roundMoney(left - share)
+		if share <= 0 {
+			continue
+		}
+		line.item.Discount = roundMoney(line.item.Discount + share)
+		line.item.Promotions = append(line.item.Promotions, model.AppliedPromotion{
+			CouponCode: coupon.Code,
+			Name:       coupon.Code,
+			Amount:     share,
+		})
+	}
+
+	order.CouponCode = coupon.Code
+	updateTotals(order, lines)
+	return amount, nil
+}
+
+// productCategories возвращает категорию товара и ее родителей по хлебным
+// крошкам; для товара вне дерева остается только его категория
+func productCategories(product *pb.Product) []string {
+	categories := []string{product.GetCategory()}
+	for _, breadcrumb := range product.GetBreadcrumbs() {
+		categories = append(categories, breadcrumb.GetSlug())
+	}
+	return categories
+}
+
+func updateTotals(order *model.Order, lines []*pricingLine) {
+	var subtotal, discount float64
+	for _, line := range lines {
+		subtotal += line.item.Price * float64(line.item.Quantity)
+		discount += line.item.Discount
+	}
+	order.Subtotal = roundMoney(subtotal)
+	order.DiscountTotal = roundMoney(discount)
+	order.TotalAmount = roundMoney(subtotal - discount)
+}
+
+// promotionDiscounts считает скидку акции для каждой подходящей позиции
+func promotionDiscounts(promotion *model.Promotion, lines []*pricingLine) map[*pricingLine]float64 {
+	discounts := make(map[*pricingLine]float64, len(lines))
+	switch promotion.Type {
+	case model.PromotionPercentage:
+		for _, line := range lines {
+			discounts[line] = line.remaining() * promotion.Value / 100
+		}
+	case model.PromotionFixed:
+		for _, line := range lines {
+			discounts[line] = promotion.Value * float64(line.item.Quantity)
+		}
+	case model.PromotionBuyXGetY:
+		// Единицы всех подходящих позиций считаются вместе, бесплатными
+		// становятся самые дешевые - так выгоднее магазину и привычнее покупателю
+		var units int32
+		for _, line := range lines {
+			units += line.item.Quantity
+		}
+		free := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+
+		byPrice := append([]*pricingLine(nil), lines...)
+		sort.SliceStable(byPrice, func(i, j int) bool {
+			return unitPrice(byPrice[i]) < unitPrice(byPrice[j])
+		})
+		for _, line := range byPrice {
+			if free == 0 {
+				break
+			}
+			n := line.item.Quantity
+			if n > free {
+				n = free
+			}
+			discounts[line] = unitPrice(line) * float64(n)
+			free -= n
+		}
+	}
+	return discounts
+}
+
+// unitPrice - цена единицы с учетом уже примененных скидок
+func unitPrice(line *pricingLine) float64 {
+	if line.item.Quantity == 0 {
+		return 0
+	}
+	return line.remaining() / float64(line.item.Quantity)
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shoeshop/ids"
+	"shoeshop/order-service/internal/model"
+	"shoeshop/order-service/internal/repository"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error)
+	UpdatePromotion(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error)
+	DeletePromotion(ctx context.Context, id string) error
+	ListPromotions(ctx context.Context, activeOnly bool) ([]*model.Promotion, error)
+}
+
+type promotionService struct {
+	repo repository.PromotionRepository
+}
+
+func NewPromotionService(repo repository.PromotionRepository) PromotionService {
+	return &promotionService{
+		repo: repo,
+	}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+	promotion.ID = ids.New()
+
+	createdPromotion, err := s.repo.Create(ctx, promotion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return createdPromotion, nil
+}
+
+// UpdatePromotion заменяет акцию целиком. Уже оформленные заказы хранят
+// примененные скидки и не пересчитываются
+func (s *promotionService) UpdatePromotion(ctx context.Context, promotion *model.Promotion) (*model.Promotion, error) {
+	if promotion.ID == "" {
+		return nil, fmt.Errorf("%w: id is required", model.ErrInvalidPromotion)
+	}
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+
+	updatedPromotion, err := s.repo.Update(ctx, promotion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+	return updatedPromotion, nil
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+	return nil
+}
+
+func (s *promotionService) ListPromotions(ctx context.Context, activeOnly bool) ([]*model.Promotion, error) {
+	var promotions []*model.Promotion
+	var err error
+	if activeOnly {
+		promotions, err = s.repo.ListActive(ctx, time.Now())
+	} else {
+		promotions, err = s.repo.List(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+	return promotions, nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/ids"
+	"shoeshop/order-service/internal/model"
+	"shoeshop/sizing"
+)
+
+var (
+	ErrNotShippable     = errors.New("order cannot be shipped")
+	ErrShipmentExists   = errors.New("tracking number is already used by another shipment")
+	ErrShipmentsPending = errors.New("order has shipments that are not delivered")
+)
+
+// Сколько раз перечитываем заказ, если его изменили между чтением и записью
+const maxShipmentAttempts = 3
+
+// CreateShipment отправляет позиции оплаченного заказа одной посылкой.
+// Без позиций в посылку идет все, что еще не отправлено. Первая посылка
+// переводит заказ в SHIPPED
+func (s *orderService) CreateShipment(ctx context.Context, orderID, carrier, trackingNumber string, items []model.ShipmentItem, system sizing.System) (*model.Order, error) {
+	carrier = model.NormalizeCarrier(carrier)
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if orderID == "" || carrier == "" || trackingNumber == "" {
+		return nil, fmt.Errorf("%w: order_id, carrier and tracking_number are required", model.ErrInvalidShipment)
+	}
+
+	_, err := s.repo.FindByTracking(ctx, carrier, trackingNumber)
+	if err == nil {
+		return nil, ErrShipmentExists
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("failed to check tracking number: %w", err)
+	}
+
+	order, previous, err := s.saveShipments(ctx, orderID, func(order *model.Order) (bool, error) {
+		if order.Status != model.OrderStatusPaid && order.Status != model.OrderStatusShipped {
+			return false, fmt.Errorf("%w: order is %s", ErrNotShippable, order.Status)
+		}
+
+		shipmentItems, err := shipmentItemsOf(order, items, system)
+		if err != nil {
+			return false, err
+		}
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		shipment := model.Shipment{
+			ID:             ids.New(),
+			Carrier:        carrier,
+			TrackingNumber: trackingNumber,
+			Items:          shipmentItems,
+			CreatedAt:      now,
+		}
+		shipment.Record(model.ShipmentEvent{Status: model.ShipmentStatusLabelCreated, OccurredAt: now})
+
+		order.Shipments = append(order.Shipments, shipment)
+		order.Status = model.OrderStatusShipped
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != previous {
+		s.notifyStatusChanged(ctx, order)
+	}
+	return order, nil
+}
+
+// RecordShipmentEvent записывает событие перевозчика в историю посылки.
+// Заказ становится DELIVERED, когда отправлены все позиции и доставлены
+// все посылки
+func (s *orderService) RecordShipmentEvent(ctx context.Context, carrier, trackingNumber string, event model.ShipmentEvent) (*model.Order, error) {
+	carrier = model.NormalizeCarrier(carrier)
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if carrier == "" || trackingNumber == "" {
+		return nil, fmt.Errorf("%w: carrier and tracking_number are required", model.ErrInvalidShipment)
+	}
+
+	found, err := s.repo.FindByTracking(ctx, carrier, trackingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shipment %s %s: %w", carrier, trackingNumber, err)
+	}
+
+	order, previous, err := s.saveShipments(ctx, found.ID, func(order *model.Order) (bool, error) {
+		shipment := order.Shipment(carrier, trackingNumber)
+		if shipment == nil {
+			return false, fmt.Errorf("failed to find shipment %s %s: %w", carrier, trackingNumber, mongo.ErrNoDocuments)
+		}
+		if !shipment.Record(event) {
+			return false, nil
+		}
+
+		// Отмененный или уже доставленный заказ события перевозчика не меняют
+		if order.Status == model.OrderStatusShipped && order.Delivered() {
+			order.Status = model.OrderStatusDelivered
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != previous {
+		s.notifyStatusChanged(ctx, order)
+	}
+	return order, nil
+}
+
+// saveShipments меняет посылки и статус заказа функцией change и
+// записывает их с проверкой версии. Если заказ успели изменить, он
+// перечитывается и change применяется заново. Возвращает заказ и его
+// статус до изменения
+func (s *orderService) saveShipments(ctx context.Context, orderID string, change func(order *model.Order) (bool, error)) (*model.Order, model.OrderStatus, error) {
+	for attempt := 1; ; attempt++ {
+		order, err := s.repo.GetByID(ctx, orderID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get order: %w", err)
+		}
+		previous := order.Status
+
+		changed, err := change(order)
+		if err != nil {
+			return nil, "", err
+		}
+		if !changed {
+			return order, previous, nil
+		}
+
+		updatedOrder, err := s.repo.Update(ctx, order, []string{"shipments", "status"})
+		if errors.Is(err, model.ErrVersionConflict) && attempt < maxShipmentAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to save shipments: %w", err)
+		}
+		return updatedOrder, previous, nil
+	}
+}
+
+// checkDelivered не дает вручную отметить доставленным заказ, посылки
+// которого еще в пути. Заказы без посылок отмечаются как раньше
+func (s *orderService) checkDelivered(ctx context.Context, orderID string) error {
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if len(order.Shipments) > 0 && !order.Delivered() {
+		return ErrShipmentsPending
+	}
+	return nil
+}
+
+// shipmentItemsOf сопоставляет позиции посылки с неотправленными
+// позициями заказа; без позиций в посылку идет все неотправленное
+func shipmentItemsOf(order *model.Order, items []model.ShipmentItem, system sizing.System) ([]model.ShipmentItem, error) {
+	unshipped := order.Unshipped()
+	if len(unshipped) == 0 {
+		return nil, fmt.Errorf("%w: every item is already shipped", ErrNotShippable)
+	}
+	if len(items) == 0 {
+		return unshipped, nil
+	}
+	if system == "" {
+		system = order.SizeSystem
+	}
+
+	type itemKey struct {
+		productID string
+		size      sizing.Size
+	}
+	remaining := make(map[itemKey]int32, len(unshipped))
+	sizes := make(map[string][]sizing.Size)
+	for _, item := range unshipped {
+		remaining[itemKey{item.ProductID, item.Size}] += item.Quantity
+		if !slices.Contains(sizes[item.ProductID], item.Size) {
+			sizes[item.ProductID] = append(sizes[item.ProductID], item.Size)
+		}
+	}
+
+	shipmentItems := make([]model.ShipmentItem, 0, len(items))
+	for _, item := range items {
+		if item.ProductID == "" || item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: every item needs a product_id and a positive quantity", model.ErrInvalidShipment)
+		}
+
+		// Размер можно не указывать, если товар остался неотправленным в одном размере
+		switch {
+		case item.SizeLabel != "":
+			size, err := itemSizeOf(order, item.ProductID, item.SizeLabel, system)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", model.ErrInvalidShipment, err)
+			}
+			item.Size = size
+		case len(sizes[item.ProductID]) > 1:
+			return nil, fmt.Errorf("%w: size of product %s is required", model.ErrInvalidShipment, item.ProductID)
+		case len(sizes[item.ProductID]) == 1:
+			item.Size = sizes[item.ProductID][0]
+		}
+
+		key := itemKey{item.ProductID, item.Size}
+		if item.Quantity > remaining[key] {
+			return nil, fmt.Errorf("%w: only %d of product %s left to ship", model.ErrInvalidShipment, remaining[key], item.ProductID)
+		}
+		remaining[key] -= item.Quantity
+		shipmentItems = append(shipmentItems, item)
+	}
+	return shipmentItems, nil
+}
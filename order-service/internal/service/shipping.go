@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"shoeshop/order-service/internal/model"
+	"shoeshop/order-service/internal/shipping"
+	pb "shoeshop/proto"
+)
+
+// QuoteShipping возвращает способы доставки корзины в страну заказа.
+// Порог бесплатной доставки сравнивается с суммой после скидок
+func (s *orderService) QuoteShipping(ctx context.Context, order *model.Order) ([]shipping.Option, error) {
+	lines, _, _, err := s.priceItems(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := s.rates.Quote(parcels(lines), order.ShippingCountry, order.TotalAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote shipping: %w", err)
+	}
+	return options, nil
+}
+
+// applyShipping выбирает способ доставки заказа, без выбора - самый
+// дешевый, и добавляет его стоимость к итогу заказа
+func (s *orderService) applyShipping(order *model.Order, lines []*pricingLine) error {
+	option, err := s.rates.Select(parcels(lines), order.ShippingCountry, order.TotalAmount, order.ShippingMethod)
+	if err != nil {
+		return fmt.Errorf("failed to calculate shipping: %w", err)
+	}
+
+	order.ShippingCountry = option.Country
+	order.ShippingMethod = option.Method
+	order.ShippingCost = option.Cost
+	order.TotalAmount = roundMoney(order.TotalAmount + option.Cost)
+	return nil
+}
+
+func parcelOf(item *model.OrderItem, product *pb.Product) shipping.Item {
+	info := product.GetShippingInfo()
+	return shipping.Item{
+		ProductID:     item.ProductID,
+		Quantity:      item.Quantity,
+		WeightGrams:   info.GetWeightGrams(),
+		LengthMM:      info.GetLengthMm(),
+		WidthMM:       info.GetWidthMm(),
+		HeightMM:      info.GetHeightMm(),
+		Methods:       info.GetMethods(),
+		FreeShipping:  info.GetFreeShipping(),
+		International: info.GetInternational(),
+	}
+}
+
+func parcels(lines []*pricingLine) []shipping.Item {
+	items := make([]shipping.Item, len(lines))
+	for i, line := range lines {
+		items[i] = line.parcel
+	}
+	return items
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"slices"
+
+	"shoeshop/order-service/internal/model"
+	"shoeshop/order-service/internal/tax"
+)
+
+// applyTax считает налог позиций и доставки по стране и региону
+// назначения и запоминает его в каждой позиции. Налог, не включенный в
+// цены, добавляется к итогу заказа
+func (s *orderService) applyTax(order *model.Order, lines []*pricingLine) {
+	taxLines := make([]tax.Line, len(lines))
+	for i, line := range lines {
+		// Сначала категория товара, затем ее родители от ближайшего
+		categories := slices.Clone(line.categories[1:])
+		slices.Reverse(categories)
+		taxLines[i] = tax.Line{
+			Amount:     roundMoney(line.remaining()),
+			Categories: append([]string{line.categories[0]}, categories...),
+		}
+	}
+
+	result := s.taxes.Calculate(order.ShippingCountry, order.ShippingRegion, taxLines, order.ShippingCost)
+	for i, line := range lines {
+		line.item.Tax = result.Lines[i].Amount
+		line.item.TaxRate = result.Lines[i].Rate
+	}
+
+	order.TaxTotal = result.Total
+	order.TaxInclusive = result.Inclusive
+	order.ShippingTax = result.ShippingTax
+	if !result.Inclusive {
+		order.TotalAmount = roundMoney(order.TotalAmount + result.Total)
+	}
+}
@@ -0,0 +1,152 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+var (
+	// ErrUndeliverable - заказ нельзя доставить в страну назначения
+	ErrUndeliverable = errors.New("order cannot be shipped to the destination")
+	// ErrUnknownMethod - выбранного способа доставки нет среди доступных
+	ErrUnknownMethod = errors.New("shipping method is not available")
+)
+
+// Item - позиция корзины с упаковкой товара
+type Item struct {
+	ProductID   string
+	Quantity    int32
+	WeightGrams int32
+	LengthMM    int32
+	WidthMM     int32
+	HeightMM    int32
+	// Коды способов доставки; пустой список - любой способ
+	Methods       []string
+	FreeShipping  bool
+	International bool
+}
+
+// Option - способ доставки, доступный для корзины
+type Option struct {
+	Method  string
+	Name    string
+	Zone    string
+	Country string
+	Cost    float64
+	MinDays int
+	MaxDays int
+}
+
+// Quote считает стоимость всех способов доставки корзины в страну country,
+// самые дешевые первыми. Пустая страна - страна магазина. subtotal - сумма
+// заказа после скидок, от нее зависит бесплатная доставка
+func (t *RateTable) Quote(items []Item, country string, subtotal float64) ([]Option, error) {
+	country = normalizeCode(country)
+	if country == "" {
+		country = t.HomeCountry
+	}
+
+	zone := t.zone(country)
+	if zone == nil {
+		return nil, fmt.Errorf("%w: no shipping zone for %s", ErrUndeliverable, country)
+	}
+	if zone.International {
+		for _, item := range items {
+			if !item.International {
+				return nil, fmt.Errorf("%w: product %s does not ship internationally", ErrUndeliverable, item.ProductID)
+			}
+		}
+	}
+
+	options := []Option{}
+	for _, method := range zone.Methods {
+		if !allowsMethod(items, method.Code) {
+			continue
+		}
+		options = append(options, Option{
+			Method:  method.Code,
+			Name:    method.Name,
+			Zone:    zone.Name,
+			Country: country,
+			Cost:    t.cost(method, items, subtotal),
+			MinDays: method.MinDays,
+			MaxDays: method.MaxDays,
+		})
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: no shipping method to %s fits every product", ErrUndeliverable, country)
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Cost != options[j].Cost {
+			return options[i].Cost < options[j].Cost
+		}
+		return options[i].MaxDays < options[j].MaxDays
+	})
+	return options, nil
+}
+
+// Select возвращает выбранный способ доставки, а без выбора - самый дешевый
+func (t *RateTable) Select(items []Item, country string, subtotal float64, method string) (Option, error) {
+	options, err := t.Quote(items, country, subtotal)
+	if err != nil {
+		return Option{}, err
+	}
+
+	method = normalizeCode(method)
+	if method == "" {
+		return options[0], nil
+	}
+	for _, option := range options {
+		if option.Method == method {
+			return option, nil
+		}
+	}
+	return Option{}, fmt.Errorf("%w: %s to %s", ErrUnknownMethod, method, options[0].Country)
+}
+
+func (t *RateTable) cost(method Method, items []Item, subtotal float64) float64 {
+	if method.FreeOver > 0 && subtotal >= method.FreeOver {
+		return 0
+	}
+
+	var grams float64
+	for _, item := range items {
+		if method.OffersFree && item.FreeShipping {
+			continue
+		}
+		grams += t.chargeableGrams(item) * float64(item.Quantity)
+	}
+	if grams == 0 {
+		return 0
+	}
+
+	// Вес округляется вверх до полукилограмма
+	halfKilos := math.Ceil(grams / 500)
+	return math.Round((method.BaseRate+method.PerKg*halfKilos/2)*100) / 100
+}
+
+// chargeableGrams - больший из фактического и объемного веса единицы товара
+func (t *RateTable) chargeableGrams(item Item) float64 {
+	weight := float64(item.WeightGrams)
+	if weight <= 0 {
+		weight = float64(t.DefaultWeightGrams)
+	}
+
+	// мм³ -> см³ -> кг -> г
+	volume := float64(item.LengthMM) * float64(item.WidthMM) * float64(item.HeightMM) / 1000
+	volumetric := volume / t.VolumetricDivisor * 1000
+	return math.Max(weight, volumetric)
+}
+
+func allowsMethod(items []Item, code string) bool {
+	for _, item := range items {
+		if len(item.Methods) > 0 && !slices.Contains(item.Methods, code) {
+			return false
+		}
+	}
+	return true
+}
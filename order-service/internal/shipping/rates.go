@@ -0,0 +1,167 @@
+package shipping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidRates - таблица тарифов не прошла проверку
+var ErrInvalidRates = errors.New("invalid shipping rates")
+
+// AnyCountry в списке стран зоны подходит всем странам, которых нет в
+// других зонах
+const AnyCountry = "*"
+
+// Method - способ доставки в зоне. Стоимость = BaseRate + PerKg за каждые
+// начатые полкило оплачиваемого веса
+type Method struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	BaseRate float64 `json:"base_rate"`
+	PerKg    float64 `json:"per_kg"`
+	MinDays  int     `json:"min_days"`
+	MaxDays  int     `json:"max_days"`
+	// FreeOver - сумма заказа после скидок, с которой доставка бесплатна;
+	// ноль - бесплатной доставки по сумме нет
+	FreeOver float64 `json:"free_over"`
+	// OffersFree - товары с бесплатной доставкой едут этим способом бесплатно
+	OffersFree bool `json:"offers_free"`
+}
+
+// Zone - страны с общими тарифами
+type Zone struct {
+	Name      string   `json:"name"`
+	Countries []string `json:"countries"`
+	// В международную зону едут только товары с флагом international
+	International bool     `json:"international"`
+	Methods       []Method `json:"methods"`
+}
+
+// RateTable - тарифы доставки по зонам
+type RateTable struct {
+	// HomeCountry - страна назначения, если покупатель ее не указал
+	HomeCountry string `json:"home_country"`
+	// DefaultWeightGrams - вес коробки товара без указанного веса
+	DefaultWeightGrams int32 `json:"default_weight_grams"`
+	// VolumetricDivisor - кубических сантиметров на килограмм объемного веса
+	VolumetricDivisor float64 `json:"volumetric_divisor"`
+	Zones             []Zone  `json:"zones"`
+}
+
+// DefaultRates - тарифы, которые действуют, пока не задан файл тарифов
+func DefaultRates() *RateTable {
+	return &RateTable{
+		HomeCountry:        "US",
+		DefaultWeightGrams: 1200,
+		VolumetricDivisor:  5000,
+		Zones: []Zone{
+			{
+				Name:      "DOMESTIC",
+				Countries: []string{"US"},
+				Methods: []Method{
+					{Code: "STANDARD", Name: "Standard", BaseRate: 5.99, PerKg: 0.5, MinDays: 3, MaxDays: 5, FreeOver: 100, OffersFree: true},
+					{Code: "EXPRESS", Name: "Express", BaseRate: 14.99, PerKg: 1.5, MinDays: 1, MaxDays: 2},
+				},
+			},
+			{
+				Name:          "NORTH_AMERICA",
+				Countries:     []string{"CA", "MX"},
+				International: true,
+				Methods: []Method{
+					{Code: "STANDARD", Name: "Standard", BaseRate: 14.99, PerKg: 2, MinDays: 5, MaxDays: 10, FreeOver: 200, OffersFree: true},
+					{Code: "EXPRESS", Name: "Express", BaseRate: 29.99, PerKg: 4, MinDays: 2, MaxDays: 4},
+				},
+			},
+			{
+				Name:          "INTERNATIONAL",
+				Countries:     []string{AnyCountry},
+				International: true,
+				Methods: []Method{
+					{Code: "STANDARD", Name: "International Standard", BaseRate: 24.99, PerKg: 4, MinDays: 7, MaxDays: 21},
+					{Code: "EXPRESS", Name: "International Express", BaseRate: 49.99, PerKg: 8, MinDays: 3, MaxDays: 6},
+				},
+			},
+		},
+	}
+}
+
+// LoadRates читает таблицу тарифов из JSON-файла
+func LoadRates(path string) (*RateTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var table RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// Validate проверяет таблицу и приводит коды стран и способов к верхнему регистру
+func (t *RateTable) Validate() error {
+	t.HomeCountry = normalizeCode(t.HomeCountry)
+	if t.HomeCountry == "" {
+		return fmt.Errorf("%w: home_country is required", ErrInvalidRates)
+	}
+	if t.DefaultWeightGrams <= 0 || t.VolumetricDivisor <= 0 {
+		return fmt.Errorf("%w: default_weight_grams and volumetric_divisor must be positive", ErrInvalidRates)
+	}
+
+	seen := make(map[string]string)
+	for i := range t.Zones {
+		zone := &t.Zones[i]
+		if zone.Name == "" || len(zone.Countries) == 0 || len(zone.Methods) == 0 {
+			return fmt.Errorf("%w: zone %d needs a name, countries and methods", ErrInvalidRates, i+1)
+		}
+		for j, country := range zone.Countries {
+			country = normalizeCode(country)
+			if other, ok := seen[country]; ok {
+				return fmt.Errorf("%w: country %s is in zones %s and %s", ErrInvalidRates, country, other, zone.Name)
+			}
+			seen[country] = zone.Name
+			zone.Countries[j] = country
+		}
+		for j := range zone.Methods {
+			method := &zone.Methods[j]
+			method.Code = normalizeCode(method.Code)
+			if method.Code == "" {
+				return fmt.Errorf("%w: method without code in zone %s", ErrInvalidRates, zone.Name)
+			}
+			if method.BaseRate < 0 || method.PerKg < 0 || method.FreeOver < 0 {
+				return fmt.Errorf("%w: negative rate of %s in zone %s", ErrInvalidRates, method.Code, zone.Name)
+			}
+			if method.MinDays < 0 || method.MaxDays < method.MinDays {
+				return fmt.Errorf("%w: invalid delivery days of %s in zone %s", ErrInvalidRates, method.Code, zone.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// zone находит зону страны; зона со звездочкой подходит остальным странам
+func (t *RateTable) zone(country string) *Zone {
+	var fallback *Zone
+	for i := range t.Zones {
+		for _, code := range t.Zones[i].Countries {
+			if code == country {
+				return &t.Zones[i]
+			}
+			if code == AnyCountry {
+				fallback = &t.Zones[i]
+			}
+		}
+	}
+	return fallback
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
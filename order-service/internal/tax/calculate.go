@@ -0,0 +1,78 @@
+package tax
+
+import "math"
+
+// Line - сумма позиции заказа после скидок и категории товара, самая
+// конкретная первой
+type Line struct {
+	Amount     float64
+	Categories []string
+}
+
+// LineTax - налог одной позиции
+type LineTax struct {
+	Rate   float64
+	Amount float64
+}
+
+// Result - налог заказа. Total - сумма налогов позиций и доставки, каждый
+// округлен отдельно, поэтому налоги позиций сходятся с итогом
+type Result struct {
+	// Name - название налога региона; пусто, если налог не начисляется
+	Name         string
+	Inclusive    bool
+	Lines        []LineTax
+	ShippingRate float64
+	ShippingTax  float64
+	Total        float64
+}
+
+// Calculate считает налог позиций и доставки для страны и региона
+// назначения. При налоге, включенном в цены, налог выделяется из сумм, а не
+// начисляется сверху
+func (t *RateTable) Calculate(country, region string, lines []Line, shipping float64) Result {
+	result := Result{Lines: make([]LineTax, len(lines))}
+	rates := t.region(normalizeCode(country), normalizeCode(region))
+	if rates == nil {
+		return result
+	}
+
+	result.Name = rates.Name
+	result.Inclusive = rates.Inclusive
+	for i, line := range lines {
+		rate := rates.rateFor(line.Categories)
+		result.Lines[i] = LineTax{Rate: rate, Amount: rates.tax(line.Amount, rate)}
+		result.Total += result.Lines[i].Amount
+	}
+	if rates.Shipping {
+		result.ShippingRate = rates.Rate
+		result.ShippingTax = rates.tax(shipping, rates.Rate)
+		result.Total += result.ShippingTax
+	}
+	result.Total = roundMoney(result.Total)
+	return result
+}
+
+// rateFor - ставка первой категории товара, для которой она задана, или основная
+func (r *Region) rateFor(categories []string) float64 {
+	for _, category := range categories {
+		if rate, ok := r.Categories[category]; ok {
+			return rate
+		}
+	}
+	return r.Rate
+}
+
+func (r *Region) tax(amount, rate float64) float64 {
+	if amount <= 0 || rate == 0 {
+		return 0
+	}
+	if r.Inclusive {
+		return roundMoney(amount - amount/(1+rate))
+	}
+	return roundMoney(amount * rate)
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,131 @@
+package tax
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidRates - таблица налоговых ставок не прошла проверку
+var ErrInvalidRates = errors.New("invalid tax rates")
+
+// Region - налоговые ставки страны или одного ее региона
+type Region struct {
+	// Country - код страны ISO 3166-1 alpha-2
+	Country string `json:"country"`
+	// Region - код штата или провинции без кода страны; пусто - вся страна.
+	// Ставки региона заменяют ставки страны, а не добавляются к ним
+	Region string `json:"region"`
+	Name   string `json:"name"`
+	// Rate - основная ставка, доля от суммы: 0.2 - это 20%
+	Rate float64 `json:"rate"`
+	// Categories - ставки категорий по slug, отличные от основной
+	Categories map[string]float64 `json:"categories"`
+	// Inclusive - цены каталога уже содержат налог (НДС) и налог из них
+	// выделяется; иначе налог добавляется к итогу заказа
+	Inclusive bool `json:"inclusive"`
+	// Shipping - доставка облагается по основной ставке
+	Shipping bool `json:"shipping"`
+}
+
+// RateTable - налоговые ставки по странам и регионам. В странах, которых
+// нет в таблице, налог не начисляется
+type RateTable struct {
+	Regions []Region `json:"regions"`
+}
+
+// DefaultRates - ставки, которые действуют, пока не задан файл ставок
+func DefaultRates() *RateTable {
+	return &RateTable{
+		Regions: []Region{
+			{Country: "US", Region: "CA", Name: "California Sales Tax", Rate: 0.0725},
+			{Country: "US", Region: "NY", Name: "New York Sales Tax", Rate: 0.04},
+			{Country: "US", Region: "TX", Name: "Texas Sales Tax", Rate: 0.0625},
+			{Country: "CA", Name: "GST", Rate: 0.05, Shipping: true},
+			{Country: "CA", Region: "ON", Name: "HST", Rate: 0.13, Shipping: true},
+			{Country: "DE", Name: "MwSt", Rate: 0.19, Inclusive: true, Shipping: true},
+			{Country: "FR", Name: "TVA", Rate: 0.2, Inclusive: true, Shipping: true},
+			{
+				Country:    "GB",
+				Name:       "VAT",
+				Rate:       0.2,
+				Categories: map[string]float64{"kids": 0},
+				Inclusive:  true,
+				Shipping:   true,
+			},
+		},
+	}
+}
+
+// LoadRates читает таблицу ставок из JSON-файла
+func LoadRates(path string) (*RateTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var table RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// Validate проверяет ставки и приводит коды стран и регионов к верхнему регистру
+func (t *RateTable) Validate() error {
+	seen := make(map[string]bool)
+	for i := range t.Regions {
+		region := &t.Regions[i]
+		region.Country = normalizeCode(region.Country)
+		region.Region = normalizeCode(region.Region)
+		if region.Country == "" {
+			return fmt.Errorf("%w: region %d has no country", ErrInvalidRates, i+1)
+		}
+
+		key := region.Country + "-" + region.Region
+		if seen[key] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidRates, strings.TrimSuffix(key, "-"))
+		}
+		seen[key] = true
+
+		if !validRate(region.Rate) {
+			return fmt.Errorf("%w: rate of %s must be between 0 and 1", ErrInvalidRates, strings.TrimSuffix(key, "-"))
+		}
+		for category, rate := range region.Categories {
+			if !validRate(rate) {
+				return fmt.Errorf("%w: rate of %s in %s must be between 0 and 1", ErrInvalidRates, category, strings.TrimSuffix(key, "-"))
+			}
+		}
+	}
+	return nil
+}
+
+// region находит ставки региона страны, а без них - ставки всей страны
+func (t *RateTable) region(country, region string) *Region {
+	var fallback *Region
+	for i := range t.Regions {
+		if t.Regions[i].Country != country {
+			continue
+		}
+		if region != "" && t.Regions[i].Region == region {
+			return &t.Regions[i]
+		}
+		if t.Regions[i].Region == "" {
+			fallback = &t.Regions[i]
+		}
+	}
+	return fallback
+}
+
+func validRate(rate float64) bool {
+	return rate >= 0 && rate < 1
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
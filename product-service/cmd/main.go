@@ -1,22 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 
 	"shoeshop/product-service/internal/handler"
 	"shoeshop/product-service/internal/repository"
 	"shoeshop/product-service/internal/service"
+	"shoeshop/product-service/internal/storage"
 	pb "shoeshop/proto"
 )
 
+const (
+	mediaDir     = "media"
+	mediaAddr    = ":8090"
+	mediaBaseURL = "http://localhost:8090/media"
+
+	// Архивные товары без заказов удаляются окончательно через archiveRetention
+	archiveRetention     = 90 * 24 * time.Hour
+	archivePurgeInterval = time.Hour
+)
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
@@ -26,21 +41,110 @@ func main() {
 		log.Fatalf("Failed to create MongoDB repository: %v", err)
 	}
 
-	// Инициализация Redis кэша
-	cache, err := repository.NewRedisCache("localhost:6379")
+	// История цен и запланированные изменения цен
+	prices, err := repository.NewPriceRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create price repository: %v", err)
+	}
+
+	// Дерево категорий; при первом запуске строковые категории товаров
+	// переносятся в дерево
+	categories, err := repository.NewCategoryRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create category repository: %v", err)
+	}
+
+	// Бренды с таблицами размеров; товары со строковым брендом получают
+	// ссылку на бренд при первом запуске
+	brands, err := repository.NewBrandRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create brand repository: %v", err)
+	}
+
+	// Подписки пользователей на поступление товаров
+	subscriptions, err := repository.NewStockSubscriptionRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create stock subscription repository: %v", err)
+	}
+
+	// Совместные покупки для рекомендаций
+	recommendations, err := repository.NewRecommendationRepository("mongodb://localhost:27017")
 	if err != nil {
-		log.Printf("Warning: Failed to connect to Redis: %v", err)
-		// Продолжаем работу без кэша
+		log.Fatalf("Failed to create recommendation repository: %v", err)
 	}
 
+	// Инициализация двухуровневого кэша: LRU в памяти процесса + Redis.
+	// Если Redis недоступен, работаем только на LRU и переподключаемся в фоне
+	cache := repository.NewLayeredCache("localhost:6379", 10000, time.Minute)
+
 	// Инициализация NATS для событий
 	natsClient, err := repository.NewNatsClient("nats://localhost:4222")
 	if err != nil {
 		log.Fatalf("Failed to connect to NATS: %v", err)
 	}
 
+	// Хранилище изображений товаров на локальном диске
+	imageStorage, err := storage.NewLocalStorage(mediaDir, mediaBaseURL)
+	if err != nil {
+		log.Fatalf("Failed to create image storage: %v", err)
+	}
+
+	// Раздача сохраненных изображений по HTTP
+	mux := http.NewServeMux()
+	mux.Handle("/media/", http.StripPrefix("/media", storage.Handler(mediaDir)))
+	mediaServer := &http.Server{Addr: mediaAddr, Handler: mux}
+	go func() {
+		log.Printf("Serving product images on %s", mediaAddr)
+		if err := mediaServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to serve images: %v", err)
+		}
+	}()
+
 	// Инициализация сервиса
-	svc := service.NewProductService(repo, cache, natsClient)
+	svc := service.NewProductService(repo, prices, categories, brands, subscriptions, recommendations, cache, natsClient, imageStorage)
+
+	// Загрузка индекса автодополнения и подписка на события
+	if err := svc.StartIndexSync(context.Background(), natsClient); err != nil {
+		log.Fatalf("Failed to start autocomplete index: %v", err)
+	}
+
+	// Сброс закэшированных списков по событиям товаров
+	if err := svc.StartCacheInvalidation(natsClient); err != nil {
+		log.Fatalf("Failed to start cache invalidation: %v", err)
+	}
+
+	// Рейтинг товаров по событиям review-service
+	if err := svc.StartRatingSync(natsClient); err != nil {
+		log.Fatalf("Failed to start rating sync: %v", err)
+	}
+
+	// Списание остатков по заказам и оповещения о низком остатке
+	if err := svc.StartStockSync(natsClient); err != nil {
+		log.Fatalf("Failed to start stock sync: %v", err)
+	}
+
+	// Письма подписчикам о поступлении товара
+	if err := svc.StartBackInStockNotifications(natsClient); err != nil {
+		log.Fatalf("Failed to start back in stock notifications: %v", err)
+	}
+
+	// Рекомендации "с этим покупают" по истории заказов
+	if err := svc.StartRecommendationSync(natsClient); err != nil {
+		log.Fatalf("Failed to start recommendation sync: %v", err)
+	}
+
+	// Применение запланированных цен; останавливается вместе с сервисом
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	svc.StartPriceScheduler(schedulerCtx, time.Minute)
+
+	// Подключение к Order Service: товары из заказов из архива не удаляются
+	orderConn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer orderConn.Close()
+	svc.StartArchivePurge(schedulerCtx, pb.NewOrderServiceClient(orderConn), archivePurgeInterval, archiveRetention)
 
 	// Инициализация gRPC handler
 	grpcHandler := handler.NewGRPCHandler(svc)
@@ -76,6 +180,9 @@ func main() {
 
 	// Graceful shutdown
 	server.GracefulStop()
+	if err := mediaServer.Shutdown(context.Background()); err != nil {
+		log.Printf("Error stopping image server: %v", err)
+	}
 	
 	// Закрываем соединения
 	if err := cache.Close(); err != nil {
@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"shoeshop/product-service/internal/model"
+)
+
+// Format - формат файла каталога
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ErrMalformed - файл нельзя разобрать целиком (нет заголовка, неизвестный формат и т.п.)
+var ErrMalformed = errors.New("malformed catalog file")
+
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "csv":
+		return FormatCSV, nil
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported format %q", ErrMalformed, value)
+	}
+}
+
+// RowError - ошибка в конкретной строке файла
+type RowError struct {
+	Line    int
+	SKU     string
+	Message string
+}
+
+// ImportReport - итог импорта каталога
+type ImportReport struct {
+	Created int
+	Updated int
+	Failed  int
+	DryRun  bool
+	Errors  []RowError
+}
+
+func (r *ImportReport) AddError(line int, sku string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, RowError{Line: line, SKU: sku, Message: err.Error()})
+}
+
+// Validate проверяет товар перед записью в каталог
+func Validate(product *model.Product) error {
+	var problems []string
+	if product.SKU == "" {
+		problems = append(problems, "sku is required")
+	}
+	if product.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if product.Price < 0 || math.IsNaN(product.Price) || math.IsInf(product.Price, 0) {
+		problems = append(problems, "price must be a non-negative number")
+	}
+	if product.Stock < 0 {
+		problems = append(problems, "stock must not be negative")
+	}
+	if product.Discount < 0 || product.Discount >= 1 || math.IsNaN(product.Discount) {
+		problems = append(problems, "discount must be a fraction of the price from 0 to 1")
+	}
+	if product.Rating < 0 || product.Rating > 5 || math.IsNaN(product.Rating) {
+		problems = append(problems, "rating must be from 0 to 5")
+	}
+	if err := model.ValidateReleaseDate(product.ReleaseDate); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if err := product.Shipping.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, size := range product.Sizes {
+		if size <= 0 {
+			problems = append(problems, fmt.Sprintf("invalid size %d", size))
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
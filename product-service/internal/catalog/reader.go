@@ -0,0 +1,384 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
+)
+
+// Row - одна строка файла; Err заполнен, если строку не удалось разобрать
+type Row struct {
+	Line    int
+	Product *model.Product
+	Err     error
+}
+
+// Reader читает товары из файла построчно, не загружая его в память целиком
+type Reader interface {
+	// Next возвращает io.EOF, когда строки закончились
+	Next() (*Row, error)
+}
+
+// SizeTables возвращает таблицу размеров бренда по его названию из файла
+type SizeTables func(brand string) *sizing.Table
+
+// NewReader создает читателя файла; размеры без указания системы
+// разбираются в system по таблице бренда товара из tables
+func NewReader(format Format, r io.Reader, system sizing.System, tables SizeTables) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r, system, tables)
+	case FormatJSONL:
+		return &jsonlReader{r: bufio.NewReader(r), system: system, tables: tables}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrMalformed, format)
+	}
+}
+
+// Колонки CSV; разделитель элементов списков - "|"
+var csvColumns = []string{
+	"id", "sku", "name", "description", "price", "category", "brand", "sizes", "colors", "images", "stock",
+	"discount", "rating", "material", "features", "release_date",
+	"weight_grams", "length_mm", "width_mm", "height_mm", "shipping_methods", "free_shipping", "international",
+}
+
+var requiredColumns = []string{"sku", "name", "price"}
+
+const listSeparator = "|"
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	width   int
+	system  sizing.System
+	tables  SizeTables
+}
+
+func newCSVReader(r io.Reader, system sizing.System, tables SizeTables) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	// Лишние и недостающие колонки в строке - ошибка строки, а не всего файла
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: missing header", ErrMalformed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excel сохраняет UTF-8 с BOM
+			name = strings.TrimPrefix(name, string(utf8BOM))
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrMalformed, name)
+		}
+	}
+
+	return &csvReader{r: reader, columns: columns, width: len(header), system: system, tables: tables}, nil
+}
+
+func (c *csvReader) Next() (*Row, error) {
+	record, err := c.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &Row{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := c.r.FieldPos(0)
+	row := &Row{Line: line}
+	row.Product, row.Err = c.parse(record)
+	return row, nil
+}
+
+func (c *csvReader) parse(record []string) (*model.Product, error) {
+	field := func(name string) string {
+		i, ok := c.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	product := &model.Product{
+		SKU:         field("sku"),
+		Name:        field("name"),
+		Description: field("description"),
+		Category:    field("category"),
+		Brand:       field("brand"),
+		Colors:      splitList(field("colors")),
+		Images:      imagesFromURLs(splitList(field("images"))),
+		Material:    field("material"),
+		Features:    splitList(field("features")),
+		ReleaseDate: field("release_date"),
+		Shipping: model.ShippingInfo{
+			Methods: splitList(field("shipping_methods")),
+		},
+	}
+
+	if len(record) != c.width {
+		return product, fmt.Errorf("expected %d columns, got %d", c.width, len(record))
+	}
+
+	price, err := strconv.ParseFloat(field("price"), 64)
+	if err != nil {
+		return product, fmt.Errorf("invalid price %q", field("price"))
+	}
+	product.Price = price
+
+	if value := field("stock"); value != "" {
+		stock, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return product, fmt.Errorf("invalid stock %q", value)
+		}
+		product.Stock = int32(stock)
+	}
+
+	if value := field("discount"); value != "" {
+		discount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return product, fmt.Errorf("invalid discount %q", value)
+		}
+		product.Discount = discount
+	}
+
+	if value := field("rating"); value != "" {
+		rating, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return product, fmt.Errorf("invalid rating %q", value)
+		}
+		product.Rating = rating
+	}
+
+	// Упаковка: вес в граммах, размеры в миллиметрах
+	for _, dimension := range []struct {
+		column string
+		value  *int32
+	}{
+		{"weight_grams", &product.Shipping.WeightGrams},
+		{"length_mm", &product.Shipping.LengthMM},
+		{"width_mm", &product.Shipping.WidthMM},
+		{"height_mm", &product.Shipping.HeightMM},
+	} {
+		if value := field(dimension.column); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return product, fmt.Errorf("invalid %s %q", dimension.column, value)
+			}
+			*dimension.value = int32(parsed)
+		}
+	}
+	for _, flag := range []struct {
+		column string
+		value  *bool
+	}{
+		{"free_shipping", &product.Shipping.FreeShipping},
+		{"international", &product.Shipping.International},
+	} {
+		if value := field(flag.column); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return product, fmt.Errorf("invalid %s %q", flag.column, value)
+			}
+			*flag.value = parsed
+		}
+	}
+
+	sizes, err := c.tables(product.Brand).ParseAll(splitList(field("sizes")), c.system)
+	if err != nil {
+		return product, err
+	}
+	product.Sizes = sizes
+
+	return product, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// imagesFromURLs превращает список URL из файла в записи изображений.
+// Пустой список дает nil: у существующего товара изображения не трогаем
+func imagesFromURLs(urls []string) []model.Image {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	images := make([]model.Image, len(urls))
+	for i, url := range urls {
+		images[i] = model.Image{ID: url, URL: url, SortOrder: int32(i)}
+	}
+	return images
+}
+
+// imageURLs возвращает URL изображений в порядке показа
+func imageURLs(images []model.Image) []string {
+	sorted := append([]model.Image(nil), images...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].SortOrder < sorted[j].SortOrder })
+
+	urls := make([]string, len(sorted))
+	for i, image := range sorted {
+		urls[i] = image.URL
+	}
+	return urls
+}
+
+// record - представление товара в JSON Lines
+type record struct {
+	ID          string   `json:"id,omitempty"`
+	SKU         string   `json:"sku"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       float64  `json:"price"`
+	Category    string   `json:"category"`
+	Brand       string   `json:"brand"`
+	Sizes       []label  `json:"sizes"`
+	Colors      []string `json:"colors"`
+	Images      []string `json:"images"`
+	Stock       int32    `json:"stock"`
+	Discount    float64  `json:"discount"`
+	Rating      float64  `json:"rating"`
+	Material    string   `json:"material"`
+	Features    []string `json:"features"`
+	ReleaseDate string   `json:"release_date"`
+	Shipping    shipping `json:"shipping_info"`
+}
+
+// shipping - упаковка товара в JSON Lines
+type shipping struct {
+	WeightGrams   int32    `json:"weight_grams"`
+	LengthMM      int32    `json:"length_mm"`
+	WidthMM       int32    `json:"width_mm"`
+	HeightMM      int32    `json:"height_mm"`
+	Methods       []string `json:"methods"`
+	FreeShipping  bool     `json:"free_shipping"`
+	International bool     `json:"international"`
+}
+
+// label - размер в JSON: число (42.5) или строка ("US 9.5")
+type label string
+
+func (l *label) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*l = label(text)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("size must be a number or a string, got %s", data)
+	}
+	*l = label(number)
+	return nil
+}
+
+type jsonlReader struct {
+	r      *bufio.Reader
+	line   int
+	system sizing.System
+	tables SizeTables
+}
+
+func (j *jsonlReader) Next() (*Row, error) {
+	for {
+		// ReadBytes, а не Scanner: у Scanner ограничение на длину строки
+		data, err := j.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(data) == 0 && err == io.EOF {
+			return nil, io.EOF
+		}
+
+		j.line++
+		if j.line == 1 {
+			data = bytes.TrimPrefix(data, utf8BOM)
+		}
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &Row{Line: j.line}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			row.Err = fmt.Errorf("invalid JSON: %v", err)
+			return row, nil
+		}
+
+		labels := make([]string, len(rec.Sizes))
+		for i, value := range rec.Sizes {
+			labels[i] = string(value)
+		}
+		brand := strings.TrimSpace(rec.Brand)
+		sizes, err := j.tables(brand).ParseAll(labels, j.system)
+		if err != nil {
+			row.Err = err
+			return row, nil
+		}
+
+		row.Product = &model.Product{
+			SKU:         strings.TrimSpace(rec.SKU),
+			Name:        strings.TrimSpace(rec.Name),
+			Description: rec.Description,
+			Price:       rec.Price,
+			Category:    strings.TrimSpace(rec.Category),
+			Brand:       brand,
+			Sizes:       sizes,
+			Colors:      rec.Colors,
+			Images:      imagesFromURLs(rec.Images),
+			Stock:       rec.Stock,
+			Discount:    rec.Discount,
+			Rating:      rec.Rating,
+			Material:    strings.TrimSpace(rec.Material),
+			Features:    rec.Features,
+			ReleaseDate: strings.TrimSpace(rec.ReleaseDate),
+			Shipping: model.ShippingInfo{
+				WeightGrams:   rec.Shipping.WeightGrams,
+				LengthMM:      rec.Shipping.LengthMM,
+				WidthMM:       rec.Shipping.WidthMM,
+				HeightMM:      rec.Shipping.HeightMM,
+				Methods:       rec.Shipping.Methods,
+				FreeShipping:  rec.Shipping.FreeShipping,
+				International: rec.Shipping.International,
+			},
+		}
+		return row, nil
+	}
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
+)
+
+// Writer записывает товары в том же формате, который принимает импорт
+type Writer interface {
+	Write(product *model.Product) error
+	Flush() error
+}
+
+// NewWriter создает писателя файла; размеры выводятся в system
+func NewWriter(format Format, w io.Writer, system sizing.System) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: writer, system: system}, nil
+	case FormatJSONL:
+		return &jsonlWriter{w: w, system: system}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrMalformed, format)
+	}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	system sizing.System
+}
+
+func (c *csvWriter) Write(product *model.Product) error {
+	sizes := product.SizeTable().FormatAll(product.Sizes, c.system)
+
+	return c.w.Write([]string{
+		product.ID,
+		product.SKU,
+		product.Name,
+		product.Description,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		product.Category,
+		product.Brand,
+		strings.Join(sizes, listSeparator),
+		strings.Join(product.Colors, listSeparator),
+		strings.Join(imageURLs(product.Images), listSeparator),
+		strconv.Itoa(int(product.Stock)),
+		strconv.FormatFloat(product.Discount, 'f', -1, 64),
+		strconv.FormatFloat(product.Rating, 'f', -1, 64),
+		product.Material,
+		strings.Join(product.Features, listSeparator),
+		product.ReleaseDate,
+		strconv.Itoa(int(product.Shipping.WeightGrams)),
+		strconv.Itoa(int(product.Shipping.LengthMM)),
+		strconv.Itoa(int(product.Shipping.WidthMM)),
+		strconv.Itoa(int(product.Shipping.HeightMM)),
+		strings.Join(product.Shipping.Methods, listSeparator),
+		strconv.FormatBool(product.Shipping.FreeShipping),
+		strconv.FormatBool(product.Shipping.International),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w      io.Writer
+	system sizing.System
+}
+
+func (j *jsonlWriter) Write(product *model.Product) error {
+	labels := product.SizeTable().FormatAll(product.Sizes, j.system)
+	sizes := make([]label, len(labels))
+	for i, value := range labels {
+		sizes[i] = label(value)
+	}
+
+	data, err := json.Marshal(record{
+		ID:          product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		Brand:       product.Brand,
+		Sizes:       sizes,
+		Colors:      product.Colors,
+		Images:      imageURLs(product.Images),
+		Stock:       product.Stock,
+		Discount:    product.Discount,
+		Rating:      product.Rating,
+		Material:    product.Material,
+		Features:    product.Features,
+		ReleaseDate: product.ReleaseDate,
+		Shipping: shipping{
+			WeightGrams:   product.Shipping.WeightGrams,
+			LengthMM:      product.Shipping.LengthMM,
+			WidthMM:       product.Shipping.WidthMM,
+			HeightMM:      product.Shipping.HeightMM,
+			Methods:       product.Shipping.Methods,
+			FreeShipping:  product.Shipping.FreeShipping,
+			International: product.Shipping.International,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = j.w.Write(append(data, '\n'))
+	return err
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
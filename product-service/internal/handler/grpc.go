@@ -1,14 +1,24 @@
 package handler
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"shoeshop/product-service/internal/catalog"
+	"shoeshop/product-service/internal/imaging"
 	"shoeshop/product-service/internal/model"
 	"shoeshop/product-service/internal/service"
 	pb "shoeshop/proto"
+	"shoeshop/sizing"
 )
 
 type GRPCHandler struct {
@@ -23,7 +33,12 @@ func NewGRPCHandler(productService service.ProductService) *GRPCHandler {
 }
 
 func (h *GRPCHandler) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.ProductResponse, error) {
-	product, err := model.FromProto(req.GetProduct())
+	brand, err := h.productService.ResolveBrand(ctx, req.GetProduct().GetBrandId(), req.GetProduct().GetBrand())
+	if err != nil {
+		return nil, brandError("failed to resolve brand", err)
+	}
+
+	product, err := model.FromProto(req.GetProduct(), brand)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid product data: %v", err)
 	}
@@ -34,41 +49,86 @@ func (h *GRPCHandler) CreateProduct(ctx context.Context, req *pb.CreateProductRe
 	}
 
 	return &pb.ProductResponse{
-		Product: createdProduct.ToProto(),
+		Product: h.productToProto(ctx, createdProduct, sizeSystem(req.GetProduct()), ""),
 	}, nil
 }
 
 func (h *GRPCHandler) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.ProductResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	product, err := h.productService.GetProduct(ctx, req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get product: %v", err)
 	}
 
 	return &pb.ProductResponse{
-		Product: product.ToProto(),
+		Product: h.productToProto(ctx, product, system, req.GetLocale()),
 	}, nil
 }
 
 func (h *GRPCHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.ProductResponse, error) {
-	product, err := model.FromProto(req.GetProduct())
+	if req.GetProduct() == nil {
+		return nil, status.Error(codes.InvalidArgument, "product is required")
+	}
+	if !req.GetUpdateMask().IsValid(req.GetProduct()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update mask: %v", req.GetUpdateMask().GetPaths())
+	}
+	paths := req.GetUpdateMask().GetPaths()
+
+	brand, err := h.updatedBrand(ctx, req.GetProduct(), paths)
+	if err != nil {
+		return nil, brandError("failed to resolve brand", err)
+	}
+
+	product, err := model.FromProto(req.GetProduct(), brand)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid product data: %v", err)
 	}
 
-	updatedProduct, err := h.productService.UpdateProduct(ctx, product)
+	updatedProduct, err := h.productService.UpdateProduct(ctx, product, paths)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
+		return nil, productError("failed to update product", err)
 	}
 
 	return &pb.ProductResponse{
-		Product: updatedProduct.ToProto(),
+		Product: h.productToProto(ctx, updatedProduct, sizeSystem(req.GetProduct()), ""),
 	}, nil
 }
 
+// updatedBrand находит бренд, по таблице которого разбираются размеры
+// обновления. Если маска меняет размеры, но не бренд, берется текущий
+// бренд товара
+func (h *GRPCHandler) updatedBrand(ctx context.Context, product *pb.Product, paths []string) (*model.Brand, error) {
+	if len(paths) == 0 || containsPath(paths, "brand") || containsPath(paths, "brand_id") {
+		return h.productService.ResolveBrand(ctx, product.GetBrandId(), product.GetBrand())
+	}
+	if !containsPath(paths, "sizes") {
+		return nil, nil
+	}
+
+	existing, err := h.productService.GetProduct(ctx, product.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return h.productService.ResolveBrand(ctx, existing.BrandID, "")
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *GRPCHandler) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
 	err := h.productService.DeleteProduct(ctx, req.GetId())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete product: %v", err)
+		return nil, productError("failed to delete product", err)
 	}
 
 	return &pb.DeleteProductResponse{
@@ -76,7 +136,39 @@ func (h *GRPCHandler) DeleteProduct(ctx context.Context, req *pb.DeleteProductRe
 	}, nil
 }
 
+func (h *GRPCHandler) RestoreProduct(ctx context.Context, req *pb.RestoreProductRequest) (*pb.ProductResponse, error) {
+	product, err := h.productService.RestoreProduct(ctx, req.GetId())
+	if err != nil {
+		return nil, productError("failed to restore product", err)
+	}
+
+	return &pb.ProductResponse{
+		Product: h.productToProto(ctx, product, sizing.DefaultSystem, ""),
+	}, nil
+}
+
+func (h *GRPCHandler) ListArchivedProducts(ctx context.Context, req *pb.ListArchivedProductsRequest) (*pb.ListProductsResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	products, err := h.productService.ListArchivedProducts(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list archived products: %v", err)
+	}
+
+	return &pb.ListProductsResponse{
+		Products: h.productsToProto(ctx, products, system, ""),
+	}, nil
+}
+
 func (h *GRPCHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	filter := make(map[string]interface{})
 	if req.GetCategory() != "" {
 		filter["category"] = req.GetCategory()
@@ -90,28 +182,596 @@ func (h *GRPCHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequ
 		return nil, status.Errorf(codes.Internal, "failed to list products: %v", err)
 	}
 
-	pbProducts := make([]*pb.Product, len(products))
-	for i, product := range products {
-		pbProducts[i] = product.ToProto()
-	}
-
 	return &pb.ListProductsResponse{
-		Products: pbProducts,
+		Products: h.productsToProto(ctx, products, system, req.GetLocale()),
 	}, nil
 }
 
 func (h *GRPCHandler) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.ListProductsResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	products, err := h.productService.SearchProducts(ctx, req.GetQuery())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to search products: %v", err)
 	}
 
+	return &pb.ListProductsResponse{
+		Products: h.productsToProto(ctx, products, system, req.GetLocale()),
+	}, nil
+}
+
+func (h *GRPCHandler) Autocomplete(ctx context.Context, req *pb.AutocompleteRequest) (*pb.AutocompleteResponse, error) {
+	suggestions, err := h.productService.Autocomplete(ctx, req.GetPrefix(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to autocomplete: %v", err)
+	}
+
+	pbSuggestions := make([]*pb.Suggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		pbSuggestions[i] = &pb.Suggestion{
+			Text:      suggestion.Text,
+			Kind:      string(suggestion.Kind),
+			ProductId: suggestion.ProductID,
+			Score:     suggestion.Score,
+		}
+	}
+
+	return &pb.AutocompleteResponse{
+		Suggestions: pbSuggestions,
+	}, nil
+}
+
+func (h *GRPCHandler) GetCacheStats(ctx context.Context, req *pb.GetCacheStatsRequest) (*pb.CacheStatsResponse, error) {
+	stats := h.productService.CacheStats(ctx)
+
+	layers := make([]*pb.CacheLayerStats, len(stats))
+	for i, layer := range stats {
+		layers[i] = &pb.CacheLayerStats{
+			Layer:     layer.Layer,
+			Hits:      layer.Hits,
+			Misses:    layer.Misses,
+			Errors:    layer.Errors,
+			Entries:   int64(layer.Entries),
+			Available: layer.Available,
+		}
+	}
+
+	return &pb.CacheStatsResponse{
+		Layers: layers,
+	}, nil
+}
+
+func (h *GRPCHandler) GetReorderReport(ctx context.Context, req *pb.ReorderReportRequest) (*pb.ReorderReport, error) {
+	report, err := h.productService.GetReorderReport(ctx, int(req.GetDays()), int(req.GetCoverDays()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reorder report: %v", err)
+	}
+
+	return report.ToProto(), nil
+}
+
+// Размер куска файла в одном сообщении потока экспорта
+const exportChunkSize = 32 * 1024
+
+func (h *GRPCHandler) ImportProducts(stream pb.ProductService_ImportProductsServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty import stream")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to receive import data: %v", err)
+	}
+
+	format, err := catalog.ParseFormat(first.GetFormat())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid format: %v", err)
+	}
+	system, err := sizing.ParseSystem(first.GetSizeSystem())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	reader := &chunkReader{buf: first.GetData(), recv: func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetData(), err
+	}}
+	report, err := h.productService.ImportProducts(stream.Context(), format, system, reader, first.GetDryRun())
+	if err != nil {
+		if errors.Is(err, catalog.ErrMalformed) {
+			return status.Errorf(codes.InvalidArgument, "invalid catalog file: %v", err)
+		}
+		return status.Errorf(codes.Internal, "failed to import products: %v", err)
+	}
+
+	rowErrors := make([]*pb.ImportRowError, len(report.Errors))
+	for i, rowErr := range report.Errors {
+		rowErrors[i] = &pb.ImportRowError{
+			Line:    int32(rowErr.Line),
+			Sku:     rowErr.SKU,
+			Message: rowErr.Message,
+		}
+	}
+
+	return stream.SendAndClose(&pb.ImportProductsResponse{
+		Created: int32(report.Created),
+		Updated: int32(report.Updated),
+		Failed:  int32(report.Failed),
+		DryRun:  report.DryRun,
+		Errors:  rowErrors,
+	})
+}
+
+func (h *GRPCHandler) ExportProducts(req *pb.ExportProductsRequest, stream pb.ProductService_ExportProductsServer) error {
+	format, err := catalog.ParseFormat(req.GetFormat())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid format: %v", err)
+	}
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	filter := make(map[string]interface{})
+	if req.GetCategory() != "" {
+		filter["category"] = req.GetCategory()
+	}
+	if req.GetBrand() != "" {
+		filter["brand"] = req.GetBrand()
+	}
+
+	writer := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, exportChunkSize)
+	if err := h.productService.ExportProducts(stream.Context(), format, system, filter, writer); err != nil {
+		return status.Errorf(codes.Internal, "failed to export products: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to export products: %v", err)
+	}
+	return nil
+}
+
+func (h *GRPCHandler) UploadProductImage(stream pb.ProductService_UploadProductImageServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty upload stream")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to receive image: %v", err)
+	}
+
+	reader := &chunkReader{buf: first.GetData(), recv: func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetData(), err
+	}}
+	image, err := h.productService.UploadImage(stream.Context(), first.GetProductId(), first.GetAltText(), reader)
+	if err != nil {
+		return imageError("failed to upload image", err)
+	}
+
+	return stream.SendAndClose(image.ToProto())
+}
+
+func (h *GRPCHandler) UpdateProductImage(ctx context.Context, req *pb.UpdateProductImageRequest) (*pb.ProductImage, error) {
+	image, err := h.productService.UpdateImage(ctx, req.GetProductId(), req.GetImageId(), req.GetAltText(), req.GetSortOrder())
+	if err != nil {
+		return nil, imageError("failed to update image", err)
+	}
+	if image == nil {
+		return nil, status.Error(codes.NotFound, "image not found")
+	}
+
+	return image.ToProto(), nil
+}
+
+func (h *GRPCHandler) DeleteProductImage(ctx context.Context, req *pb.DeleteProductImageRequest) (*pb.DeleteProductImageResponse, error) {
+	if err := h.productService.DeleteImage(ctx, req.GetProductId(), req.GetImageId()); err != nil {
+		return nil, imageError("failed to delete image", err)
+	}
+
+	return &pb.DeleteProductImageResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *GRPCHandler) GetPriceHistory(ctx context.Context, req *pb.GetPriceHistoryRequest) (*pb.PriceHistoryResponse, error) {
+	history, err := h.productService.GetPriceHistory(ctx, req.GetProductId(), int(req.GetDays()), int(req.GetLimit()))
+	if err != nil {
+		return nil, priceError("failed to get price history", err)
+	}
+
+	return history.ToProto(), nil
+}
+
+func (h *GRPCHandler) SchedulePriceChange(ctx context.Context, req *pb.SchedulePriceChangeRequest) (*pb.ScheduledPrice, error) {
+	effectiveAt, err := time.Parse(time.RFC3339, req.GetEffectiveAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid effective_at: %v", err)
+	}
+
+	scheduled, err := h.productService.SchedulePriceChange(ctx, req.GetProductId(), req.GetPrice(), effectiveAt)
+	if err != nil {
+		return nil, priceError("failed to schedule price change", err)
+	}
+
+	return scheduled.ToProto(), nil
+}
+
+func (h *GRPCHandler) ListScheduledPrices(ctx context.Context, req *pb.ListScheduledPricesRequest) (*pb.ListScheduledPricesResponse, error) {
+	scheduled, err := h.productService.ListScheduledPrices(ctx, req.GetProductId(), model.ScheduleStatus(strings.ToUpper(req.GetStatus())))
+	if err != nil {
+		return nil, priceError("failed to list scheduled prices", err)
+	}
+
+	pbScheduled := make([]*pb.ScheduledPrice, len(scheduled))
+	for i, price := range scheduled {
+		pbScheduled[i] = price.ToProto()
+	}
+
+	return &pb.ListScheduledPricesResponse{
+		ScheduledPrices: pbScheduled,
+	}, nil
+}
+
+func (h *GRPCHandler) CancelScheduledPrice(ctx context.Context, req *pb.CancelScheduledPriceRequest) (*pb.ScheduledPrice, error) {
+	scheduled, err := h.productService.CancelScheduledPrice(ctx, req.GetId(), req.GetProductId())
+	if err != nil {
+		return nil, priceError("failed to cancel scheduled price", err)
+	}
+
+	return scheduled.ToProto(), nil
+}
+
+func (h *GRPCHandler) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	if req.GetCategory() == nil {
+		return nil, status.Error(codes.InvalidArgument, "category is required")
+	}
+
+	category, err := h.productService.CreateCategory(ctx, model.CategoryFromProto(req.GetCategory()))
+	if err != nil {
+		return nil, categoryError("failed to create category", err)
+	}
+
+	return h.categoryToProto(ctx, category, "")
+}
+
+func (h *GRPCHandler) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.Category, error) {
+	tree, err := h.productService.CategoryTree(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get category: %v", err)
+	}
+
+	category, ok := tree.Find(req.GetId())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "category not found")
+	}
+
+	return tree.NodeToProto(category, req.GetLocale()), nil
+}
+
+func (h *GRPCHandler) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.Category, error) {
+	if req.GetCategory() == nil {
+		return nil, status.Error(codes.InvalidArgument, "category is required")
+	}
+
+	category, err := h.productService.UpdateCategory(ctx, model.CategoryFromProto(req.GetCategory()))
+	if err != nil {
+		return nil, categoryError("failed to update category", err)
+	}
+
+	return h.categoryToProto(ctx, category, "")
+}
+
+func (h *GRPCHandler) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if err := h.productService.DeleteCategory(ctx, req.GetId()); err != nil {
+		return nil, categoryError("failed to delete category", err)
+	}
+
+	return &pb.DeleteCategoryResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *GRPCHandler) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	tree, err := h.productService.CategoryTree(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list categories: %v", err)
+	}
+
+	return &pb.ListCategoriesResponse{
+		Categories: tree.ToProto(req.GetLocale()),
+	}, nil
+}
+
+func (h *GRPCHandler) CreateBrand(ctx context.Context, req *pb.CreateBrandRequest) (*pb.Brand, error) {
+	if req.GetBrand() == nil {
+		return nil, status.Error(codes.InvalidArgument, "brand is required")
+	}
+
+	brand, err := h.productService.CreateBrand(ctx, model.BrandFromProto(req.GetBrand()))
+	if err != nil {
+		return nil, brandError("failed to create brand", err)
+	}
+
+	return brand.ToProto(), nil
+}
+
+func (h *GRPCHandler) GetBrand(ctx context.Context, req *pb.GetBrandRequest) (*pb.Brand, error) {
+	brand, err := h.productService.GetBrand(ctx, req.GetId())
+	if err != nil {
+		return nil, brandError("failed to get brand", err)
+	}
+
+	return brand.ToProto(), nil
+}
+
+func (h *GRPCHandler) UpdateBrand(ctx context.Context, req *pb.UpdateBrandRequest) (*pb.Brand, error) {
+	if req.GetBrand() == nil {
+		return nil, status.Error(codes.InvalidArgument, "brand is required")
+	}
+
+	brand, err := h.productService.UpdateBrand(ctx, model.BrandFromProto(req.GetBrand()))
+	if err != nil {
+		return nil, brandError("failed to update brand", err)
+	}
+
+	return brand.ToProto(), nil
+}
+
+func (h *GRPCHandler) DeleteBrand(ctx context.Context, req *pb.DeleteBrandRequest) (*pb.DeleteBrandResponse, error) {
+	if err := h.productService.DeleteBrand(ctx, req.GetId()); err != nil {
+		return nil, brandError("failed to delete brand", err)
+	}
+
+	return &pb.DeleteBrandResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *GRPCHandler) ListBrands(ctx context.Context, req *pb.ListBrandsRequest) (*pb.ListBrandsResponse, error) {
+	brands, err := h.productService.ListBrands(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list brands: %v", err)
+	}
+
+	pbBrands := make([]*pb.Brand, len(brands))
+	for i, brand := range brands {
+		pbBrands[i] = brand.ToProto()
+	}
+
+	return &pb.ListBrandsResponse{
+		Brands: pbBrands,
+	}, nil
+}
+
+// categoryToProto отдает категорию с хлебными крошками и поддеревом
+func (h *GRPCHandler) SubscribeBackInStock(ctx context.Context, req *pb.SubscribeBackInStockRequest) (*pb.StockSubscription, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	subscription, err := h.productService.SubscribeBackInStock(ctx, req.GetUserId(), req.GetEmail(), req.GetProductId(), req.GetSize(), system)
+	if err != nil {
+		return nil, subscriptionError("failed to subscribe", err)
+	}
+
+	return subscription.ToProto(), nil
+}
+
+func (h *GRPCHandler) UnsubscribeBackInStock(ctx context.Context, req *pb.UnsubscribeBackInStockRequest) (*pb.UnsubscribeBackInStockResponse, error) {
+	if err := h.productService.UnsubscribeBackInStock(ctx, req.GetId(), req.GetUserId()); err != nil {
+		return nil, subscriptionError("failed to unsubscribe", err)
+	}
+
+	return &pb.UnsubscribeBackInStockResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *GRPCHandler) ListStockSubscriptions(ctx context.Context, req *pb.ListStockSubscriptionsRequest) (*pb.ListStockSubscriptionsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	subscriptions, err := h.productService.ListStockSubscriptions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list stock subscriptions: %v", err)
+	}
+
+	pbSubscriptions := make([]*pb.StockSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		pbSubscriptions[i] = subscription.ToProto()
+	}
+
+	return &pb.ListStockSubscriptionsResponse{
+		Subscriptions: pbSubscriptions,
+	}, nil
+}
+
+func (h *GRPCHandler) GetRecommendations(ctx context.Context, req *pb.RecommendationsRequest) (*pb.RecommendationsResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	productIDs := req.GetProductIds()
+	if req.GetProductId() != "" {
+		productIDs = append([]string{req.GetProductId()}, productIDs...)
+	}
+
+	recommendations, err := h.productService.GetRecommendations(ctx, productIDs, req.GetUserId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get recommendations: %v", err)
+	}
+
+	pbRecommendations := make([]*pb.Recommendation, len(recommendations))
+	for i, recommendation := range recommendations {
+		pbRecommendations[i] = &pb.Recommendation{
+			Product: h.productToProto(ctx, recommendation.Product, system, req.GetLocale()),
+			Reason:  string(recommendation.Reason),
+			Score:   recommendation.Score,
+		}
+	}
+
+	return &pb.RecommendationsResponse{
+		Recommendations: pbRecommendations,
+	}, nil
+}
+
+func (h *GRPCHandler) categoryToProto(ctx context.Context, category *model.Category, locale string) (*pb.Category, error) {
+	tree, err := h.productService.CategoryTree(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load categories: %v", err)
+	}
+	if node, ok := tree.ByID(category.ID); ok {
+		category = node
+	}
+	return tree.NodeToProto(category, locale), nil
+}
+
+func (h *GRPCHandler) productToProto(ctx context.Context, product *model.Product, system sizing.System, locale string) *pb.Product {
+	return h.productsToProto(ctx, []*model.Product{product}, system, locale)[0]
+}
+
+// productsToProto добавляет к товарам хлебные крошки категорий. Если дерево
+// не загрузилось, товары все равно отдаются, только без крошек
+func (h *GRPCHandler) productsToProto(ctx context.Context, products []*model.Product, system sizing.System, locale string) []*pb.Product {
+	tree, err := h.productService.CategoryTree(ctx)
+	if err != nil {
+		fmt.Printf("failed to load categories for breadcrumbs: %v\n", err)
+	}
+
 	pbProducts := make([]*pb.Product, len(products))
 	for i, product := range products {
-		pbProducts[i] = product.ToProto()
+		pbProducts[i] = product.ToProto(system)
+		if tree != nil {
+			pbProducts[i].Breadcrumbs = tree.Breadcrumbs(product.Category, locale)
+		}
+	}
+	return pbProducts
+}
+
+func categoryError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidCategory):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, service.ErrCategoryExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", message, err)
+	case errors.Is(err, service.ErrCategoryNotEmpty):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: category not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
 	}
+}
 
-	return &pb.ListProductsResponse{
-		Products: pbProducts,
-	}, nil
-} 
\ No newline at end of file
+func productError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrVersionConflict):
+		return status.Errorf(codes.Aborted, "%s: %v", message, err)
+	case errors.Is(err, model.ErrInvalidUpdateMask):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, model.ErrVersionRequired):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, model.ErrArchived), errors.Is(err, model.ErrNotArchived):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: product not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func brandError(message string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidBrand):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, service.ErrBrandExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", message, err)
+	case errors.Is(err, service.ErrBrandInUse):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: brand not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func priceError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidPriceChange):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, service.ErrScheduleNotPending):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func subscriptionError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidSubscription):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, service.ErrInStock), errors.Is(err, model.ErrArchived):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: product or subscription not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func imageError(message string, err error) error {
+	switch {
+	case errors.Is(err, imaging.ErrInvalidImage):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", message, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: product or image not found", message)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+// chunkReader представляет поток сообщений с кусками файла как io.Reader
+type chunkReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		data, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// exportStreamWriter отправляет каждый Write отдельным сообщением потока
+type exportStreamWriter struct {
+	stream pb.ProductService_ExportProductsServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.ExportProductsChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sizeSystem возвращает систему, в которой клиент прислал товар; FromProto
+// уже отклонил неизвестные системы
+func sizeSystem(product *pb.Product) sizing.System {
+	system, _ := sizing.ParseSystem(product.GetSizeSystem())
+	return system
+}
@@ -0,0 +1,147 @@
+package imaging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxUploadSize - максимальный размер исходного файла
+	MaxUploadSize = 10 << 20
+	// maxPixels защищает от "бомб": маленький файл с огромным разрешением
+	maxPixels = 40_000_000
+	// Длина ID изображения в символах hex
+	idLength = 32
+)
+
+// ThumbnailWidths - ширины миниатюр; ширины больше исходной пропускаются
+var ThumbnailWidths = []int{160, 480, 1024}
+
+var (
+	ErrInvalidImage = errors.New("invalid image")
+	ErrTooLarge     = fmt.Errorf("%w: file exceeds %d bytes", ErrInvalidImage, MaxUploadSize)
+)
+
+// Расширения файлов для поддерживаемых типов
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// Rendition - файл изображения, готовый к сохранению
+type Rendition struct {
+	Name        string
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Processed - исходное изображение и его миниатюры
+type Processed struct {
+	ID         string
+	Original   Rendition
+	Thumbnails []Rendition
+}
+
+// Process проверяет загруженный файл и строит миниатюры.
+// Тип определяется по содержимому, а не по имени файла или заголовкам клиента
+func Process(data []byte) (*Processed, error) {
+	if len(data) > MaxUploadSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported content type %s", ErrInvalidImage, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("%w: unsupported dimensions %dx%d", ErrInvalidImage, config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:])[:idLength]
+
+	processed := &Processed{
+		ID: id,
+		Original: Rendition{
+			Name:        id + "." + ext,
+			ContentType: contentType,
+			Width:       config.Width,
+			Height:      config.Height,
+			Data:        data,
+		},
+	}
+
+	for _, width := range ThumbnailWidths {
+		if width >= config.Width {
+			continue
+		}
+
+		thumbnail, err := resize(src, width, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %dpx thumbnail: %w", width, err)
+		}
+		thumbnail.Name = fmt.Sprintf("%s_w%d.%s", id, width, extensions[thumbnail.ContentType])
+		processed.Thumbnails = append(processed.Thumbnails, *thumbnail)
+	}
+
+	return processed, nil
+}
+
+func resize(src image.Image, width int, contentType string) (*Rendition, error) {
+	bounds := src.Bounds()
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	// PNG, GIF и WebP могут быть прозрачными, поэтому их миниатюры сохраняем в PNG
+	thumbType := "image/jpeg"
+	var err error
+	switch contentType {
+	case "image/png", "image/gif", "image/webp":
+		thumbType = "image/png"
+		err = png.Encode(&buf, dst)
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendition{
+		ContentType: thumbType,
+		Width:       width,
+		Height:      height,
+		Data:        buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+var ErrInvalidBrand = errors.New("invalid brand")
+
+// Brand - производитель обуви. Товары хранят ID бренда, а название
+// и смещение таблицы размеров копируются в товар для фильтров и поиска
+type Brand struct {
+	ID          string `bson:"_id"`
+	Slug        string `bson:"slug"`
+	Name        string `bson:"name"`
+	LogoURL     string `bson:"logo_url"`
+	Description string `bson:"description"`
+	Country     string `bson:"country"`
+	// SizeOffset сдвигает стандартную таблицу размеров: бренды, которые
+	// маломерят, получают отрицательное смещение
+	SizeOffset sizing.Size `bson:"size_offset"`
+	CreatedAt  time.Time   `bson:"created_at"`
+	UpdatedAt  time.Time   `bson:"updated_at"`
+}
+
+func (b *Brand) SizeTable() *sizing.Table {
+	return sizing.WithOffset(b.SizeOffset)
+}
+
+func (b *Brand) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBrand)
+	}
+	if b.Slug != "" && !slugPattern.MatchString(b.Slug) {
+		return fmt.Errorf("%w: slug must contain only lowercase letters, digits and single dashes", ErrInvalidBrand)
+	}
+	if err := sizing.ValidateOffset(b.SizeOffset); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBrand, err)
+	}
+	return nil
+}
+
+// GenerateSlug заполняет пустой slug из названия, а для названий
+// без латиницы - из ID
+func (b *Brand) GenerateSlug() {
+	if b.Slug != "" {
+		return
+	}
+	b.Slug = Slugify(b.Name)
+	if b.Slug == "" {
+		b.Slug = Slugify(b.ID)
+	}
+}
+
+func (b *Brand) ToProto() *pb.Brand {
+	chart := b.SizeTable().Chart()
+	sizeChart := make([]*pb.SizeChartRow, len(chart))
+	for i, row := range chart {
+		sizeChart[i] = &pb.SizeChartRow{
+			Length: int32(row.Length),
+			Eu:     row.EU,
+			UsM:    row.USMen,
+			UsW:    row.USWomen,
+			Uk:     row.UK,
+		}
+	}
+
+	return &pb.Brand{
+		Id:          b.ID,
+		Slug:        b.Slug,
+		Name:        b.Name,
+		LogoUrl:     b.LogoURL,
+		Description: b.Description,
+		Country:     b.Country,
+		SizeOffset:  int32(b.SizeOffset),
+		SizeChart:   sizeChart,
+		CreatedAt:   b.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   b.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func BrandFromProto(pbBrand *pb.Brand) *Brand {
+	return &Brand{
+		ID:          pbBrand.Id,
+		Slug:        strings.TrimSpace(pbBrand.Slug),
+		Name:        strings.TrimSpace(pbBrand.Name),
+		LogoURL:     strings.TrimSpace(pbBrand.LogoUrl),
+		Description: pbBrand.Description,
+		Country:     strings.TrimSpace(pbBrand.Country),
+		SizeOffset:  sizing.Size(pbBrand.SizeOffset),
+	}
+}
+
+// BrandCatalog - все бренды в памяти для поиска по ID, slug и названию
+type BrandCatalog struct {
+	brands []*Brand
+	byID   map[string]*Brand
+	bySlug map[string]*Brand
+	byName map[string]*Brand
+}
+
+func NewBrandCatalog(brands []*Brand) *BrandCatalog {
+	c := &BrandCatalog{
+		byID:   make(map[string]*Brand, len(brands)),
+		bySlug: make(map[string]*Brand, len(brands)),
+		byName: make(map[string]*Brand, len(brands)),
+	}
+	for _, b := range brands {
+		c.Add(b)
+	}
+	return c
+}
+
+func (c *BrandCatalog) Add(b *Brand) {
+	c.brands = append(c.brands, b)
+	c.byID[b.ID] = b
+	c.bySlug[b.Slug] = b
+	c.byName[strings.ToLower(b.Name)] = b
+}
+
+func (c *BrandCatalog) ByID(id string) (*Brand, bool) {
+	b, ok := c.byID[id]
+	return b, ok
+}
+
+// Find ищет бренд по ID, slug или названию без учета регистра
+func (c *BrandCatalog) Find(value string) (*Brand, bool) {
+	value = strings.TrimSpace(value)
+	if b, ok := c.byID[value]; ok {
+		return b, true
+	}
+	if b, ok := c.bySlug[value]; ok {
+		return b, true
+	}
+	b, ok := c.byName[strings.ToLower(value)]
+	return b, ok
+}
+
+func (c *BrandCatalog) All() []*Brand {
+	return c.brands
+}
+
+// SizeTable возвращает таблицу размеров бренда по названию. Для еще не
+// заведенного бренда берется встроенная таблица - с ней он и будет создан
+func (c *BrandCatalog) SizeTable(name string) *sizing.Table {
+	if b, ok := c.Find(name); ok {
+		return b.SizeTable()
+	}
+	return sizing.TableFor(name)
+}
@@ -0,0 +1,309 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "shoeshop/proto"
+)
+
+// DefaultLocale - язык, на который откатываются названия без перевода
+const DefaultLocale = "en"
+
+var ErrInvalidCategory = errors.New("invalid category")
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Category - узел дерева категорий. Товар ссылается на категорию по Slug
+type Category struct {
+	ID        string            `bson:"_id"`
+	Slug      string            `bson:"slug"`
+	ParentID  string            `bson:"parent_id"`
+	Names     map[string]string `bson:"names"`
+	SortOrder int32             `bson:"sort_order"`
+	CreatedAt time.Time         `bson:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+}
+
+// Name возвращает название на языке locale, иначе на языке по умолчанию
+func (c *Category) Name(locale string) string {
+	if name := c.Names[locale]; name != "" {
+		return name
+	}
+	if name := c.Names[DefaultLocale]; name != "" {
+		return name
+	}
+	// Любой перевод лучше, чем slug; берем первый по языку для стабильности
+	locales := make([]string, 0, len(c.Names))
+	for l := range c.Names {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	for _, l := range locales {
+		if c.Names[l] != "" {
+			return c.Names[l]
+		}
+	}
+	return c.Slug
+}
+
+func (c *Category) Validate() error {
+	if c.Slug != "" && !slugPattern.MatchString(c.Slug) {
+		return fmt.Errorf("%w: slug must contain only lowercase letters, digits and single dashes", ErrInvalidCategory)
+	}
+	for locale, name := range c.Names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: empty name for locale %q", ErrInvalidCategory, locale)
+		}
+	}
+	if len(c.Names) == 0 {
+		return fmt.Errorf("%w: at least one name is required", ErrInvalidCategory)
+	}
+	if c.ParentID != "" && c.ParentID == c.ID {
+		return fmt.Errorf("%w: category cannot be its own parent", ErrInvalidCategory)
+	}
+	return nil
+}
+
+// Slugify строит slug из названия: "Men's Trail" -> "men-s-trail".
+// Буквы вне латиницы отбрасываются, поэтому результат может быть пустым
+func Slugify(parts ...string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.Join(parts, " ")) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// GenerateSlug заполняет пустой slug из slug родителя и названия.
+// Для названий без латиницы используется ID, он тоже подходит под формат
+func (c *Category) GenerateSlug(parent *Category) {
+	if c.Slug != "" {
+		return
+	}
+	parentSlug := ""
+	if parent != nil {
+		parentSlug = parent.Slug
+	}
+	c.Slug = Slugify(parentSlug, c.Name(DefaultLocale))
+	if c.Slug == parentSlug {
+		c.Slug = Slugify(parentSlug, c.ID)
+	}
+}
+
+func (c *Category) ToProto(locale string) *pb.Category {
+	names := make(map[string]string, len(c.Names))
+	for l, name := range c.Names {
+		names[l] = name
+	}
+
+	return &pb.Category{
+		Id:        c.ID,
+		Slug:      c.Slug,
+		ParentId:  c.ParentID,
+		Names:     names,
+		Name:      c.Name(locale),
+		SortOrder: c.SortOrder,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func CategoryFromProto(pbCategory *pb.Category) *Category {
+	names := make(map[string]string, len(pbCategory.Names))
+	for l, name := range pbCategory.Names {
+		names[strings.ToLower(strings.TrimSpace(l))] = strings.TrimSpace(name)
+	}
+
+	return &Category{
+		ID:        pbCategory.Id,
+		Slug:      strings.TrimSpace(pbCategory.Slug),
+		ParentID:  pbCategory.ParentId,
+		Names:     names,
+		SortOrder: pbCategory.SortOrder,
+	}
+}
+
+// CategoryTree - дерево категорий в памяти для навигации по нему
+type CategoryTree struct {
+	byID     map[string]*Category
+	bySlug   map[string]*Category
+	children map[string][]*Category
+}
+
+func NewCategoryTree(categories []*Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:     make(map[string]*Category, len(categories)),
+		bySlug:   make(map[string]*Category, len(categories)),
+		children: make(map[string][]*Category),
+	}
+	for _, c := range categories {
+		t.Add(c)
+	}
+	return t
+}
+
+// Add добавляет узел, сохраняя порядок детей: sort_order, затем название
+func (t *CategoryTree) Add(c *Category) {
+	t.byID[c.ID] = c
+	t.bySlug[c.Slug] = c
+
+	siblings := append(t.children[c.ParentID], c)
+	sort.SliceStable(siblings, func(i, j int) bool {
+		if siblings[i].SortOrder != siblings[j].SortOrder {
+			return siblings[i].SortOrder < siblings[j].SortOrder
+		}
+		return siblings[i].Name(DefaultLocale) < siblings[j].Name(DefaultLocale)
+	})
+	t.children[c.ParentID] = siblings
+}
+
+func (t *CategoryTree) ByID(id string) (*Category, bool) {
+	c, ok := t.byID[id]
+	return c, ok
+}
+
+func (t *CategoryTree) BySlug(slug string) (*Category, bool) {
+	c, ok := t.bySlug[slug]
+	return c, ok
+}
+
+// Find ищет категорию по id или slug
+func (t *CategoryTree) Find(idOrSlug string) (*Category, bool) {
+	if c, ok := t.byID[idOrSlug]; ok {
+		return c, true
+	}
+	return t.BySlug(idOrSlug)
+}
+
+// Resolve находит категорию по slug или, для старых данных и файлов
+// импорта, по названию на любом языке без учета регистра
+func (t *CategoryTree) Resolve(value string) (*Category, bool) {
+	value = strings.TrimSpace(value)
+	if c, ok := t.bySlug[value]; ok {
+		return c, true
+	}
+
+	var found *Category
+	for _, c := range t.byID {
+		for _, name := range c.Names {
+			if strings.EqualFold(name, value) && (found == nil || c.Slug < found.Slug) {
+				found = c
+			}
+		}
+	}
+	return found, found != nil
+}
+
+func (t *CategoryTree) Roots() []*Category {
+	return t.children[""]
+}
+
+func (t *CategoryTree) Children(id string) []*Category {
+	return t.children[id]
+}
+
+// Path возвращает цепочку категорий от корня до указанной
+func (t *CategoryTree) Path(slug string) []*Category {
+	c, ok := t.bySlug[slug]
+	if !ok {
+		return nil
+	}
+
+	var path []*Category
+	// Ограничение глубины защищает от цикла в испорченных данных
+	for i := 0; c != nil && i <= len(t.byID); i++ {
+		path = append([]*Category{c}, path...)
+		c = t.byID[c.ParentID]
+	}
+	return path
+}
+
+// Descendants возвращает slug категории и всех ее потомков
+func (t *CategoryTree) Descendants(slug string) []string {
+	root, ok := t.bySlug[slug]
+	if !ok {
+		return nil
+	}
+
+	slugs := []string{}
+	seen := make(map[string]bool)
+	queue := []*Category{root}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		slugs = append(slugs, c.Slug)
+		queue = append(queue, t.children[c.ID]...)
+	}
+	return slugs
+}
+
+// IsDescendant сообщает, лежит ли категория id в поддереве ancestorID
+func (t *CategoryTree) IsDescendant(id, ancestorID string) bool {
+	c, ok := t.byID[id]
+	for i := 0; ok && i <= len(t.byID); i++ {
+		if c.ID == ancestorID {
+			return true
+		}
+		c, ok = t.byID[c.ParentID]
+	}
+	return false
+}
+
+func (t *CategoryTree) Breadcrumbs(slug, locale string) []*pb.Breadcrumb {
+	path := t.Path(slug)
+	breadcrumbs := make([]*pb.Breadcrumb, len(path))
+	for i, c := range path {
+		breadcrumbs[i] = &pb.Breadcrumb{
+			Id:   c.ID,
+			Slug: c.Slug,
+			Name: c.Name(locale),
+		}
+	}
+	return breadcrumbs
+}
+
+// NodeToProto конвертирует категорию вместе с хлебными крошками и поддеревом
+func (t *CategoryTree) NodeToProto(c *Category, locale string) *pb.Category {
+	pbCategory := c.ToProto(locale)
+	pbCategory.Breadcrumbs = t.Breadcrumbs(c.Slug, locale)
+	pbCategory.Children = t.subtreeToProto(c.ID, locale, 0)
+	return pbCategory
+}
+
+// ToProto возвращает корневые категории с вложенными детьми
+func (t *CategoryTree) ToProto(locale string) []*pb.Category {
+	return t.subtreeToProto("", locale, 0)
+}
+
+func (t *CategoryTree) subtreeToProto(parentID, locale string, depth int) []*pb.Category {
+	children := t.children[parentID]
+	if depth > len(t.byID) {
+		return nil
+	}
+
+	nodes := make([]*pb.Category, len(children))
+	for i, c := range children {
+		nodes[i] = c.ToProto(locale)
+		nodes[i].Children = t.subtreeToProto(c.ID, locale, depth+1)
+	}
+	return nodes
+}
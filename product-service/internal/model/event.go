@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// OrderItemEvent - позиция заказа из события order.created
+type OrderItemEvent struct {
+	ProductID string
+	Quantity  int32
+}
+
+// OrderCreatedEvent - событие order.created, которое публикует order-service
+type OrderCreatedEvent struct {
+	ID     string
+	UserID string
+	Items  []OrderItemEvent
+}
+
+// ReviewRatingEvent - событие review.rating_changed, которое публикует review-service
+type ReviewRatingEvent struct {
+	ProductID string
+	Rating    float64
+	Count     int32
+}
+
+// LowStockEvent - событие product.low_stock: остаток товара дошел до порога дозаказа
+type LowStockEvent struct {
+	ProductID string
+	SKU       string
+	Name      string
+	Stock     int32
+	Threshold int32
+	At        time.Time
+}
+
+// BackInStockEvent - событие product.back_in_stock: email-service отправляет
+// по нему письмо подписчику
+type BackInStockEvent struct {
+	SubscriptionID string
+	UserID         string
+	Email          string
+	ProductID      string
+	ProductName    string
+	Size           string
+	Price          float64
+	At             time.Time
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	pb "shoeshop/proto"
+)
+
+// Thumbnail - уменьшенная копия изображения фиксированной ширины
+type Thumbnail struct {
+	URL    string `bson:"url"`
+	Width  int32  `bson:"width"`
+	Height int32  `bson:"height"`
+}
+
+// Image - изображение товара. ID - хэш содержимого, поэтому повторная
+// загрузка того же файла не создает дубликат
+type Image struct {
+	ID          string      `bson:"id"`
+	URL         string      `bson:"url"`
+	Width       int32       `bson:"width"`
+	Height      int32       `bson:"height"`
+	Alt         string      `bson:"alt"`
+	SortOrder   int32       `bson:"sort_order"`
+	ContentType string      `bson:"content_type"`
+	Size        int64       `bson:"size"`
+	Thumbnails  []Thumbnail `bson:"thumbnails"`
+}
+
+// UnmarshalBSONValue понимает и старый формат, где изображение было просто строкой с URL
+func (i *Image) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.String {
+		var url string
+		if err := bson.UnmarshalValue(t, data, &url); err != nil {
+			return err
+		}
+		*i = Image{ID: url, URL: url}
+		return nil
+	}
+
+	type plain Image
+	return bson.Unmarshal(data, (*plain)(i))
+}
+
+func (i *Image) ToProto() *pb.ProductImage {
+	thumbnails := make([]*pb.ImageThumbnail, len(i.Thumbnails))
+	for k, thumbnail := range i.Thumbnails {
+		thumbnails[k] = &pb.ImageThumbnail{
+			Url:    thumbnail.URL,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		}
+	}
+
+	return &pb.ProductImage{
+		Id:          i.ID,
+		Url:         i.URL,
+		Width:       i.Width,
+		Height:      i.Height,
+		AltText:     i.Alt,
+		SortOrder:   i.SortOrder,
+		ContentType: i.ContentType,
+		Size:        i.Size,
+		Thumbnails:  thumbnails,
+	}
+}
+
+func ImageFromProto(pbImage *pb.ProductImage) Image {
+	thumbnails := make([]Thumbnail, len(pbImage.Thumbnails))
+	for k, thumbnail := range pbImage.Thumbnails {
+		thumbnails[k] = Thumbnail{
+			URL:    thumbnail.Url,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		}
+	}
+
+	id := pbImage.Id
+	if id == "" {
+		// Внешние изображения, заданные только URL
+		id = pbImage.Url
+	}
+
+	return Image{
+		ID:          id,
+		URL:         pbImage.Url,
+		Width:       pbImage.Width,
+		Height:      pbImage.Height,
+		Alt:         pbImage.AltText,
+		SortOrder:   pbImage.SortOrder,
+		ContentType: pbImage.ContentType,
+		Size:        pbImage.Size,
+		Thumbnails:  thumbnails,
+	}
+}
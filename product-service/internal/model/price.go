@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	pb "shoeshop/proto"
+)
+
+// PriceSource - откуда пришло изменение цены
+type PriceSource string
+
+const (
+	PriceSourceCreate   PriceSource = "CREATE"
+	PriceSourceUpdate   PriceSource = "UPDATE"
+	PriceSourceImport   PriceSource = "IMPORT"
+	PriceSourceSchedule PriceSource = "SCHEDULE"
+)
+
+// PriceChange - запись истории цен товара
+type PriceChange struct {
+	ID            string      `bson:"_id"`
+	ProductID     string      `bson:"product_id"`
+	Price         float64     `bson:"price"`
+	PreviousPrice float64     `bson:"previous_price"`
+	Source        PriceSource `bson:"source"`
+	ChangedAt     time.Time   `bson:"changed_at"`
+}
+
+// ScheduleStatus - состояние запланированного изменения цены
+type ScheduleStatus string
+
+const (
+	ScheduleStatusPending  ScheduleStatus = "PENDING"
+	ScheduleStatusApplied  ScheduleStatus = "APPLIED"
+	ScheduleStatusCanceled ScheduleStatus = "CANCELED"
+)
+
+func (s ScheduleStatus) Valid() bool {
+	switch s {
+	case ScheduleStatusPending, ScheduleStatusApplied, ScheduleStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// ScheduledPrice - цена, которую фоновый обработчик установит в EffectiveAt
+type ScheduledPrice struct {
+	ID          string         `bson:"_id"`
+	ProductID   string         `bson:"product_id"`
+	Price       float64        `bson:"price"`
+	EffectiveAt time.Time      `bson:"effective_at"`
+	Status      ScheduleStatus `bson:"status"`
+	CreatedAt   time.Time      `bson:"created_at"`
+	AppliedAt   time.Time      `bson:"applied_at,omitempty"`
+}
+
+// PriceHistory - история цен товара и минимальная цена за окно Days
+type PriceHistory struct {
+	ProductID    string
+	CurrentPrice float64
+	LowestPrice  float64
+	Days         int
+	Changes      []*PriceChange
+}
+
+func (c *PriceChange) ToProto() *pb.PriceChange {
+	return &pb.PriceChange{
+		Price:         c.Price,
+		PreviousPrice: c.PreviousPrice,
+		Source:        string(c.Source),
+		ChangedAt:     c.ChangedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *ScheduledPrice) ToProto() *pb.ScheduledPrice {
+	appliedAt := ""
+	if !s.AppliedAt.IsZero() {
+		appliedAt = s.AppliedAt.Format(time.RFC3339)
+	}
+
+	return &pb.ScheduledPrice{
+		Id:          s.ID,
+		ProductId:   s.ProductID,
+		Price:       s.Price,
+		EffectiveAt: s.EffectiveAt.Format(time.RFC3339),
+		Status:      string(s.Status),
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+		AppliedAt:   appliedAt,
+	}
+}
+
+func (h *PriceHistory) ToProto() *pb.PriceHistoryResponse {
+	changes := make([]*pb.PriceChange, len(h.Changes))
+	for i, change := range h.Changes {
+		changes[i] = change.ToProto()
+	}
+
+	return &pb.PriceHistoryResponse{
+		ProductId:    h.ProductID,
+		CurrentPrice: h.CurrentPrice,
+		LowestPrice:  h.LowestPrice,
+		Days:         int32(h.Days),
+		Changes:      changes,
+	}
+}
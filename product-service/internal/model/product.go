@@ -1,53 +1,180 @@
 package model
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
 	"time"
 	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+var (
+	// ErrVersionConflict - товар изменили после того, как клиент его прочитал
+	ErrVersionConflict   = errors.New("product was modified concurrently")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+	ErrArchived          = errors.New("product is archived")
+	ErrNotArchived       = errors.New("product is not archived")
+	// ErrVersionRequired - обновление без версии, которую клиент прочитал
+	ErrVersionRequired = errors.New("product version is required")
 )
 
 type Product struct {
-	ID          string    `bson:"_id,omitempty"`
-	Name        string    `bson:"name"`
-	Description string    `bson:"description"`
-	Price       float64   `bson:"price"`
-	Category    string    `bson:"category"`
-	Brand       string    `bson:"brand"`
-	Sizes       []int     `bson:"sizes"`
-	Colors      []string  `bson:"colors"`
-	Images      []string  `bson:"images"`
-	Stock       int32     `bson:"stock"`
-	CreatedAt   time.Time `bson:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at"`
+	ID          string  `bson:"_id,omitempty"`
+	SKU         string  `bson:"sku"`
+	Name        string  `bson:"name"`
+	Description string  `bson:"description"`
+	Price       float64 `bson:"price"`
+	// Discount - уценка товара долей цены (0.15 - минус 15%), order-service
+	// применяет ее раньше акций
+	Discount float64 `bson:"discount,omitempty"`
+	Category string  `bson:"category"`
+	// Brand и SizeOffset - копия названия и таблицы размеров бренда BrandID
+	BrandID     string        `bson:"brand_id"`
+	Brand       string        `bson:"brand"`
+	SizeOffset  sizing.Size   `bson:"size_offset"`
+	Sizes       []sizing.Size `bson:"sizes"`
+	Colors      []string      `bson:"colors"`
+	Material    string        `bson:"material,omitempty"`
+	Features    []string      `bson:"features,omitempty"`
+	ReleaseDate string        `bson:"release_date,omitempty"` // YYYY-MM-DD
+	Images      []Image       `bson:"images"`
+	Stock       int32         `bson:"stock"`
+	Rating      float64       `bson:"rating"`
+	ReviewCount int32         `bson:"review_count"`
+	CreatedAt   time.Time     `bson:"created_at"`
+	UpdatedAt   time.Time     `bson:"updated_at"`
+	// Version растет при каждой записи; Update применяется, только если
+	// товар все еще в версии, которую прочитал клиент
+	Version int64 `bson:"version"`
+	// DeletedAt задан у архивного товара: он скрыт из каталога и поиска,
+	// но по ID находится, чтобы не ломать историю заказов
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+	// ReorderThreshold - остаток, на котором публикуется product.low_stock;
+	// ноль отключает оповещения
+	ReorderThreshold int32        `bson:"reorder_threshold"`
+	Shipping         ShippingInfo `bson:"shipping_info"`
 }
 
-// ToProto конвертирует доменную модель в protobuf модель
-func (p *Product) ToProto() *pb.Product {
-	// Конвертируем []int в []string для размеров
-	sizes := make([]string, len(p.Sizes))
-	for i, size := range p.Sizes {
-		sizes[i] = fmt.Sprintf("%d", size)
+// LowStock сообщает, что остаток дошел до порога дозаказа
+func (p *Product) LowStock() bool {
+	return p.ReorderThreshold > 0 && p.Stock <= p.ReorderThreshold
+}
+
+// ValidateReleaseDate проверяет, что дата выхода пустая или в формате YYYY-MM-DD
+func ValidateReleaseDate(date string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("release date must be in YYYY-MM-DD format, got %q", date)
+	}
+	return nil
+}
+
+// Archived сообщает, что товар удален в архив
+func (p *Product) Archived() bool {
+	return p.DeletedAt != nil
+}
+
+// updatableFields - поля товара, которые можно указать в маске обновления,
+// и поля документа, которые они меняют. Бренд меняет и свои копии в товаре,
+// size_system только описывает размеры в запросе
+var updatableFields = map[string][]string{
+	"sku":               {"sku"},
+	"name":              {"name"},
+	"description":       {"description"},
+	"price":             {"price"},
+	"discount":          {"discount"},
+	"category":          {"category"},
+	"brand":             {"brand_id", "brand", "size_offset"},
+	"brand_id":          {"brand_id", "brand", "size_offset"},
+	"sizes":             {"sizes"},
+	"colors":            {"colors"},
+	"material":          {"material"},
+	"features":          {"features"},
+	"release_date":      {"release_date"},
+	"stock":             {"stock"},
+	"reorder_threshold": {"reorder_threshold"},
+	"shipping_info":     {"shipping_info"},
+	"size_system":       nil,
+}
+
+// UpdateFields переводит пути маски в поля документа. Пустая маска -
+// пустой список, то есть обновление всех полей
+func UpdateFields(paths []string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		mapped, ok := updatableFields[path]
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+		for _, field := range mapped {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	if len(paths) > 0 && len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidUpdateMask)
+	}
+	return fields, nil
+}
+
+// SizeTable - таблица размеров бренда товара
+func (p *Product) SizeTable() *sizing.Table {
+	return sizing.WithOffset(p.SizeOffset)
+}
+
+// ToProto конвертирует доменную модель в protobuf модель,
+// размеры выводятся в указанной системе по таблице бренда
+func (p *Product) ToProto(system sizing.System) *pb.Product {
+	sizes := p.SizeTable().FormatAll(p.Sizes, system)
+
+	images := make([]*pb.ProductImage, len(p.Images))
+	for i := range p.Images {
+		images[i] = p.Images[i].ToProto()
+	}
+
+	var deletedAt string
+	if p.DeletedAt != nil {
+		deletedAt = p.DeletedAt.Format(time.RFC3339)
 	}
 
 	return &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Category:    p.Category,
-		Brand:       p.Brand,
-		Sizes:       sizes,
-		Colors:      p.Colors,
-		Images:      p.Images,
-		Stock:       p.Stock,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+		Id:               p.ID,
+		Sku:              p.SKU,
+		Name:             p.Name,
+		Description:      p.Description,
+		Price:            p.Price,
+		Discount:         p.Discount,
+		Category:         p.Category,
+		BrandId:          p.BrandID,
+		Brand:            p.Brand,
+		SizeOffset:       int32(p.SizeOffset),
+		Sizes:            sizes,
+		Colors:           p.Colors,
+		Material:         p.Material,
+		Features:         p.Features,
+		ReleaseDate:      p.ReleaseDate,
+		Images:           images,
+		Stock:            p.Stock,
+		Rating:           p.Rating,
+		ReviewCount:      p.ReviewCount,
+		SizeSystem:       string(system),
+		CreatedAt:        p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        p.UpdatedAt.Format(time.RFC3339),
+		Version:          p.Version,
+		DeletedAt:        deletedAt,
+		ReorderThreshold: p.ReorderThreshold,
+		ShippingInfo:     p.Shipping.ToProto(),
 	}
 }
 
-// FromProto конвертирует protobuf модель в доменную модель
-func FromProto(pbProduct *pb.Product) (*Product, error) {
+// FromProto конвертирует protobuf модель в доменную модель. Бренд уже найден
+// по brand_id или названию: по его таблице разбираются размеры
+func FromProto(pbProduct *pb.Product, brand *Brand) (*Product, error) {
 	createdAt, err := time.Parse(time.RFC3339, pbProduct.CreatedAt)
 	if err != nil {
 		createdAt = time.Now()
@@ -58,28 +185,63 @@ func FromProto(pbProduct *pb.Product) (*Product, error) {
 		updatedAt = time.Now()
 	}
 
-	// Конвертируем []string в []int для размеров
-	sizes := make([]int, len(pbProduct.Sizes))
-	for i, size := range pbProduct.Sizes {
-		sizeInt, err := strconv.Atoi(size)
-		if err != nil {
-			return nil, fmt.Errorf("invalid size format: %v", err)
-		}
-		sizes[i] = sizeInt
+	// Размеры приходят в системе запроса и хранятся как длина стопы
+	system, err := sizing.ParseSystem(pbProduct.SizeSystem)
+	if err != nil {
+		return nil, err
+	}
+	// Товар без бренда получает стандартную таблицу
+	if brand == nil {
+		brand = &Brand{}
+	}
+	sizes, err := brand.SizeTable().ParseAll(pbProduct.Sizes, system)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size format: %v", err)
+	}
+
+	if pbProduct.Discount < 0 || pbProduct.Discount >= 1 {
+		return nil, fmt.Errorf("discount must be a fraction of the price from 0 to 1")
+	}
+	if err := ValidateReleaseDate(pbProduct.ReleaseDate); err != nil {
+		return nil, err
+	}
+	if pbProduct.ReorderThreshold < 0 {
+		return nil, fmt.Errorf("reorder threshold must not be negative")
+	}
+	shipping, err := ShippingInfoFromProto(pbProduct.ShippingInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]Image, len(pbProduct.Images))
+	for i, image := range pbProduct.Images {
+		images[i] = ImageFromProto(image)
 	}
 
 	return &Product{
-		ID:          pbProduct.Id,
-		Name:        pbProduct.Name,
-		Description: pbProduct.Description,
-		Price:       pbProduct.Price,
-		Category:    pbProduct.Category,
-		Brand:       pbProduct.Brand,
-		Sizes:       sizes,
-		Colors:      pbProduct.Colors,
-		Images:      pbProduct.Images,
-		Stock:       pbProduct.Stock,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:               pbProduct.Id,
+		SKU:              pbProduct.Sku,
+		Name:             pbProduct.Name,
+		Description:      pbProduct.Description,
+		Price:            pbProduct.Price,
+		Discount:         pbProduct.Discount,
+		Category:         pbProduct.Category,
+		BrandID:          brand.ID,
+		Brand:            brand.Name,
+		SizeOffset:       brand.SizeOffset,
+		Sizes:            sizes,
+		Colors:           pbProduct.Colors,
+		Material:         pbProduct.Material,
+		Features:         pbProduct.Features,
+		ReleaseDate:      pbProduct.ReleaseDate,
+		Images:           images,
+		Stock:            pbProduct.Stock,
+		Rating:           pbProduct.Rating,
+		ReviewCount:      pbProduct.ReviewCount,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+		Version:          pbProduct.Version,
+		ReorderThreshold: pbProduct.ReorderThreshold,
+		Shipping:         shipping,
 	}, nil
-} 
\ No newline at end of file
+}
@@ -0,0 +1,18 @@
+package model
+
+type RecommendationReason string
+
+const (
+	// Товар покупали вместе с запрошенными
+	RecommendationCoPurchase RecommendationReason = "CO_PURCHASE"
+	// Популярный товар для случая, когда истории покупок не хватает
+	RecommendationPopular RecommendationReason = "POPULAR"
+)
+
+// Recommendation - рекомендованный товар. Score - число заказов вместе с
+// запрошенными товарами для CO_PURCHASE и число заказанных единиц для POPULAR
+type Recommendation struct {
+	Product *Product
+	Reason  RecommendationReason
+	Score   int64
+}
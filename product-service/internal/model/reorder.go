@@ -0,0 +1,50 @@
+package model
+
+import (
+	pb "shoeshop/proto"
+)
+
+// ReorderSuggestion - сколько единиц товара дозаказать, чтобы продаж
+// хватило на заданное число дней и остаток не опустился до порога
+type ReorderSuggestion struct {
+	Product   *Product
+	UnitsSold int64
+	// DailyVelocity - средние продажи в день за окно отчета
+	DailyVelocity float64
+	// DaysOfStock - на сколько дней хватит остатка, -1 без продаж
+	DaysOfStock       float64
+	SuggestedQuantity int32
+}
+
+// ReorderReport - товары, которые пора дозаказать, самые срочные первыми
+type ReorderReport struct {
+	Days        int
+	CoverDays   int
+	Suggestions []*ReorderSuggestion
+}
+
+func (s *ReorderSuggestion) ToProto() *pb.ReorderSuggestion {
+	return &pb.ReorderSuggestion{
+		ProductId:         s.Product.ID,
+		Sku:               s.Product.SKU,
+		Name:              s.Product.Name,
+		Stock:             s.Product.Stock,
+		ReorderThreshold:  s.Product.ReorderThreshold,
+		UnitsSold:         s.UnitsSold,
+		DailyVelocity:     s.DailyVelocity,
+		DaysOfStock:       s.DaysOfStock,
+		SuggestedQuantity: s.SuggestedQuantity,
+	}
+}
+
+func (r *ReorderReport) ToProto() *pb.ReorderReport {
+	suggestions := make([]*pb.ReorderSuggestion, len(r.Suggestions))
+	for i, suggestion := range r.Suggestions {
+		suggestions[i] = suggestion.ToProto()
+	}
+	return &pb.ReorderReport{
+		Days:        int32(r.Days),
+		CoverDays:   int32(r.CoverDays),
+		Suggestions: suggestions,
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	pb "shoeshop/proto"
+)
+
+// ShippingInfo - упаковка одной единицы товара, по ней order-service
+// считает стоимость доставки
+type ShippingInfo struct {
+	// Ноль - вес коробки по умолчанию
+	WeightGrams int32 `bson:"weight_grams,omitempty"`
+	LengthMM    int32 `bson:"length_mm,omitempty"`
+	WidthMM     int32 `bson:"width_mm,omitempty"`
+	HeightMM    int32 `bson:"height_mm,omitempty"`
+	// Коды способов доставки; пустой список - любой способ
+	Methods       []string `bson:"methods,omitempty"`
+	FreeShipping  bool     `bson:"free_shipping,omitempty"`
+	International bool     `bson:"international,omitempty"`
+}
+
+func (s ShippingInfo) ToProto() *pb.ShippingInfo {
+	return &pb.ShippingInfo{
+		WeightGrams:   s.WeightGrams,
+		LengthMm:      s.LengthMM,
+		WidthMm:       s.WidthMM,
+		HeightMm:      s.HeightMM,
+		Methods:       s.Methods,
+		FreeShipping:  s.FreeShipping,
+		International: s.International,
+	}
+}
+
+// Validate проверяет размеры упаковки и приводит коды способов доставки
+// к верхнему регистру
+func (s *ShippingInfo) Validate() error {
+	if s.WeightGrams < 0 || s.LengthMM < 0 || s.WidthMM < 0 || s.HeightMM < 0 {
+		return fmt.Errorf("shipping weight and dimensions must not be negative")
+	}
+
+	var methods []string
+	for _, method := range s.Methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method != "" {
+			methods = append(methods, method)
+		}
+	}
+	s.Methods = methods
+	return nil
+}
+
+func ShippingInfoFromProto(info *pb.ShippingInfo) (ShippingInfo, error) {
+	if info == nil {
+		return ShippingInfo{}, nil
+	}
+
+	shipping := ShippingInfo{
+		WeightGrams:   info.WeightGrams,
+		LengthMM:      info.LengthMm,
+		WidthMM:       info.WidthMm,
+		HeightMM:      info.HeightMm,
+		Methods:       info.Methods,
+		FreeShipping:  info.FreeShipping,
+		International: info.International,
+	}
+	if err := shipping.Validate(); err != nil {
+		return ShippingInfo{}, err
+	}
+	return shipping, nil
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+// StockSubscription - подписка пользователя на поступление товара или
+// одного его размера. После отправленного уведомления подписка удаляется
+type StockSubscription struct {
+	ID        string `bson:"_id"`
+	UserID    string `bson:"user_id"`
+	Email     string `bson:"email"`
+	ProductID string `bson:"product_id"`
+	// Size - длина стопы; ноль - подходит любой размер
+	Size sizing.Size `bson:"size"`
+	// SizeLabel - размер в системе SizeSystem, как его выбрал пользователь
+	SizeLabel  string        `bson:"size_label"`
+	SizeSystem sizing.System `bson:"size_system"`
+	CreatedAt  time.Time     `bson:"created_at"`
+	// ClaimedAt - когда обработчик взял подписку для уведомления
+	ClaimedAt time.Time `bson:"claimed_at,omitempty"`
+}
+
+// Available сообщает, что товар из подписки снова можно купить
+func (s *StockSubscription) Available(product *Product) bool {
+	if product.Stock <= 0 || product.Archived() {
+		return false
+	}
+	return s.Size == 0 || slices.Contains(product.Sizes, s.Size)
+}
+
+func (s *StockSubscription) ToProto() *pb.StockSubscription {
+	return &pb.StockSubscription{
+		Id:         s.ID,
+		UserId:     s.UserID,
+		ProductId:  s.ProductID,
+		Size:       s.SizeLabel,
+		SizeSystem: string(s.SizeSystem),
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+	}
+}
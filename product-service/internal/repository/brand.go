@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
+)
+
+type BrandRepository interface {
+	Create(ctx context.Context, brand *model.Brand) (*model.Brand, error)
+	Update(ctx context.Context, brand *model.Brand) (*model.Brand, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*model.Brand, error)
+}
+
+type mongoBrandRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	products   *mongo.Collection
+}
+
+func NewBrandRepository(uri string) (BrandRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	collection := db.Collection("brands")
+
+	_, err = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &mongoBrandRepository{
+		client:     client,
+		collection: collection,
+		products:   db.Collection("products"),
+	}
+	if err := repo.migrateLegacyBrands(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Раньше бренд товара был только названием. Для каждого названия без
+// brand_id находится или создается бренд со встроенной таблицей размеров,
+// и товары получают его ID. Повторный запуск ничего не меняет
+func (r *mongoBrandRepository) migrateLegacyBrands(ctx context.Context) error {
+	legacy := bson.M{"brand_id": bson.M{"$exists": false}, "brand": bson.M{"$nin": bson.A{"", nil}}}
+	names, err := r.products.Distinct(ctx, "brand", legacy)
+	if err != nil {
+		return err
+	}
+
+	brands, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	catalog := model.NewBrandCatalog(brands)
+
+	for _, v := range names {
+		name, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		brand, ok := catalog.Find(name)
+		if !ok {
+			now := time.Now()
+			brand = &model.Brand{
+				ID:         ids.New(),
+				Name:       name,
+				SizeOffset: sizing.OffsetFor(name),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}
+			brand.GenerateSlug()
+			if existing, ok := catalog.Find(brand.Slug); ok {
+				brand = existing
+			} else {
+				if _, err := r.collection.InsertOne(ctx, brand); err != nil {
+					return fmt.Errorf("failed to migrate brand %q: %w", name, err)
+				}
+				catalog.Add(brand)
+			}
+		}
+
+		filter := bson.M{"brand_id": bson.M{"$exists": false}, "brand": name}
+		_, err := r.products.UpdateMany(ctx, filter, bson.M{"$set": bson.M{
+			"brand_id":    brand.ID,
+			"brand":       brand.Name,
+			"size_offset": brand.SizeOffset,
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to migrate products of brand %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (r *mongoBrandRepository) Create(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	now := time.Now()
+	brand.CreatedAt = now
+	brand.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, brand); err != nil {
+		return nil, err
+	}
+	return brand, nil
+}
+
+// Update меняет все, кроме slug
+func (r *mongoBrandRepository) Update(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	var updated model.Brand
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": brand.ID},
+		bson.M{"$set": bson.M{
+			"name":        brand.Name,
+			"logo_url":    brand.LogoURL,
+			"description": brand.Description,
+			"country":     brand.Country,
+			"size_offset": brand.SizeOffset,
+			"updated_at":  time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *mongoBrandRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoBrandRepository) List(ctx context.Context) ([]*model.Brand, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	brands := []*model.Brand{}
+	if err := cursor.All(ctx, &brands); err != nil {
+		return nil, err
+	}
+	return brands, nil
+}
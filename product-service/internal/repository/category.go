@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *model.Category) (*model.Category, error)
+	Update(ctx context.Context, category *model.Category) (*model.Category, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*model.Category, error)
+}
+
+type mongoCategoryRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	products   *mongo.Collection
+}
+
+func NewCategoryRepository(uri string) (CategoryRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	collection := db.Collection("categories")
+
+	_, err = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "sort_order", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &mongoCategoryRepository{
+		client:     client,
+		collection: collection,
+		products:   db.Collection("products"),
+	}
+	if err := repo.migrateLegacyCategories(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Раньше категория товара была произвольной строкой. Каждое такое значение
+// становится корневой категорией (или находит существующую по названию),
+// а товары получают ее slug. Повторный запуск ничего не меняет
+func (r *mongoCategoryRepository) migrateLegacyCategories(ctx context.Context) error {
+	values, err := r.products.Distinct(ctx, "category", bson.M{"category": bson.M{"$nin": bson.A{"", nil}}})
+	if err != nil {
+		return err
+	}
+
+	categories, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	tree := model.NewCategoryTree(categories)
+
+	for _, v := range values {
+		value, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if _, ok := tree.BySlug(value); ok {
+			continue
+		}
+
+		category, ok := tree.Resolve(value)
+		if !ok {
+			now := time.Now()
+			category = &model.Category{
+				ID:        ids.New(),
+				Names:     map[string]string{model.DefaultLocale: value},
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			category.GenerateSlug(nil)
+			// Разные написания одного названия ("Running", "running") сливаются
+			if existing, ok := tree.BySlug(category.Slug); ok {
+				category = existing
+			} else {
+				if _, err := r.collection.InsertOne(ctx, category); err != nil {
+					return fmt.Errorf("failed to migrate category %q: %w", value, err)
+				}
+				tree.Add(category)
+			}
+		}
+
+		_, err := r.products.UpdateMany(ctx,
+			bson.M{"category": value},
+			bson.M{"$set": bson.M{"category": category.Slug}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to migrate products of category %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+func (r *mongoCategoryRepository) Create(ctx context.Context, category *model.Category) (*model.Category, error) {
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// Update меняет названия, родителя и порядок; slug неизменен
+func (r *mongoCategoryRepository) Update(ctx context.Context, category *model.Category) (*model.Category, error) {
+	var updated model.Category
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": category.ID},
+		bson.M{"$set": bson.M{
+			"names":      category.Names,
+			"parent_id":  category.ParentID,
+			"sort_order": category.SortOrder,
+			"updated_at": time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *mongoCategoryRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoCategoryRepository) List(ctx context.Context) ([]*model.Category, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	categories := []*model.Category{}
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"shoeshop/product-service/internal/model"
+)
+
+const redisReconnectInterval = 5 * time.Second
+
+// layeredCache - двухуровневый кэш: LRU в памяти процесса, затем Redis.
+// Если Redis недоступен, кэш работает только на LRU и в фоне переподключается.
+type layeredCache struct {
+	local     *lruCache
+	redisAddr string
+
+	mu     sync.RWMutex
+	remote *redisCache
+	// Пока Redis недоступен, запоминаем, что в нем могло устареть
+	pendingDeletes map[string]struct{}
+	queriesDirty   bool
+	reconnecting   bool
+
+	stop chan struct{}
+}
+
+func NewLayeredCache(redisAddr string, capacity int, ttl time.Duration) Cache {
+	c := &layeredCache{
+		local:          newLRUCache(capacity, ttl),
+		redisAddr:      redisAddr,
+		pendingDeletes: make(map[string]struct{}),
+		stop:           make(chan struct{}),
+	}
+
+	remote, err := newRedisCache(redisAddr)
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis, using in-memory cache only: %v", err)
+		c.mu.Lock()
+		c.queriesDirty = true
+		c.startReconnectLocked()
+		c.mu.Unlock()
+	} else {
+		c.remote = remote
+	}
+
+	return c
+}
+
+func (c *layeredCache) Get(ctx context.Context, key string) (*model.Product, error) {
+	if product, _ := c.local.Get(ctx, key); product != nil {
+		return product, nil
+	}
+
+	remote := c.available()
+	if remote == nil {
+		return nil, nil
+	}
+
+	product, err := remote.Get(ctx, key)
+	if err != nil {
+		c.markDown(err)
+		return nil, nil
+	}
+	if product != nil {
+		c.local.Set(ctx, key, product)
+	}
+	return product, nil
+}
+
+func (c *layeredCache) Set(ctx context.Context, key string, product *model.Product) error {
+	c.local.Set(ctx, key, product)
+
+	remote := c.available()
+	if remote == nil {
+		c.rememberDelete(key)
+		return nil
+	}
+	if err := remote.Set(ctx, key, product); err != nil {
+		c.markDown(err)
+		c.rememberDelete(key)
+	}
+	return nil
+}
+
+func (c *layeredCache) Delete(ctx context.Context, key string) error {
+	c.local.Delete(ctx, key)
+
+	remote := c.available()
+	if remote == nil {
+		c.rememberDelete(key)
+		return nil
+	}
+	if err := remote.Delete(ctx, key); err != nil {
+		c.markDown(err)
+		c.rememberDelete(key)
+	}
+	return nil
+}
+
+func (c *layeredCache) GetQuery(ctx context.Context, key string) ([]*model.Product, error) {
+	if products, _ := c.local.GetQuery(ctx, key); products != nil {
+		return products, nil
+	}
+
+	remote := c.available()
+	if remote == nil {
+		return nil, nil
+	}
+
+	query, err := remote.getQuery(ctx, key)
+	if err != nil {
+		c.markDown(err)
+		return nil, nil
+	}
+	if query == nil {
+		return nil, nil
+	}
+
+	c.local.SetQuery(ctx, key, query.Products, query.Tags)
+	return query.Products, nil
+}
+
+func (c *layeredCache) SetQuery(ctx context.Context, key string, products []*model.Product, tags []string) error {
+	c.local.SetQuery(ctx, key, products, tags)
+
+	remote := c.available()
+	if remote == nil {
+		return nil
+	}
+	if err := remote.SetQuery(ctx, key, products, tags); err != nil {
+		c.markDown(err)
+	}
+	return nil
+}
+
+func (c *layeredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.local.InvalidateTags(ctx, tags...)
+
+	remote := c.available()
+	if remote == nil {
+		c.markQueriesDirty()
+		return nil
+	}
+	if err := remote.InvalidateTags(ctx, tags...); err != nil {
+		c.markDown(err)
+		c.markQueriesDirty()
+	}
+	return nil
+}
+
+func (c *layeredCache) Stats() []LayerStats {
+	stats := c.local.Stats()
+
+	c.mu.RLock()
+	remote := c.remote
+	reconnecting := c.reconnecting
+	c.mu.RUnlock()
+
+	if remote == nil {
+		return append(stats, LayerStats{Layer: "redis"})
+	}
+
+	remoteStats := remote.Stats()
+	for i := range remoteStats {
+		remoteStats[i].Available = !reconnecting
+	}
+	return append(stats, remoteStats...)
+}
+
+func (c *layeredCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	close(c.stop)
+	c.local.Close()
+	if c.remote != nil {
+		return c.remote.Close()
+	}
+	return nil
+}
+
+// available возвращает Redis, если он сейчас доступен
+func (c *layeredCache) available() *redisCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.reconnecting {
+		return nil
+	}
+	return c.remote
+}
+
+func (c *layeredCache) markDown(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.reconnecting {
+		return
+	}
+	log.Printf("Warning: Redis is unavailable, falling back to in-memory cache: %v", err)
+	c.startReconnectLocked()
+}
+
+func (c *layeredCache) rememberDelete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pendingDeletes[key] = struct{}{}
+}
+
+func (c *layeredCache) markQueriesDirty() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queriesDirty = true
+}
+
+func (c *layeredCache) startReconnectLocked() {
+	c.reconnecting = true
+	go c.reconnectLoop()
+}
+
+func (c *layeredCache) reconnectLoop() {
+	ticker := time.NewTicker(redisReconnectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if c.tryReconnect() {
+				log.Println("Redis connection restored")
+				return
+			}
+		}
+	}
+}
+
+func (c *layeredCache) tryReconnect() bool {
+	ctx := context.Background()
+
+	c.mu.RLock()
+	remote := c.remote
+	c.mu.RUnlock()
+
+	if remote == nil {
+		var err error
+		if remote, err = newRedisCache(c.redisAddr); err != nil {
+			return false
+		}
+	} else if err := remote.ping(ctx); err != nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remote = remote
+
+	// Пока Redis был недоступен, инвалидации до него не доходили
+	for key := range c.pendingDeletes {
+		if err := remote.Delete(ctx, key); err != nil {
+			return false
+		}
+		delete(c.pendingDeletes, key)
+	}
+	if c.queriesDirty {
+		if err := remote.purgeQueries(ctx); err != nil {
+			return false
+		}
+		c.queriesDirty = false
+	}
+
+	c.reconnecting = false
+	return true
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"shoeshop/product-service/internal/model"
+)
+
+// LayerStats - статистика одного уровня кэша
+type LayerStats struct {
+	Layer     string
+	Hits      uint64
+	Misses    uint64
+	Errors    uint64
+	Entries   int
+	Available bool
+}
+
+type lruEntry struct {
+	key      string
+	product  *model.Product
+	products []*model.Product
+	tags     []string
+	expires  time.Time
+}
+
+// lruCache - ограниченный по размеру кэш в памяти процесса с TTL
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+	tags     map[string]map[string]struct{}
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func newLRUCache(capacity int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *lruCache) Get(ctx context.Context, key string) (*model.Product, error) {
+	entry := c.lookup("product:" + key)
+	if entry == nil || entry.product == nil {
+		c.misses.Add(1)
+		return nil, nil
+	}
+
+	c.hits.Add(1)
+	product := *entry.product
+	return &product, nil
+}
+
+func (c *lruCache) Set(ctx context.Context, key string, product *model.Product) error {
+	copied := *product
+	c.store(&lruEntry{key: "product:" + key, product: &copied})
+	return nil
+}
+
+func (c *lruCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items["product:"+key]; ok {
+		c.removeElement(el)
+	}
+	return nil
+}
+
+func (c *lruCache) GetQuery(ctx context.Context, key string) ([]*model.Product, error) {
+	entry := c.lookup("query:" + key)
+	if entry == nil || entry.products == nil {
+		c.misses.Add(1)
+		return nil, nil
+	}
+
+	c.hits.Add(1)
+	return append([]*model.Product(nil), entry.products...), nil
+}
+
+func (c *lruCache) SetQuery(ctx context.Context, key string, products []*model.Product, tags []string) error {
+	if products == nil {
+		products = []*model.Product{}
+	}
+	c.store(&lruEntry{
+		key:      "query:" + key,
+		products: append([]*model.Product(nil), products...),
+		tags:     tags,
+	})
+	return nil
+}
+
+func (c *lruCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if el, ok := c.items[key]; ok {
+				c.removeElement(el)
+			}
+		}
+		delete(c.tags, tag)
+	}
+	return nil
+}
+
+func (c *lruCache) Stats() []LayerStats {
+	c.mu.Lock()
+	entries := c.ll.Len()
+	c.mu.Unlock()
+
+	return []LayerStats{{
+		Layer:     "memory",
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Entries:   entries,
+		Available: true,
+	}}
+}
+
+func (c *lruCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+	c.tags = make(map[string]map[string]struct{})
+	return nil
+}
+
+func (c *lruCache) lookup(key string) *lruEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.removeElement(el)
+		return nil
+	}
+
+	c.ll.MoveToFront(el)
+	return entry
+}
+
+func (c *lruCache) store(entry *lruEntry) {
+	entry.expires = time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[entry.key]; ok {
+		c.removeElement(el)
+	}
+
+	c.items[entry.key] = c.ll.PushFront(entry)
+	for _, tag := range entry.tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[entry.key] = struct{}{}
+	}
+
+	// Вытесняем самые старые записи
+	for c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *lruCache) removeElement(el *list.Element) {
+	entry := el.Value.(*lruEntry)
+	c.ll.Remove(el)
+	delete(c.items, entry.key)
+	for _, tag := range entry.tags {
+		if keys, ok := c.tags[tag]; ok {
+			delete(keys, entry.key)
+			if len(keys) == 0 {
+				delete(c.tags, tag)
+			}
+		}
+	}
+}
@@ -2,27 +2,55 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"shoeshop/ids"
 	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *model.Product) (*model.Product, error)
 	GetByID(ctx context.Context, id string) (*model.Product, error)
-	Update(ctx context.Context, product *model.Product) (*model.Product, error)
-	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, product *model.Product, fields []string) (*model.Product, error)
+	Archive(ctx context.Context, id string) (*model.Product, error)
+	Restore(ctx context.Context, id string) (*model.Product, error)
+	ListArchived(ctx context.Context, before time.Time) ([]*model.Product, error)
+	Purge(ctx context.Context, id string, before time.Time) (bool, error)
 	List(ctx context.Context, filter bson.M) ([]*model.Product, error)
 	SearchProducts(ctx context.Context, query string) ([]*model.Product, error)
+	IncrementOrderCount(ctx context.Context, productID string, quantity int64) error
+	GetOrderCounts(ctx context.Context) (map[string]int64, error)
+	DecrementStock(ctx context.Context, productID string, quantity int32) (*model.Product, int32, error)
+	RecordSale(ctx context.Context, productID string, quantity int64, at time.Time) error
+	SalesSince(ctx context.Context, since time.Time) (map[string]int64, error)
+	FindBySKUs(ctx context.Context, skus []string) (map[string]*model.Product, error)
+	UpsertBySKU(ctx context.Context, products []*model.Product) (map[int]error, error)
+	Stream(ctx context.Context, filter bson.M, fn func(product *model.Product) error) error
+	AddImage(ctx context.Context, productID string, image model.Image) (*model.Product, error)
+	UpdateImage(ctx context.Context, productID, imageID, alt string, sortOrder int32) (*model.Product, error)
+	RemoveImage(ctx context.Context, productID, imageID string) (*model.Product, error)
+	SetRating(ctx context.Context, productID string, rating float64, count int32) (*model.Product, error)
+	SetPrice(ctx context.Context, productID string, price float64) (float64, *model.Product, error)
+	CountByCategory(ctx context.Context, category string) (int64, error)
+	CountByBrand(ctx context.Context, brandID string) (int64, error)
+	SetBrand(ctx context.Context, brand *model.Brand) (int64, error)
 }
 
 type mongoRepository struct {
-	client     *mongo.Client
-	collection *mongo.Collection
+	client          *mongo.Client
+	collection      *mongo.Collection
+	statsCollection *mongo.Collection
+	salesCollection *mongo.Collection
 }
 
 func NewMongoRepository(uri string) (ProductRepository, error) {
@@ -31,8 +59,12 @@ func NewMongoRepository(uri string) (ProductRepository, error) {
 		return nil, err
 	}
 
-	collection := client.Database("shoeshop").Collection("products")
-	
+	db := client.Database("shoeshop")
+	collection := db.Collection("products")
+	statsCollection := db.Collection("product_stats")
+	// Продажи товаров по дням для отчета о дозаказе
+	salesCollection := db.Collection("product_sales")
+
 	// Создаем индексы
 	indexes := []mongo.IndexModel{
 		{
@@ -44,6 +76,21 @@ func NewMongoRepository(uri string) (ProductRepository, error) {
 		{
 			Keys: bson.D{{Key: "brand", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "brand_id", Value: 1}},
+		},
+		{
+			// Товары без артикула в уникальность не входят
+			Keys: bson.D{{Key: "sku", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"sku": bson.M{"$type": "string", "$gt": ""}}),
+		},
+		{
+			// В индекс попадают только архивные товары
+			Keys:    bson.D{{Key: "deleted_at", Value: -1}},
+			Options: options.Index().SetSparse(true),
+		},
 	}
 
 	_, err = collection.Indexes().CreateMany(context.Background(), indexes)
@@ -51,23 +98,173 @@ func NewMongoRepository(uri string) (ProductRepository, error) {
 		return nil, err
 	}
 
-	return &mongoRepository{
-		client:     client,
-		collection: collection,
-	}, nil
+	_, err = salesCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "day", Value: 1}, {Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &mongoRepository{
+		client:          client,
+		collection:      collection,
+		statsCollection: statsCollection,
+		salesCollection: salesCollection,
+	}
+	// Товары, которым ID выдал Mongo, получают строковый ID
+	if err := ids.MigrateObjectIDs(context.Background(), collection); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateLegacySizes(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateLegacyDiscount(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateLegacyShipping(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateVersions(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Раньше размеры хранились номерами EU. Длина стопы в миллиметрах всегда
+// больше этого порога, поэтому меньшие значения - старые номера
+const legacySizeLimit = 100
+
+// migrateLegacySizes переводит старые номера EU в длину стопы по таблице бренда
+func (r *mongoRepository) migrateLegacySizes(ctx context.Context) error {
+	cursor, err := r.collection.Find(ctx, bson.M{"sizes": bson.M{"$lt": legacySizeLimit}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var product model.Product
+		if err := cursor.Decode(&product); err != nil {
+			return err
+		}
+
+		table := sizing.TableFor(product.Brand)
+		sizes := make([]sizing.Size, 0, len(product.Sizes))
+		for _, size := range product.Sizes {
+			if size < legacySizeLimit {
+				converted, err := table.Parse(strconv.Itoa(int(size)), sizing.EU)
+				if err != nil {
+					return fmt.Errorf("failed to migrate size %d of product %s: %w", size, product.ID, err)
+				}
+				size = converted
+			}
+			sizes = append(sizes, size)
+		}
+
+		_, err := r.collection.UpdateOne(ctx, bson.M{"_id": product.ID}, bson.M{"$set": bson.M{"sizes": sizes}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// migrateLegacyDiscount переводит скидку из процентов в долю цены: раньше
+// она хранилась целым числом процентов, а доля всегда меньше единицы
+func (r *mongoRepository) migrateLegacyDiscount(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"discount": bson.M{"$gte": 1}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"discount": bson.M{"$divide": bson.A{"$discount", 100}},
+		}}}},
+	)
+	return err
+}
+
+// legacyShipping - прежний вид shipping_info: размеры в сантиметрах, вес в
+// килограммах и способы доставки с ценой и сроком
+type legacyShipping struct {
+	Dimensions struct {
+		Length float64 `bson:"length"`
+		Width  float64 `bson:"width"`
+		Height float64 `bson:"height"`
+	} `bson:"dimensions"`
+	Weight          float64 `bson:"weight"`
+	ShippingMethods []struct {
+		Method string `bson:"method"`
+	} `bson:"shipping_methods"`
+	FreeShipping  bool `bson:"free_shipping"`
+	International bool `bson:"international"`
+}
+
+// migrateLegacyShipping переводит shipping_info прежнего вида в миллиметры,
+// граммы и коды способов доставки ("Next Day" - NEXT_DAY)
+func (r *mongoRepository) migrateLegacyShipping(ctx context.Context) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"shipping_info.dimensions": bson.M{"$exists": true}},
+		bson.M{"shipping_info.weight": bson.M{"$exists": true}},
+		bson.M{"shipping_info.shipping_methods": bson.M{"$exists": true}},
+	}}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var product struct {
+			ID       string         `bson:"_id"`
+			Shipping legacyShipping `bson:"shipping_info"`
+		}
+		if err := cursor.Decode(&product); err != nil {
+			return err
+		}
+
+		legacy := product.Shipping
+		shipping := model.ShippingInfo{
+			WeightGrams:   int32(math.Round(legacy.Weight * 1000)),
+			LengthMM:      int32(math.Round(legacy.Dimensions.Length * 10)),
+			WidthMM:       int32(math.Round(legacy.Dimensions.Width * 10)),
+			HeightMM:      int32(math.Round(legacy.Dimensions.Height * 10)),
+			FreeShipping:  legacy.FreeShipping,
+			International: legacy.International,
+		}
+		for _, method := range legacy.ShippingMethods {
+			shipping.Methods = append(shipping.Methods, strings.ReplaceAll(strings.TrimSpace(method.Method), " ", "_"))
+		}
+		if err := shipping.Validate(); err != nil {
+			return fmt.Errorf("failed to migrate shipping info of product %s: %w", product.ID, err)
+		}
+
+		_, err := r.collection.UpdateOne(ctx, bson.M{"_id": product.ID}, bson.M{"$set": bson.M{"shipping_info": shipping}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// migrateVersions выдает версию 1 товарам, созданным до появления версий
+func (r *mongoRepository) migrateVersions(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": 1}},
+	)
+	return err
 }
 
 func (r *mongoRepository) Create(ctx context.Context, product *model.Product) (*model.Product, error) {
 	now := time.Now()
 	product.CreatedAt = now
 	product.UpdatedAt = now
+	product.Version = 1
+	product.ID = ids.New()
 
-	result, err := r.collection.InsertOne(ctx, product)
-	if err != nil {
+	if _, err := r.collection.InsertOne(ctx, product); err != nil {
 		return nil, err
 	}
-
-	product.ID = result.InsertedID.(string)
 	return product, nil
 }
 
@@ -80,31 +277,161 @@ func (r *mongoRepository) GetByID(ctx context.Context, id string) (*model.Produc
 	return &product, nil
 }
 
-func (r *mongoRepository) Update(ctx context.Context, product *model.Product) (*model.Product, error) {
+// Поля, которые меняются только своими методами и не перезаписываются в Update
+var managedFields = []string{"_id", "images", "rating", "review_count", "version", "deleted_at"}
+
+// Update записывает поля fields, а при пустом списке - все поля, кроме managedFields.
+// Запись применяется, только если товар все еще в версии product.Version
+func (r *mongoRepository) Update(ctx context.Context, product *model.Product, fields []string) (*model.Product, error) {
 	product.UpdatedAt = time.Now()
 
+	data, err := bson.Marshal(product)
+	if err != nil {
+		return nil, err
+	}
+	var set bson.M
+	if err := bson.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	for _, field := range managedFields {
+		delete(set, field)
+	}
+	if len(fields) > 0 {
+		set = selectFields(set, append(fields, "updated_at"))
+	}
+
 	result := r.collection.FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": product.ID},
-		bson.M{"$set": product},
+		bson.M{"_id": product.ID, "version": product.Version},
+		bson.M{"$set": set, "$inc": bson.M{"version": 1}},
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
 
 	var updatedProduct model.Product
 	if err := result.Decode(&updatedProduct); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.versionError(ctx, product.ID)
+		}
 		return nil, err
 	}
 
 	return &updatedProduct, nil
 }
 
-func (r *mongoRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
-	return err
+// selectFields оставляет в set только поля fields
+func selectFields(set bson.M, fields []string) bson.M {
+	selected := make(bson.M, len(fields))
+	for _, field := range fields {
+		if value, ok := set[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
 }
 
+// versionError отличает устаревшую версию от удаленного товара
+func (r *mongoRepository) versionError(ctx context.Context, id string) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return model.ErrVersionConflict
+}
+
+// activeFilter добавляет к фильтру условие, отсекающее архивные товары
+func activeFilter(filter bson.M) bson.M {
+	active := bson.M{"deleted_at": bson.M{"$exists": false}}
+	for key, value := range filter {
+		active[key] = value
+	}
+	return active
+}
+
+// Archive помечает товар удаленным. Повторное удаление возвращает model.ErrArchived
+func (r *mongoRepository) Archive(ctx context.Context, id string) (*model.Product, error) {
+	now := time.Now()
+	product, err := r.updateOne(ctx,
+		activeFilter(bson.M{"_id": id}),
+		bson.M{
+			"$set": bson.M{"deleted_at": now, "updated_at": now},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.stateError(ctx, id, model.ErrArchived)
+	}
+	return product, err
+}
+
+// Restore возвращает архивный товар в каталог
+func (r *mongoRepository) Restore(ctx context.Context, id string) (*model.Product, error) {
+	product, err := r.updateOne(ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}},
+		bson.M{
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$unset": bson.M{"deleted_at": ""},
+			"$inc":   bson.M{"version": 1},
+		},
+	)
+	if err == mongo.ErrNoDocuments {
+		return nil, r.stateError(ctx, id, model.ErrNotArchived)
+	}
+	return product, err
+}
+
+// stateError отличает товар в неподходящем состоянии от отсутствующего
+func (r *mongoRepository) stateError(ctx context.Context, id string, stateErr error) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return stateErr
+}
+
+// ListArchived возвращает архивные товары, удаленные раньше before, начиная
+// с последних. Нулевой before - все архивные товары
+func (r *mongoRepository) ListArchived(ctx context.Context, before time.Time) ([]*model.Product, error) {
+	deletedAt := bson.M{"$exists": true}
+	if !before.IsZero() {
+		deletedAt["$lt"] = before
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"deleted_at": deletedAt}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []*model.Product
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// Purge окончательно удаляет товар, если он все еще в архиве с даты раньше
+// before; восстановленный за это время товар остается
+func (r *mongoRepository) Purge(ctx context.Context, id string, before time.Time) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// List возвращает только товары каталога, без архивных
 func (r *mongoRepository) List(ctx context.Context, filter bson.M) ([]*model.Product, error) {
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := r.collection.Find(ctx, activeFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -117,12 +444,40 @@ func (r *mongoRepository) List(ctx context.Context, filter bson.M) ([]*model.Pro
 	return products, nil
 }
 
+func (r *mongoRepository) CountByCategory(ctx context.Context, category string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"category": category})
+}
+
+func (r *mongoRepository) CountByBrand(ctx context.Context, brandID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"brand_id": brandID})
+}
+
+// SetBrand копирует название и таблицу размеров бренда во все его товары
+func (r *mongoRepository) SetBrand(ctx context.Context, brand *model.Brand) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"brand_id": brand.ID},
+		bson.M{
+			"$set": bson.M{
+				"brand":       brand.Name,
+				"size_offset": brand.SizeOffset,
+				"updated_at":  time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
 func (r *mongoRepository) SearchProducts(ctx context.Context, query string) ([]*model.Product, error) {
-	filter := bson.M{
+	filter := activeFilter(bson.M{
 		"$text": bson.M{
 			"$search": query,
 		},
-	}
+	})
 
 	opts := options.Find().SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
 
@@ -137,4 +492,298 @@ func (r *mongoRepository) SearchProducts(ctx context.Context, query string) ([]*
 		return nil, err
 	}
 	return products, nil
-} 
\ No newline at end of file
+}
+
+func (r *mongoRepository) IncrementOrderCount(ctx context.Context, productID string, quantity int64) error {
+	_, err := r.statsCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": productID},
+		bson.M{"$inc": bson.M{"order_count": quantity}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *mongoRepository) GetOrderCounts(ctx context.Context) (map[string]int64, error) {
+	cursor, err := r.statsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []struct {
+		ProductID  string `bson:"_id"`
+		OrderCount int64  `bson:"order_count"`
+	}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(stats))
+	for _, stat := range stats {
+		counts[stat.ProductID] = stat.OrderCount
+	}
+	return counts, nil
+}
+
+// DecrementStock списывает проданное количество и возвращает товар после
+// списания. Остаток не уходит в минус: если параллельные заказы продали
+// больше, чем было, остаток обнуляется, а oversold - сколько не хватило
+func (r *mongoRepository) DecrementStock(ctx context.Context, productID string, quantity int32) (product *model.Product, oversold int32, err error) {
+	now := time.Now()
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"stock":      bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$stock", quantity}}}},
+		"version":    bson.M{"$add": bson.A{"$version", 1}},
+		"updated_at": now,
+	}}}}
+
+	// Прежний остаток нужен, чтобы узнать, сколько продано сверх него
+	var before model.Product
+	err = r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": productID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&before)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	after := before
+	after.Stock = max(before.Stock-quantity, 0)
+	after.Version++
+	after.UpdatedAt = now
+	return &after, max(quantity-before.Stock, 0), nil
+}
+
+// RecordSale добавляет проданное количество к продажам товара за день at
+func (r *mongoRepository) RecordSale(ctx context.Context, productID string, quantity int64, at time.Time) error {
+	day := at.UTC().Truncate(24 * time.Hour)
+	_, err := r.salesCollection.UpdateOne(
+		ctx,
+		bson.M{"product_id": productID, "day": day},
+		bson.M{"$inc": bson.M{"quantity": quantity}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// SalesSince возвращает число проданных единиц каждого товара с начала дня since
+func (r *mongoRepository) SalesSince(ctx context.Context, since time.Time) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": bson.M{"$gte": since.UTC().Truncate(24 * time.Hour)}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$product_id", "quantity": bson.M{"$sum": "$quantity"}}}},
+	}
+	cursor, err := r.salesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sales []struct {
+		ProductID string `bson:"_id"`
+		Quantity  int64  `bson:"quantity"`
+	}
+	if err := cursor.All(ctx, &sales); err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]int64, len(sales))
+	for _, sale := range sales {
+		quantities[sale.ProductID] = sale.Quantity
+	}
+	return quantities, nil
+}
+
+func (r *mongoRepository) FindBySKUs(ctx context.Context, skus []string) (map[string]*model.Product, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"sku": bson.M{"$in": skus}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []*model.Product
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	bySKU := make(map[string]*model.Product, len(products))
+	for _, product := range products {
+		bySKU[product.SKU] = product
+	}
+	return bySKU, nil
+}
+
+// UpsertBySKU записывает пачку товаров одним bulk-запросом. Ошибки отдельных
+// товаров возвращаются по их индексу в пачке, остальные товары при этом записываются
+func (r *mongoRepository) UpsertBySKU(ctx context.Context, products []*model.Product) (map[int]error, error) {
+	if len(products) == 0 {
+		return nil, nil
+	}
+
+	models := make([]mongo.WriteModel, len(products))
+	for i, product := range products {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"sku": product.SKU}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"name":          product.Name,
+					"description":   product.Description,
+					"price":         product.Price,
+					"category":      product.Category,
+					"brand_id":      product.BrandID,
+					"brand":         product.Brand,
+					"size_offset":   product.SizeOffset,
+					"sizes":         product.Sizes,
+					"colors":        product.Colors,
+					"images":        product.Images,
+					"stock":         product.Stock,
+					"discount":      product.Discount,
+					"material":      product.Material,
+					"features":      product.Features,
+					"release_date":  product.ReleaseDate,
+					"shipping_info": product.Shipping,
+					"updated_at":    product.UpdatedAt,
+				},
+				// Рейтинг из файла - начальный, дальше его пересчитывает review-service
+				"$setOnInsert": bson.M{
+					"_id":        product.ID,
+					"created_at": product.CreatedAt,
+					"rating":     product.Rating,
+				},
+				// Импорт товара с артикулом архивного возвращает его в каталог
+				"$unset": bson.M{"deleted_at": ""},
+				// Новый товар получает версию 1
+				"$inc": bson.M{"version": 1},
+			}).
+			SetUpsert(true)
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		failed := make(map[int]error, len(bulkErr.WriteErrors))
+		for _, writeErr := range bulkErr.WriteErrors {
+			failed[writeErr.Index] = writeErr
+		}
+		return failed, nil
+	}
+	return nil, err
+}
+
+// Stream обходит товары курсором, не загружая всю выборку в память
+func (r *mongoRepository) Stream(ctx context.Context, filter bson.M, fn func(product *model.Product) error) error {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var product model.Product
+		if err := cursor.Decode(&product); err != nil {
+			return err
+		}
+		if err := fn(&product); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// AddImage добавляет изображение, если у товара еще нет изображения с таким ID
+func (r *mongoRepository) AddImage(ctx context.Context, productID string, image model.Image) (*model.Product, error) {
+	product, err := r.updateOne(ctx,
+		bson.M{"_id": productID, "images.id": bson.M{"$ne": image.ID}},
+		bson.M{
+			"$push": bson.M{"images": image},
+			"$set":  bson.M{"updated_at": time.Now()},
+			"$inc":  bson.M{"version": 1},
+		},
+	)
+	if err == mongo.ErrNoDocuments {
+		// Либо товара нет, либо это изображение уже загружено
+		return r.GetByID(ctx, productID)
+	}
+	return product, err
+}
+
+func (r *mongoRepository) UpdateImage(ctx context.Context, productID, imageID, alt string, sortOrder int32) (*model.Product, error) {
+	return r.updateOne(ctx,
+		bson.M{"_id": productID, "images.id": imageID},
+		bson.M{
+			"$set": bson.M{
+				"images.$.alt":        alt,
+				"images.$.sort_order": sortOrder,
+				"updated_at":          time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+}
+
+func (r *mongoRepository) RemoveImage(ctx context.Context, productID, imageID string) (*model.Product, error) {
+	return r.updateOne(ctx,
+		bson.M{"_id": productID, "images.id": imageID},
+		bson.M{
+			"$pull": bson.M{"images": bson.M{"id": imageID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+			"$inc":  bson.M{"version": 1},
+		},
+	)
+}
+
+// SetRating сохраняет агрегат одобренных отзывов о товаре
+func (r *mongoRepository) SetRating(ctx context.Context, productID string, rating float64, count int32) (*model.Product, error) {
+	return r.updateOne(ctx,
+		bson.M{"_id": productID},
+		bson.M{
+			"$set": bson.M{
+				"rating":       rating,
+				"review_count": count,
+				"updated_at":   time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+}
+
+// SetPrice меняет только цену и возвращает прежнюю цену вместе с обновленным товаром
+func (r *mongoRepository) SetPrice(ctx context.Context, productID string, price float64) (float64, *model.Product, error) {
+	now := time.Now()
+
+	var product model.Product
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": productID},
+		bson.M{
+			"$set": bson.M{"price": price, "updated_at": now},
+			"$inc": bson.M{"version": 1},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&product)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	previous := product.Price
+	product.Price = price
+	product.UpdatedAt = now
+	product.Version++
+	return previous, &product, nil
+}
+
+func (r *mongoRepository) updateOne(ctx context.Context, filter, update bson.M) (*model.Product, error) {
+	var product model.Product
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"encoding/json"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	"shoeshop/product-service/internal/model"
@@ -11,14 +14,40 @@ type EventPublisher interface {
 	PublishProductCreated(product *model.Product) error
 	PublishProductUpdated(product *model.Product) error
 	PublishProductDeleted(productID string) error
+	PublishLowStock(event *model.LowStockEvent) error
+	// PublishBackInStock передает событие email-service и ждет, пока тот
+	// поставит письмо в очередь; ошибка значит, что письмо не принято
+	PublishBackInStock(event *model.BackInStockEvent) error
 	Close() error
 }
 
+type EventSubscriber interface {
+	SubscribeProductCreated(handler func(product *model.Product)) error
+	SubscribeProductUpdated(handler func(product *model.Product)) error
+	SubscribeProductDeleted(handler func(productID string)) error
+	SubscribeOrderCreated(handler func(event *model.OrderCreatedEvent)) error
+	// QueueSubscribeOrderCreated доставляет каждое событие только одному
+	// экземпляру сервиса из группы queue
+	QueueSubscribeOrderCreated(queue string, handler func(event *model.OrderCreatedEvent)) error
+	SubscribeReviewRatingChanged(handler func(event *model.ReviewRatingEvent)) error
+	// QueueSubscribeBackInStockSent сообщает ID подписок, письмо по которым
+	// email-service отправил
+	QueueSubscribeBackInStockSent(queue string, handler func(subscriptionID string)) error
+}
+
+// Сколько ждать, пока email-service примет письмо о поступлении
+const backInStockTimeout = 5 * time.Second
+
+type EventBus interface {
+	EventPublisher
+	EventSubscriber
+}
+
 type natsClient struct {
 	conn *nats.Conn
 }
 
-func NewNatsClient(url string) (EventPublisher, error) {
+func NewNatsClient(url string) (EventBus, error) {
 	nc, err := nats.Connect(url)
 	if err != nil {
 		return nil, err
@@ -49,7 +78,98 @@ func (n *natsClient) PublishProductDeleted(productID string) error {
 	return n.conn.Publish("product.deleted", []byte(productID))
 }
 
+func (n *natsClient) PublishLowStock(event *model.LowStockEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.conn.Publish("product.low_stock", data)
+}
+
+func (n *natsClient) PublishBackInStock(event *model.BackInStockEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	// Пустой ответ - письмо в очереди, иначе в ответе текст ошибки
+	reply, err := n.conn.Request("product.back_in_stock", data, backInStockTimeout)
+	if err != nil {
+		return err
+	}
+	if len(reply.Data) > 0 {
+		return fmt.Errorf("email-service rejected back in stock event: %s", reply.Data)
+	}
+	return nil
+}
+
+func (n *natsClient) QueueSubscribeBackInStockSent(queue string, handler func(subscriptionID string)) error {
+	_, err := n.conn.QueueSubscribe("product.back_in_stock.sent", queue, func(msg *nats.Msg) {
+		handler(string(msg.Data))
+	})
+	return err
+}
+
+func (n *natsClient) SubscribeProductCreated(handler func(product *model.Product)) error {
+	return n.subscribeProduct("product.created", handler)
+}
+
+func (n *natsClient) SubscribeProductUpdated(handler func(product *model.Product)) error {
+	return n.subscribeProduct("product.updated", handler)
+}
+
+func (n *natsClient) SubscribeProductDeleted(handler func(productID string)) error {
+	_, err := n.conn.Subscribe("product.deleted", func(msg *nats.Msg) {
+		handler(string(msg.Data))
+	})
+	return err
+}
+
+func (n *natsClient) SubscribeOrderCreated(handler func(event *model.OrderCreatedEvent)) error {
+	_, err := n.conn.Subscribe("order.created", orderCreatedHandler(handler))
+	return err
+}
+
+func (n *natsClient) QueueSubscribeOrderCreated(queue string, handler func(event *model.OrderCreatedEvent)) error {
+	_, err := n.conn.QueueSubscribe("order.created", queue, orderCreatedHandler(handler))
+	return err
+}
+
+func orderCreatedHandler(handler func(event *model.OrderCreatedEvent)) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var event model.OrderCreatedEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode order.created event: %v", err)
+			return
+		}
+		handler(&event)
+	}
+}
+
+func (n *natsClient) SubscribeReviewRatingChanged(handler func(event *model.ReviewRatingEvent)) error {
+	_, err := n.conn.Subscribe("review.rating_changed", func(msg *nats.Msg) {
+		var event model.ReviewRatingEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode review.rating_changed event: %v", err)
+			return
+		}
+		handler(&event)
+	})
+	return err
+}
+
+func (n *natsClient) subscribeProduct(subject string, handler func(product *model.Product)) error {
+	_, err := n.conn.Subscribe(subject, func(msg *nats.Msg) {
+		var product model.Product
+		if err := json.Unmarshal(msg.Data, &product); err != nil {
+			log.Printf("failed to decode %s event: %v", subject, err)
+			return
+		}
+		handler(&product)
+	})
+	return err
+}
+
 func (n *natsClient) Close() error {
 	n.conn.Close()
 	return nil
-} 
\ No newline at end of file
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/product-service/internal/model"
+)
+
+type PriceRepository interface {
+	Record(ctx context.Context, change *model.PriceChange) error
+	History(ctx context.Context, productID string, limit int64) ([]*model.PriceChange, error)
+	LowestSince(ctx context.Context, productID string, since time.Time) (float64, bool, error)
+	Schedule(ctx context.Context, scheduled *model.ScheduledPrice) error
+	GetScheduled(ctx context.Context, id string) (*model.ScheduledPrice, error)
+	ListScheduled(ctx context.Context, productID string, status model.ScheduleStatus) ([]*model.ScheduledPrice, error)
+	CancelScheduled(ctx context.Context, id, productID string) (*model.ScheduledPrice, error)
+	CancelPending(ctx context.Context, productID string) error
+	ClaimDue(ctx context.Context, now time.Time) (*model.ScheduledPrice, error)
+	Unclaim(ctx context.Context, id string) error
+}
+
+type mongoPriceRepository struct {
+	client    *mongo.Client
+	history   *mongo.Collection
+	scheduled *mongo.Collection
+}
+
+func NewPriceRepository(uri string) (PriceRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	history := db.Collection("price_history")
+	scheduled := db.Collection("scheduled_prices")
+
+	_, err = history.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "changed_at", Value: -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scheduled.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "effective_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "effective_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoPriceRepository{
+		client:    client,
+		history:   history,
+		scheduled: scheduled,
+	}, nil
+}
+
+func (r *mongoPriceRepository) Record(ctx context.Context, change *model.PriceChange) error {
+	_, err := r.history.InsertOne(ctx, change)
+	return err
+}
+
+// History возвращает изменения цены товара, новые первыми
+func (r *mongoPriceRepository) History(ctx context.Context, productID string, limit int64) ([]*model.PriceChange, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "changed_at", Value: -1}}).
+		SetLimit(limit)
+	return r.findChanges(ctx, bson.M{"product_id": productID}, opts)
+}
+
+// LowestSince возвращает минимальную цену, действовавшую с момента since:
+// цену на начало окна и все цены, установленные внутри него. false - истории нет
+func (r *mongoPriceRepository) LowestSince(ctx context.Context, productID string, since time.Time) (float64, bool, error) {
+	inWindow, err := r.findChanges(ctx,
+		bson.M{"product_id": productID, "changed_at": bson.M{"$gt": since}},
+		options.Find(),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var atStart model.PriceChange
+	err = r.history.FindOne(ctx,
+		bson.M{"product_id": productID, "changed_at": bson.M{"$lte": since}},
+		options.FindOne().SetSort(bson.D{{Key: "changed_at", Value: -1}}),
+	).Decode(&atStart)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, false, err
+	}
+
+	lowest, found := math.Inf(1), false
+	if err == nil {
+		lowest, found = atStart.Price, true
+	}
+	for _, change := range inWindow {
+		lowest, found = math.Min(lowest, change.Price), true
+	}
+	if !found {
+		return 0, false, nil
+	}
+	return lowest, true, nil
+}
+
+func (r *mongoPriceRepository) Schedule(ctx context.Context, scheduled *model.ScheduledPrice) error {
+	_, err := r.scheduled.InsertOne(ctx, scheduled)
+	return err
+}
+
+func (r *mongoPriceRepository) GetScheduled(ctx context.Context, id string) (*model.ScheduledPrice, error) {
+	var scheduled model.ScheduledPrice
+	if err := r.scheduled.FindOne(ctx, bson.M{"_id": id}).Decode(&scheduled); err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+func (r *mongoPriceRepository) ListScheduled(ctx context.Context, productID string, status model.ScheduleStatus) ([]*model.ScheduledPrice, error) {
+	filter := bson.M{}
+	if productID != "" {
+		filter["product_id"] = productID
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "effective_at", Value: 1}})
+
+	cursor, err := r.scheduled.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	scheduled := []*model.ScheduledPrice{}
+	if err := cursor.All(ctx, &scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// CancelScheduled отменяет только еще не примененное изменение
+func (r *mongoPriceRepository) CancelScheduled(ctx context.Context, id, productID string) (*model.ScheduledPrice, error) {
+	var scheduled model.ScheduledPrice
+	err := r.scheduled.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id, "product_id": productID, "status": model.ScheduleStatusPending},
+		bson.M{"$set": bson.M{"status": model.ScheduleStatusCanceled}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&scheduled)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+func (r *mongoPriceRepository) CancelPending(ctx context.Context, productID string) error {
+	_, err := r.scheduled.UpdateMany(
+		ctx,
+		bson.M{"product_id": productID, "status": model.ScheduleStatusPending},
+		bson.M{"$set": bson.M{"status": model.ScheduleStatusCanceled}},
+	)
+	return err
+}
+
+// ClaimDue атомарно помечает самое раннее наступившее изменение примененным,
+// поэтому несколько экземпляров сервиса не применят его дважды. nil - ждать нечего
+func (r *mongoPriceRepository) ClaimDue(ctx context.Context, now time.Time) (*model.ScheduledPrice, error) {
+	var scheduled model.ScheduledPrice
+	err := r.scheduled.FindOneAndUpdate(
+		ctx,
+		bson.M{"status": model.ScheduleStatusPending, "effective_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": model.ScheduleStatusApplied, "applied_at": now}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "effective_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&scheduled)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// Unclaim возвращает изменение в очередь, если применить его не удалось
+func (r *mongoPriceRepository) Unclaim(ctx context.Context, id string) error {
+	_, err := r.scheduled.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": model.ScheduleStatusApplied},
+		bson.M{
+			"$set":   bson.M{"status": model.ScheduleStatusPending},
+			"$unset": bson.M{"applied_at": ""},
+		},
+	)
+	return err
+}
+
+func (r *mongoPriceRepository) findChanges(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*model.PriceChange, error) {
+	cursor, err := r.history.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	changes := []*model.PriceChange{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
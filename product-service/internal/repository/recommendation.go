@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecommendationRepository interface {
+	RecordOrder(ctx context.Context, userID string, productIDs []string, at time.Time) error
+	CoPurchased(ctx context.Context, productIDs []string, limit int) ([]ProductScore, error)
+	Purchased(ctx context.Context, userID string, limit int) ([]string, error)
+}
+
+// ProductScore - товар и его вес в рекомендациях
+type ProductScore struct {
+	ProductID string `bson:"_id"`
+	Score     int64  `bson:"score"`
+}
+
+type mongoRecommendationRepository struct {
+	client      *mongo.Client
+	coPurchases *mongo.Collection
+	purchases   *mongo.Collection
+}
+
+func NewRecommendationRepository(uri string) (RecommendationRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database("shoeshop")
+	coPurchases := db.Collection("co_purchases")
+	purchases := db.Collection("user_purchases")
+
+	_, err = coPurchases.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "other_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "count", Value: -1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = purchases.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoRecommendationRepository{
+		client:      client,
+		coPurchases: coPurchases,
+		purchases:   purchases,
+	}, nil
+}
+
+// RecordOrder учитывает заказ: каждая пара разных товаров заказа получает
+// +1 в обе стороны, а товары попадают в историю покупок пользователя
+func (r *mongoRecommendationRepository) RecordOrder(ctx context.Context, userID string, productIDs []string, at time.Time) error {
+	var pairs []mongo.WriteModel
+	for _, productID := range productIDs {
+		for _, otherID := range productIDs {
+			if productID == otherID {
+				continue
+			}
+			pairs = append(pairs, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"product_id": productID, "other_id": otherID}).
+				SetUpdate(bson.M{"$inc": bson.M{"count": 1}}).
+				SetUpsert(true))
+		}
+	}
+	if len(pairs) > 0 {
+		if _, err := r.coPurchases.BulkWrite(ctx, pairs, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	if userID == "" {
+		return nil
+	}
+	var purchases []mongo.WriteModel
+	for _, productID := range productIDs {
+		purchases = append(purchases, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"user_id": userID, "product_id": productID}).
+			SetUpdate(bson.M{"$set": bson.M{"purchased_at": at}}).
+			SetUpsert(true))
+	}
+	if len(purchases) == 0 {
+		return nil
+	}
+	_, err := r.purchases.BulkWrite(ctx, purchases, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// CoPurchased возвращает товары, которые чаще всего покупали вместе с
+// productIDs, кроме самих productIDs; самые частые первыми
+func (r *mongoRecommendationRepository) CoPurchased(ctx context.Context, productIDs []string, limit int) ([]ProductScore, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"product_id": bson.M{"$in": productIDs},
+			"other_id":   bson.M{"$nin": productIDs},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$other_id", "score": bson.M{"$sum": "$count"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.coPurchases.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	scores := []ProductScore{}
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Purchased возвращает товары, купленные пользователем, последние первыми
+func (r *mongoRecommendationRepository) Purchased(ctx context.Context, userID string, limit int) ([]string, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "purchased_at", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.purchases.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var purchases []struct {
+		ProductID string `bson:"product_id"`
+	}
+	if err := cursor.All(ctx, &purchases); err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]string, len(purchases))
+	for i, purchase := range purchases {
+		productIDs[i] = purchase.ProductID
+	}
+	return productIDs, nil
+}
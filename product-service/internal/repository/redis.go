@@ -3,24 +3,43 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"shoeshop/product-service/internal/model"
 )
 
+const (
+	queryKeyPrefix = "products:query:"
+	tagKeyPrefix   = "products:tag:"
+	queryTTL       = 10 * time.Minute
+)
+
 type Cache interface {
 	Get(ctx context.Context, key string) (*model.Product, error)
 	Set(ctx context.Context, key string, product *model.Product) error
 	Delete(ctx context.Context, key string) error
+	GetQuery(ctx context.Context, key string) ([]*model.Product, error)
+	SetQuery(ctx context.Context, key string, products []*model.Product, tags []string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
+	Stats() []LayerStats
 	Close() error
 }
 
 type redisCache struct {
 	client *redis.Client
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
 }
 
 func NewRedisCache(addr string) (Cache, error) {
+	return newRedisCache(addr)
+}
+
+func newRedisCache(addr string) (*redisCache, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:     addr,
 		Password: "", // no password set
@@ -29,6 +48,7 @@ func NewRedisCache(addr string) (Cache, error) {
 
 	// Проверяем подключение
 	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
 		return nil, err
 	}
 
@@ -41,10 +61,13 @@ func (c *redisCache) Get(ctx context.Context, key string) (*model.Product, error
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
 		if err == redis.Nil {
+			c.misses.Add(1)
 			return nil, nil // Кэш пуст
 		}
+		c.errors.Add(1)
 		return nil, err
 	}
+	c.hits.Add(1)
 
 	var product model.Product
 	if err := json.Unmarshal(data, &product); err != nil {
@@ -68,6 +91,120 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
 
+// cachedQuery - результат запроса вместе с тегами, чтобы его можно было
+// перенести в локальный кэш без потери инвалидации
+type cachedQuery struct {
+	Products []*model.Product `json:"products"`
+	Tags     []string         `json:"tags"`
+}
+
+// GetQuery возвращает закэшированный результат запроса; nil, nil - если его нет
+func (c *redisCache) GetQuery(ctx context.Context, key string) ([]*model.Product, error) {
+	query, err := c.getQuery(ctx, key)
+	if err != nil || query == nil {
+		return nil, err
+	}
+	return query.Products, nil
+}
+
+func (c *redisCache) getQuery(ctx context.Context, key string) (*cachedQuery, error) {
+	data, err := c.client.Get(ctx, queryKeyPrefix+key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			c.misses.Add(1)
+			return nil, nil
+		}
+		c.errors.Add(1)
+		return nil, err
+	}
+
+	var query cachedQuery
+	if err := json.Unmarshal(data, &query); err != nil || query.Products == nil {
+		// Запись в старом формате считаем промахом
+		c.misses.Add(1)
+		return nil, nil
+	}
+
+	c.hits.Add(1)
+	return &query, nil
+}
+
+// SetQuery сохраняет результат запроса и регистрирует ключ в наборах тегов
+func (c *redisCache) SetQuery(ctx context.Context, key string, products []*model.Product, tags []string) error {
+	if products == nil {
+		products = []*model.Product{}
+	}
+	data, err := json.Marshal(cachedQuery{Products: products, Tags: tags})
+	if err != nil {
+		return err
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, queryKeyPrefix+key, data, queryTTL)
+	for _, tag := range tags {
+		pipe.SAdd(ctx, tagKeyPrefix+tag, key)
+		// Набор тега живет не меньше, чем ключи в нем
+		pipe.Expire(ctx, tagKeyPrefix+tag, queryTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// InvalidateTags удаляет все результаты запросов, помеченные хотя бы одним из тегов
+func (c *redisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, tagKeyPrefix+tag).Result()
+		if err != nil {
+			return err
+		}
+
+		toDelete := make([]string, 0, len(keys)+1)
+		for _, key := range keys {
+			toDelete = append(toDelete, queryKeyPrefix+key)
+		}
+		toDelete = append(toDelete, tagKeyPrefix+tag)
+
+		if err := c.client.Del(ctx, toDelete...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *redisCache) Stats() []LayerStats {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	return []LayerStats{{
+		Layer:     "redis",
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Errors:    c.errors.Load(),
+		Entries:   int(c.client.DBSize(ctx).Val()),
+		Available: true,
+	}}
+}
+
+func (c *redisCache) ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// purgeQueries удаляет все результаты запросов и наборы тегов
+func (c *redisCache) purgeQueries(ctx context.Context) error {
+	for _, pattern := range []string{queryKeyPrefix + "*", tagKeyPrefix + "*"} {
+		iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *redisCache) Close() error {
 	return c.client.Close()
-} 
\ No newline at end of file
+}
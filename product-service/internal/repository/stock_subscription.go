@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/product-service/internal/model"
+)
+
+type StockSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *model.StockSubscription) (*model.StockSubscription, error)
+	ListByUser(ctx context.Context, userID string) ([]*model.StockSubscription, error)
+	ListByProduct(ctx context.Context, productID string) ([]*model.StockSubscription, error)
+	Delete(ctx context.Context, id, userID string) error
+	Claim(ctx context.Context, id string, lease time.Duration) (*model.StockSubscription, error)
+	Release(ctx context.Context, id string) error
+	Remove(ctx context.Context, id string) error
+}
+
+type mongoStockSubscriptionRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewStockSubscriptionRepository(uri string) (StockSubscriptionRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	collection := client.Database("shoeshop").Collection("stock_subscriptions")
+
+	_, err = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			// Повторная подписка на тот же товар и размер не создает дубликат
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "size", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoStockSubscriptionRepository{
+		client:     client,
+		collection: collection,
+	}, nil
+}
+
+// Create сохраняет подписку; если пользователь уже подписан на этот товар
+// и размер, возвращает существующую подписку
+func (r *mongoStockSubscriptionRepository) Create(ctx context.Context, subscription *model.StockSubscription) (*model.StockSubscription, error) {
+	filter := bson.M{
+		"user_id":    subscription.UserID,
+		"product_id": subscription.ProductID,
+		"size":       subscription.Size,
+	}
+
+	var saved model.StockSubscription
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{
+			// Адрес берем из последней подписки
+			"$set":         bson.M{"email": subscription.Email},
+			"$setOnInsert": bson.M{"_id": subscription.ID, "size_label": subscription.SizeLabel, "size_system": subscription.SizeSystem, "created_at": subscription.CreatedAt},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// ListByUser возвращает подписки пользователя, новые первыми
+func (r *mongoStockSubscriptionRepository) ListByUser(ctx context.Context, userID string) ([]*model.StockSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+// ListByProduct возвращает подписки на товар в порядке подписки
+func (r *mongoStockSubscriptionRepository) ListByProduct(ctx context.Context, productID string) ([]*model.StockSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return r.find(ctx, bson.M{"product_id": productID}, opts)
+}
+
+// Delete удаляет подписку пользователя; чужую или несуществующую подписку
+// не находит и возвращает mongo.ErrNoDocuments
+func (r *mongoStockSubscriptionRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Claim помечает подписку взятой и возвращает ее. Подписку получает только
+// один из одновременных обработчиков, поэтому письмо уходит один раз.
+// Пометка старше lease не считается: обработчик мог упасть, не отправив событие
+func (r *mongoStockSubscriptionRepository) Claim(ctx context.Context, id string, lease time.Duration) (*model.StockSubscription, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"claimed_at": bson.M{"$exists": false}},
+			bson.M{"claimed_at": bson.M{"$lt": now.Add(-lease)}},
+		},
+	}
+
+	var subscription model.StockSubscription
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"claimed_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&subscription)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// Release снимает пометку Claim, чтобы подписку взял следующий обработчик
+func (r *mongoStockSubscriptionRepository) Release(ctx context.Context, id string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"claimed_at": ""}})
+	return err
+}
+
+// Remove удаляет подписку, по которой уведомление уже отправлено
+func (r *mongoStockSubscriptionRepository) Remove(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *mongoStockSubscriptionRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*model.StockSubscription, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []*model.StockSubscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
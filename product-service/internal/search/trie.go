@@ -0,0 +1,381 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MaxLimit - максимальное число подсказок, которое хранится в кэше узла
+const MaxLimit = 50
+
+type SuggestionKind string
+
+const (
+	KindName     SuggestionKind = "name"
+	KindBrand    SuggestionKind = "brand"
+	KindCategory SuggestionKind = "category"
+)
+
+// Suggestion - одна подсказка для строки поиска
+type Suggestion struct {
+	Text      string
+	Kind      SuggestionKind
+	ProductID string
+	Score     int64
+}
+
+type term struct {
+	key       string
+	text      string
+	kind      SuggestionKind
+	productID string
+	products  map[string]struct{}
+	score     int64
+}
+
+type node struct {
+	children map[rune]*node
+	terms    map[*term]struct{}
+	// top - лучшие термы поддерева, пересчитываются лениво после изменений
+	top   []*term
+	fresh bool
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+type indexedProduct struct {
+	name       string
+	brand      string
+	category   string
+	popularity int64
+}
+
+// Index - префиксное дерево по названиям, брендам и категориям товаров.
+// Ранжирование идет по популярности (количеству заказанных единиц).
+type Index struct {
+	mu         sync.Mutex
+	root       *node
+	terms      map[string]*term
+	products   map[string]*indexedProduct
+	popularity map[string]int64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		root:       newNode(),
+		terms:      make(map[string]*term),
+		products:   make(map[string]*indexedProduct),
+		popularity: make(map[string]int64),
+	}
+}
+
+// Normalize приводит строку к виду, в котором она хранится в дереве
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// Upsert добавляет товар в индекс или обновляет его термы
+func (idx *Index) Upsert(id, name, brand, category string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+
+	p := &indexedProduct{
+		name:       name,
+		brand:      brand,
+		category:   category,
+		popularity: idx.popularity[id],
+	}
+	idx.products[id] = p
+
+	idx.attachLocked(id, KindName, name, p.popularity)
+	idx.attachLocked(id, KindBrand, brand, p.popularity)
+	idx.attachLocked(id, KindCategory, category, p.popularity)
+}
+
+// Remove удаляет товар из индекса
+func (idx *Index) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+}
+
+// AddPopularity увеличивает популярность товара и всех его термов
+func (idx *Index) AddPopularity(id string, delta int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.addPopularityLocked(id, delta)
+}
+
+// SetPopularity задает популярность товара (используется при начальной загрузке)
+func (idx *Index) SetPopularity(id string, value int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.addPopularityLocked(id, value-idx.popularity[id])
+}
+
+func (idx *Index) addPopularityLocked(id string, delta int64) {
+	idx.popularity[id] += delta
+
+	p, ok := idx.products[id]
+	if !ok {
+		return
+	}
+	p.popularity += delta
+	for _, t := range idx.termsOfLocked(id, p) {
+		t.score += delta
+		idx.invalidateLocked(t)
+	}
+}
+
+// Lookup возвращает до limit подсказок, начинающихся с prefix
+func (idx *Index) Lookup(prefix string, limit int) []Suggestion {
+	prefix = Normalize(prefix)
+	if prefix == "" || limit <= 0 {
+		return nil
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	// Полная блокировка: при чтении может пересчитываться кэш узлов
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	n := idx.root
+	for _, r := range prefix {
+		next, ok := n.children[r]
+		if !ok {
+			return nil
+		}
+		n = next
+	}
+
+	top := topOf(n)
+	if len(top) > limit {
+		top = top[:limit]
+	}
+
+	result := make([]Suggestion, len(top))
+	for i, t := range top {
+		result[i] = Suggestion{
+			Text:      t.text,
+			Kind:      t.kind,
+			ProductID: t.productID,
+			Score:     t.score,
+		}
+	}
+	return result
+}
+
+// Warm заранее строит кэш всех узлов, чтобы первый запрос не был медленным
+func (idx *Index) Warm() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	topOf(idx.root)
+}
+
+// topOf собирает лучшие термы поддерева из кэшей дочерних узлов
+func topOf(n *node) []*term {
+	if n.fresh {
+		return n.top
+	}
+
+	seen := make(map[*term]struct{})
+	var candidates []*term
+	add := func(t *term) {
+		if _, ok := seen[t]; ok {
+			return
+		}
+		seen[t] = struct{}{}
+		candidates = append(candidates, t)
+	}
+	for t := range n.terms {
+		add(t)
+	}
+	for _, child := range n.children {
+		for _, t := range topOf(child) {
+			add(t)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return less(candidates[i], candidates[j])
+	})
+	if len(candidates) > MaxLimit {
+		candidates = candidates[:MaxLimit]
+	}
+
+	n.top = candidates
+	n.fresh = true
+	return n.top
+}
+
+func less(a, b *term) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if a.kind != b.kind {
+		return kindRank(a.kind) < kindRank(b.kind)
+	}
+	if a.text != b.text {
+		return a.text < b.text
+	}
+	return a.key < b.key
+}
+
+func kindRank(k SuggestionKind) int {
+	switch k {
+	case KindBrand:
+		return 0
+	case KindCategory:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func termKey(kind SuggestionKind, productID, text string) string {
+	// Названия уникальны для товара, бренды и категории общие для всех товаров
+	if kind == KindName {
+		return string(kind) + "\x00" + productID
+	}
+	return string(kind) + "\x00" + Normalize(text)
+}
+
+func (idx *Index) termsOfLocked(id string, p *indexedProduct) []*term {
+	var result []*term
+	for _, k := range []struct {
+		kind SuggestionKind
+		text string
+	}{{KindName, p.name}, {KindBrand, p.brand}, {KindCategory, p.category}} {
+		if Normalize(k.text) == "" {
+			continue
+		}
+		if t, ok := idx.terms[termKey(k.kind, id, k.text)]; ok {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (idx *Index) attachLocked(id string, kind SuggestionKind, text string, popularity int64) {
+	normalized := Normalize(text)
+	if normalized == "" {
+		return
+	}
+
+	key := termKey(kind, id, text)
+	t, ok := idx.terms[key]
+	if !ok {
+		t = &term{
+			key:      key,
+			text:     strings.TrimSpace(text),
+			kind:     kind,
+			products: make(map[string]struct{}),
+		}
+		if kind == KindName {
+			t.productID = id
+		}
+		idx.terms[key] = t
+		idx.insertLocked(normalized, t)
+	}
+
+	t.products[id] = struct{}{}
+	t.score += popularity
+	idx.invalidateLocked(t)
+}
+
+func (idx *Index) removeLocked(id string) {
+	p, ok := idx.products[id]
+	if !ok {
+		return
+	}
+
+	for _, t := range idx.termsOfLocked(id, p) {
+		delete(t.products, id)
+		t.score -= p.popularity
+		if len(t.products) == 0 {
+			idx.deleteTermLocked(t)
+		} else {
+			idx.invalidateLocked(t)
+		}
+	}
+	delete(idx.products, id)
+}
+
+// insertLocked кладет терм в дерево с начала каждого слова,
+// чтобы "max" находил "Nike Air Max 90"
+func (idx *Index) insertLocked(normalized string, t *term) {
+	for _, suffix := range wordSuffixes(normalized) {
+		n := idx.root
+		n.fresh = false
+		for _, r := range suffix {
+			next, ok := n.children[r]
+			if !ok {
+				next = newNode()
+				n.children[r] = next
+			}
+			n = next
+			n.fresh = false
+		}
+		if n.terms == nil {
+			n.terms = make(map[*term]struct{})
+		}
+		n.terms[t] = struct{}{}
+	}
+}
+
+func (idx *Index) deleteTermLocked(t *term) {
+	delete(idx.terms, t.key)
+	for _, suffix := range wordSuffixes(Normalize(t.text)) {
+		removePath(idx.root, []rune(suffix), t)
+	}
+}
+
+// removePath удаляет терм и чистит опустевшие узлы; возвращает true, если узел пуст
+func removePath(n *node, path []rune, t *term) bool {
+	n.fresh = false
+	if len(path) == 0 {
+		delete(n.terms, t)
+	} else if child, ok := n.children[path[0]]; ok {
+		if removePath(child, path[1:], t) {
+			delete(n.children, path[0])
+		}
+	}
+	return len(n.terms) == 0 && len(n.children) == 0
+}
+
+// invalidateLocked сбрасывает кэш узлов на всех путях терма
+func (idx *Index) invalidateLocked(t *term) {
+	for _, suffix := range wordSuffixes(Normalize(t.text)) {
+		n := idx.root
+		n.fresh = false
+		for _, r := range suffix {
+			next, ok := n.children[r]
+			if !ok {
+				break
+			}
+			n = next
+			n.fresh = false
+		}
+	}
+}
+
+func wordSuffixes(normalized string) []string {
+	suffixes := []string{normalized}
+	for i, r := range normalized {
+		if r == ' ' {
+			suffixes = append(suffixes, normalized[i+1:])
+		}
+	}
+	return suffixes
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"shoeshop/product-service/internal/model"
+	pb "shoeshop/proto"
+)
+
+// Сколько архивных товаров проверяется в order-service за один запрос
+const purgeBatchSize = 100
+
+// RestoreProduct возвращает архивный товар в каталог. Для каталога и
+// поиска это то же, что появление нового товара
+func (s *productService) RestoreProduct(ctx context.Context, id string) (*model.Product, error) {
+	product, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore product: %w", err)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, id, product); err != nil {
+			fmt.Printf("failed to update product in cache: %v\n", err)
+		}
+	}
+
+	if err := s.publisher.PublishProductCreated(product); err != nil {
+		fmt.Printf("failed to publish product created event: %v\n", err)
+	}
+
+	return product, nil
+}
+
+func (s *productService) ListArchivedProducts(ctx context.Context) ([]*model.Product, error) {
+	products, err := s.repo.ListArchived(ctx, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived products: %w", err)
+	}
+	return products, nil
+}
+
+// StartArchivePurge раз в interval окончательно удаляет товары, которые
+// пролежали в архиве дольше retention и не встречаются ни в одном заказе
+func (s *productService) StartArchivePurge(ctx context.Context, orders pb.OrderServiceClient, interval, retention time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.purgeArchived(ctx, orders, retention)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Printf("Archive purge started, checking every %s", interval)
+}
+
+func (s *productService) purgeArchived(ctx context.Context, orders pb.OrderServiceClient, retention time.Duration) {
+	cutoff := time.Now().Add(-retention)
+	products, err := s.repo.ListArchived(ctx, cutoff)
+	if err != nil {
+		fmt.Printf("failed to list archived products: %v\n", err)
+		return
+	}
+
+	purged := 0
+	for start := 0; start < len(products); start += purgeBatchSize {
+		end := min(start+purgeBatchSize, len(products))
+		ids := make([]string, 0, end-start)
+		for _, product := range products[start:end] {
+			ids = append(ids, product.ID)
+		}
+
+		// Без ответа order-service не удаляем ничего: товар мог попасть в заказ
+		resp, err := orders.ReferencedProducts(ctx, &pb.ReferencedProductsRequest{ProductIds: ids})
+		if err != nil {
+			fmt.Printf("failed to check archived products in orders: %v\n", err)
+			return
+		}
+		referenced := make(map[string]bool, len(resp.GetProductIds()))
+		for _, id := range resp.GetProductIds() {
+			referenced[id] = true
+		}
+
+		for _, id := range ids {
+			if referenced[id] {
+				continue
+			}
+			deleted, err := s.repo.Purge(ctx, id, cutoff)
+			if err != nil {
+				fmt.Printf("failed to purge product %s: %v\n", id, err)
+				continue
+			}
+			if !deleted {
+				continue
+			}
+			purged++
+
+			if s.cache != nil {
+				if err := s.cache.Delete(ctx, id); err != nil {
+					fmt.Printf("failed to delete product from cache: %v\n", err)
+				}
+			}
+			// Другие экземпляры сервиса забывают товар по событию
+			if err := s.publisher.PublishProductDeleted(id); err != nil {
+				fmt.Printf("failed to publish product deleted event: %v\n", err)
+			}
+		}
+	}
+
+	if purged > 0 {
+		log.Printf("Purged %d archived products", purged)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+	"shoeshop/product-service/internal/search"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = search.MaxLimit
+)
+
+func (s *productService) Autocomplete(ctx context.Context, prefix string, limit int) ([]search.Suggestion, error) {
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	return s.index.Lookup(prefix, limit), nil
+}
+
+// StartIndexSync загружает каталог в префиксное дерево и подписывается
+// на события товаров и заказов, чтобы держать его актуальным
+func (s *productService) StartIndexSync(ctx context.Context, subscriber repository.EventSubscriber) error {
+	products, err := s.repo.List(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to load products for autocomplete: %w", err)
+	}
+	for _, product := range products {
+		s.index.Upsert(product.ID, product.Name, product.Brand, product.Category)
+	}
+
+	counts, err := s.repo.GetOrderCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load order counts: %w", err)
+	}
+	for productID, count := range counts {
+		s.index.SetPopularity(productID, count)
+	}
+	s.index.Warm()
+
+	indexProduct := func(product *model.Product) {
+		// Изменение архивного товара не возвращает его в подсказки
+		if product.Archived() {
+			s.index.Remove(product.ID)
+			return
+		}
+		s.index.Upsert(product.ID, product.Name, product.Brand, product.Category)
+	}
+	if err := subscriber.SubscribeProductCreated(indexProduct); err != nil {
+		return fmt.Errorf("failed to subscribe to product.created: %w", err)
+	}
+	if err := subscriber.SubscribeProductUpdated(indexProduct); err != nil {
+		return fmt.Errorf("failed to subscribe to product.updated: %w", err)
+	}
+	if err := subscriber.SubscribeProductDeleted(s.index.Remove); err != nil {
+		return fmt.Errorf("failed to subscribe to product.deleted: %w", err)
+	}
+	if err := subscriber.SubscribeOrderCreated(s.handleOrderCreated); err != nil {
+		return fmt.Errorf("failed to subscribe to order.created: %w", err)
+	}
+
+	log.Printf("Autocomplete index loaded: %d products", len(products))
+	return nil
+}
+
+// handleOrderCreated обновляет популярность в индексе этого экземпляра;
+// счетчики заказов в базе пишет handleOrderStock
+func (s *productService) handleOrderCreated(event *model.OrderCreatedEvent) {
+	for _, item := range event.Items {
+		if item.Quantity > 0 {
+			s.index.AddPopularity(item.ProductID, int64(item.Quantity))
+		}
+	}
+}
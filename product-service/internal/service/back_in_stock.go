@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+	"shoeshop/sizing"
+)
+
+const (
+	// Сколько подписка остается взятой после передачи письма email-service.
+	// Срок покрывает очередь писем; если подтверждения отправки за это время
+	// нет, подписку возьмет следующее изменение товара и письмо уйдет снова
+	subscriptionClaimLease = 30 * time.Minute
+	// Группа очереди подтверждений: подписку удаляет один экземпляр сервиса
+	backInStockSentQueue = "product-service.back-in-stock"
+)
+
+var (
+	ErrInvalidSubscription = errors.New("invalid stock subscription")
+	ErrInStock             = errors.New("product is in stock")
+)
+
+// SubscribeBackInStock подписывает пользователя на поступление товара или
+// одного размера. Подписаться можно только на то, чего сейчас нет в наличии
+func (s *productService) SubscribeBackInStock(ctx context.Context, userID, email, productID, size string, system sizing.System) (*model.StockSubscription, error) {
+	if userID == "" || email == "" || productID == "" {
+		return nil, fmt.Errorf("%w: user_id, email and product_id are required", ErrInvalidSubscription)
+	}
+
+	product, err := s.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.Archived() {
+		return nil, model.ErrArchived
+	}
+
+	subscription := &model.StockSubscription{
+		ID:         ids.New(),
+		UserID:     userID,
+		Email:      email,
+		ProductID:  productID,
+		SizeSystem: system,
+		CreatedAt:  time.Now(),
+	}
+	// Размер разбираем по таблице бренда; подписаться можно и на размер,
+	// которого сейчас нет среди размеров товара
+	if size != "" {
+		table := product.SizeTable()
+		subscription.Size, err = table.Parse(size, system)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+		}
+		subscription.SizeLabel = table.Format(subscription.Size, system)
+	}
+	if subscription.Available(product) {
+		return nil, ErrInStock
+	}
+
+	saved, err := s.subscriptions.Create(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save stock subscription: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *productService) UnsubscribeBackInStock(ctx context.Context, id, userID string) error {
+	if err := s.subscriptions.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete stock subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *productService) ListStockSubscriptions(ctx context.Context, userID string) ([]*model.StockSubscription, error) {
+	subscriptions, err := s.subscriptions.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// StartBackInStockNotifications подписывается на события товаров и, когда
+// товар из подписки снова в наличии, передает product.back_in_stock
+// email-service. Подписка удаляется, только когда email-service подтвердит
+// отправку письма событием product.back_in_stock.sent
+func (s *productService) StartBackInStockNotifications(subscriber repository.EventSubscriber) error {
+	notify := func(product *model.Product) {
+		s.notifyBackInStock(context.Background(), product)
+	}
+
+	if err := subscriber.SubscribeProductCreated(notify); err != nil {
+		return fmt.Errorf("failed to subscribe to product.created: %w", err)
+	}
+	if err := subscriber.SubscribeProductUpdated(notify); err != nil {
+		return fmt.Errorf("failed to subscribe to product.updated: %w", err)
+	}
+	if err := subscriber.QueueSubscribeBackInStockSent(backInStockSentQueue, s.handleBackInStockSent); err != nil {
+		return fmt.Errorf("failed to subscribe to product.back_in_stock.sent: %w", err)
+	}
+
+	log.Println("Back in stock notifications started")
+	return nil
+}
+
+func (s *productService) notifyBackInStock(ctx context.Context, product *model.Product) {
+	// Без остатка подписки не проверяем: так большинство событий не идут в БД
+	if product.Stock <= 0 || product.Archived() {
+		return
+	}
+
+	subscriptions, err := s.subscriptions.ListByProduct(ctx, product.ID)
+	if err != nil {
+		fmt.Printf("failed to get stock subscriptions of product %s: %v\n", product.ID, err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Available(product) {
+			continue
+		}
+
+		// Подписку мог уже забрать другой обработчик или удалить пользователь
+		claimed, err := s.subscriptions.Claim(ctx, subscription.ID, subscriptionClaimLease)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("failed to claim stock subscription %s: %v\n", subscription.ID, err)
+			continue
+		}
+
+		event := &model.BackInStockEvent{
+			SubscriptionID: claimed.ID,
+			UserID:         claimed.UserID,
+			Email:          claimed.Email,
+			ProductID:      product.ID,
+			ProductName:    product.Name,
+			Size:           claimed.SizeLabel,
+			Price:          product.Price,
+			At:             time.Now(),
+		}
+		if err := s.publisher.PublishBackInStock(event); err != nil {
+			// Письмо не принято: уведомление уйдет при следующем изменении товара
+			fmt.Printf("failed to publish back in stock event: %v\n", err)
+			if err := s.subscriptions.Release(ctx, claimed.ID); err != nil {
+				fmt.Printf("failed to release stock subscription %s: %v\n", claimed.ID, err)
+			}
+		}
+	}
+}
+
+// handleBackInStockSent удаляет подписку, письмо по которой отправлено
+func (s *productService) handleBackInStockSent(subscriptionID string) {
+	if err := s.subscriptions.Remove(context.Background(), subscriptionID); err != nil {
+		fmt.Printf("failed to remove stock subscription %s: %v\n", subscriptionID, err)
+	}
+}
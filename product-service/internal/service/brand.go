@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
+)
+
+var (
+	ErrBrandExists = errors.New("brand already exists")
+	ErrBrandInUse  = errors.New("brand has products")
+)
+
+// Бренды, как и категории, нужны почти каждому запросу и меняются редко
+const brandCatalogTTL = 30 * time.Second
+
+type brandCatalogCache struct {
+	mu       sync.Mutex
+	catalog  *model.BrandCatalog
+	loadedAt time.Time
+}
+
+// BrandCatalog возвращает все бренды только для чтения
+func (s *productService) BrandCatalog(ctx context.Context) (*model.BrandCatalog, error) {
+	s.brandCatalog.mu.Lock()
+	defer s.brandCatalog.mu.Unlock()
+
+	if s.brandCatalog.catalog != nil && time.Since(s.brandCatalog.loadedAt) < brandCatalogTTL {
+		return s.brandCatalog.catalog, nil
+	}
+
+	catalog, err := s.loadBrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.brandCatalog.catalog = catalog
+	s.brandCatalog.loadedAt = time.Now()
+	return catalog, nil
+}
+
+// loadBrandCatalog читает бренды из БД; результат можно менять через Add
+func (s *productService) loadBrandCatalog(ctx context.Context) (*model.BrandCatalog, error) {
+	brands, err := s.brands.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load brands: %w", err)
+	}
+	return model.NewBrandCatalog(brands), nil
+}
+
+func (s *productService) GetBrand(ctx context.Context, idOrSlug string) (*model.Brand, error) {
+	catalog, err := s.BrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	brand, ok := catalog.Find(idOrSlug)
+	if !ok {
+		return nil, fmt.Errorf("failed to get brand: %w", mongo.ErrNoDocuments)
+	}
+	return brand, nil
+}
+
+func (s *productService) ListBrands(ctx context.Context) ([]*model.Brand, error) {
+	catalog, err := s.BrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.All(), nil
+}
+
+func (s *productService) CreateBrand(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	if err := brand.Validate(); err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.loadBrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	brand.ID = ids.New()
+	brand.GenerateSlug()
+	if _, ok := catalog.Find(brand.Name); ok {
+		return nil, fmt.Errorf("%w: %s", ErrBrandExists, brand.Name)
+	}
+	if _, ok := catalog.Find(brand.Slug); ok {
+		return nil, fmt.Errorf("%w: %s", ErrBrandExists, brand.Slug)
+	}
+
+	createdBrand, err := s.brands.Create(ctx, brand)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("%w: %s", ErrBrandExists, brand.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create brand: %w", err)
+	}
+
+	s.brandsChanged()
+	return createdBrand, nil
+}
+
+// UpdateBrand меняет бренд и, если изменились название или таблица
+// размеров, переносит их во все товары бренда
+func (s *productService) UpdateBrand(ctx context.Context, brand *model.Brand) (*model.Brand, error) {
+	catalog, err := s.loadBrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, ok := catalog.ByID(brand.ID)
+	if !ok {
+		return nil, fmt.Errorf("failed to update brand: %w", mongo.ErrNoDocuments)
+	}
+	if brand.Slug != "" && brand.Slug != existing.Slug {
+		return nil, fmt.Errorf("%w: slug cannot be changed", model.ErrInvalidBrand)
+	}
+	brand.Slug = existing.Slug
+
+	if err := brand.Validate(); err != nil {
+		return nil, err
+	}
+	if other, ok := catalog.Find(brand.Name); ok && other.ID != brand.ID {
+		return nil, fmt.Errorf("%w: %s", ErrBrandExists, brand.Name)
+	}
+
+	updatedBrand, err := s.brands.Update(ctx, brand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update brand: %w", err)
+	}
+	s.brandsChanged()
+
+	if updatedBrand.Name != existing.Name || updatedBrand.SizeOffset != existing.SizeOffset {
+		if err := s.applyBrand(ctx, updatedBrand); err != nil {
+			return nil, err
+		}
+	}
+	return updatedBrand, nil
+}
+
+// applyBrand обновляет товары бренда и объявляет каждый событием
+// product.updated: так обновляются кэши, поисковый индекс и другие сервисы
+func (s *productService) applyBrand(ctx context.Context, brand *model.Brand) error {
+	if _, err := s.repo.SetBrand(ctx, brand); err != nil {
+		return fmt.Errorf("failed to update products of brand: %w", err)
+	}
+
+	err := s.repo.Stream(ctx, bson.M{"brand_id": brand.ID}, func(product *model.Product) error {
+		s.productChanged(ctx, product)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to announce products of brand: %w", err)
+	}
+	return nil
+}
+
+// DeleteBrand удаляет только бренд без товаров
+func (s *productService) DeleteBrand(ctx context.Context, id string) error {
+	count, err := s.repo.CountByBrand(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count brand products: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d products", ErrBrandInUse, count)
+	}
+
+	if err := s.brands.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete brand: %w", err)
+	}
+
+	s.brandsChanged()
+	return nil
+}
+
+// ResolveBrand находит бренд товара: по brand_id, а если его нет - по
+// названию. Незнакомое название становится новым брендом со встроенной
+// таблицей размеров, как при миграции. Товар без бренда - nil
+func (s *productService) ResolveBrand(ctx context.Context, id, name string) (*model.Brand, error) {
+	if id == "" && name == "" {
+		return nil, nil
+	}
+
+	catalog, err := s.loadBrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id != "" {
+		brand, ok := catalog.Find(id)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown brand %s", model.ErrInvalidBrand, id)
+		}
+		return brand, nil
+	}
+	return s.resolveBrand(ctx, catalog, name, true)
+}
+
+// resolveBrand находит бренд по названию в catalog. При create == false
+// незнакомый бренд не создается (проверка импорта)
+func (s *productService) resolveBrand(ctx context.Context, catalog *model.BrandCatalog, name string, create bool) (*model.Brand, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if brand, ok := catalog.Find(name); ok {
+		return brand, nil
+	}
+	if !create {
+		return &model.Brand{Name: name, SizeOffset: sizing.OffsetFor(name)}, nil
+	}
+
+	brand, err := s.CreateBrand(ctx, &model.Brand{
+		Name:       name,
+		SizeOffset: sizing.OffsetFor(name),
+	})
+	if errors.Is(err, ErrBrandExists) {
+		// Бренд успели создать параллельно
+		fresh, loadErr := s.loadBrandCatalog(ctx)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		if brand, ok := fresh.Find(name); ok {
+			catalog.Add(brand)
+			return brand, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	catalog.Add(brand)
+	return brand, nil
+}
+
+// brandFilter возвращает ID бренда из фильтра, который может быть ID,
+// slug или названием
+func (s *productService) brandFilter(ctx context.Context, value string) (string, error) {
+	catalog, err := s.BrandCatalog(ctx)
+	if err != nil {
+		return "", err
+	}
+	if brand, ok := catalog.Find(value); ok {
+		return brand.ID, nil
+	}
+	return value, nil
+}
+
+func (s *productService) brandsChanged() {
+	s.brandCatalog.mu.Lock()
+	s.brandCatalog.catalog = nil
+	s.brandCatalog.mu.Unlock()
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/catalog"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/sizing"
+)
+
+// Размер пачки для записи в Mongo при импорте
+const importBatchSize = 500
+
+type pendingRow struct {
+	line    int
+	product *model.Product
+}
+
+// ImportProducts читает файл каталога и записывает товары пачками с upsert по SKU.
+// Ошибки отдельных строк попадают в отчет и не прерывают импорт.
+// В режиме dryRun файл проверяется целиком, но ничего не записывается.
+func (s *productService) ImportProducts(ctx context.Context, format catalog.Format, system sizing.System, r io.Reader, dryRun bool) (*catalog.ImportReport, error) {
+	// Размеры в файле пересчитываются по таблице бренда товара
+	brands, err := s.loadBrandCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := catalog.NewReader(format, r, system, brands.SizeTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog file: %w", err)
+	}
+
+	report := &catalog.ImportReport{DryRun: dryRun}
+	// Для каждого SKU запоминаем строку, где он встретился впервые
+	seen := make(map[string]int)
+	batch := make([]pendingRow, 0, importBatchSize)
+
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog file: %w", err)
+		}
+
+		if row.Err != nil {
+			report.AddError(row.Line, skuOf(row.Product), row.Err)
+			continue
+		}
+		if err := catalog.Validate(row.Product); err != nil {
+			report.AddError(row.Line, row.Product.SKU, err)
+			continue
+		}
+		if first, ok := seen[row.Product.SKU]; ok {
+			report.AddError(row.Line, row.Product.SKU, fmt.Errorf("duplicate sku, first seen on line %d", first))
+			continue
+		}
+		seen[row.Product.SKU] = row.Line
+
+		batch = append(batch, pendingRow{line: row.Line, product: row.Product})
+		if len(batch) == importBatchSize {
+			if err := s.importBatch(ctx, batch, brands, report); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := s.importBatch(ctx, batch, brands, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *productService) importBatch(ctx context.Context, batch []pendingRow, brands *model.BrandCatalog, report *catalog.ImportReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	skus := make([]string, len(batch))
+	for i, row := range batch {
+		skus[i] = row.product.SKU
+	}
+	existing, err := s.repo.FindBySKUs(ctx, skus)
+	if err != nil {
+		return fmt.Errorf("failed to load existing products: %w", err)
+	}
+
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	products := make([]*model.Product, 0, len(batch))
+	rows := make([]pendingRow, 0, len(batch))
+	for _, row := range batch {
+		product := row.product
+		// В файле может быть название категории; новые категории создаются
+		// только при настоящем импорте
+		category, err := s.resolveCategory(ctx, tree, product.Category, !report.DryRun)
+		if err != nil {
+			report.AddError(row.line, product.SKU, err)
+			continue
+		}
+		product.Category = category
+		if err := s.setImportedBrand(ctx, brands, product, !report.DryRun); err != nil {
+			report.AddError(row.line, product.SKU, err)
+			continue
+		}
+		product.UpdatedAt = now
+		if current, ok := existing[product.SKU]; ok {
+			product.ID = current.ID
+			product.CreatedAt = current.CreatedAt
+			product.Images = mergeImages(current.Images, product.Images)
+			// Порог дозаказа файл каталога не меняет, а рейтинг из файла -
+			// только начальный: дальше его считает review-service
+			product.ReorderThreshold = current.ReorderThreshold
+			product.Rating = current.Rating
+			product.ReviewCount = current.ReviewCount
+		} else {
+			product.ID = ids.New()
+			product.CreatedAt = now
+		}
+		normalizeLists(product)
+		products = append(products, product)
+		rows = append(rows, row)
+	}
+	if len(products) == 0 {
+		return nil
+	}
+
+	if report.DryRun {
+		for _, product := range products {
+			if _, ok := existing[product.SKU]; ok {
+				report.Updated++
+			} else {
+				report.Created++
+			}
+		}
+		return nil
+	}
+
+	failed, err := s.repo.UpsertBySKU(ctx, products)
+	if err != nil {
+		return fmt.Errorf("failed to write products: %w", err)
+	}
+
+	for i, product := range products {
+		if err, ok := failed[i]; ok {
+			report.AddError(rows[i].line, product.SKU, err)
+			continue
+		}
+
+		// События обновляют кэши и поисковый индекс так же, как при обычном CRUD
+		if current, ok := existing[product.SKU]; ok {
+			report.Updated++
+			s.recordPrice(ctx, product.ID, current.Price, product.Price, model.PriceSourceImport, now)
+			if err := s.publisher.PublishProductUpdated(product); err != nil {
+				fmt.Printf("failed to publish product updated event: %v\n", err)
+			}
+			s.checkLowStock(current.LowStock(), product)
+		} else {
+			report.Created++
+			s.recordPrice(ctx, product.ID, 0, product.Price, model.PriceSourceImport, now)
+			if err := s.publisher.PublishProductCreated(product); err != nil {
+				fmt.Printf("failed to publish product created event: %v\n", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ExportProducts выгружает товары, подходящие под фильтр, в формате импорта
+func (s *productService) ExportProducts(ctx context.Context, format catalog.Format, system sizing.System, filter map[string]interface{}, w io.Writer) error {
+	writer, err := catalog.NewWriter(format, w, system)
+	if err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+
+	bsonFilter, _, _, err := s.productFilter(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	// Архивные товары в выгрузку каталога не попадают
+	bsonFilter["deleted_at"] = bson.M{"$exists": false}
+
+	if err := s.repo.Stream(ctx, bsonFilter, writer.Write); err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+	return nil
+}
+
+// setImportedBrand связывает товар из файла с брендом по названию;
+// новые бренды создаются только при настоящем импорте
+func (s *productService) setImportedBrand(ctx context.Context, brands *model.BrandCatalog, product *model.Product, create bool) error {
+	brand, err := s.resolveBrand(ctx, brands, product.Brand, create)
+	if err != nil {
+		return err
+	}
+	if brand == nil {
+		product.BrandID = ""
+		product.SizeOffset = 0
+		return nil
+	}
+	product.BrandID = brand.ID
+	product.Brand = brand.Name
+	product.SizeOffset = brand.SizeOffset
+	return nil
+}
+
+func skuOf(product *model.Product) string {
+	if product == nil {
+		return ""
+	}
+	return product.SKU
+}
+
+// normalizeLists заменяет nil-списки пустыми, чтобы в Mongo не попадал null
+func normalizeLists(product *model.Product) {
+	if product.Sizes == nil {
+		product.Sizes = []sizing.Size{}
+	}
+	if product.Colors == nil {
+		product.Colors = []string{}
+	}
+	if product.Images == nil {
+		product.Images = []model.Image{}
+	}
+	if product.Features == nil {
+		product.Features = []string{}
+	}
+}
+
+// mergeImages применяет список изображений из файла к уже сохраненным.
+// Файл содержит только URL, поэтому у знакомых URL сохраняем размеры,
+// миниатюры и подписи; пустой список в файле оставляет изображения как есть
+func mergeImages(current, fromFile []model.Image) []model.Image {
+	if fromFile == nil {
+		return current
+	}
+
+	byURL := make(map[string]model.Image, len(current))
+	for _, image := range current {
+		byURL[image.URL] = image
+	}
+
+	merged := make([]model.Image, len(fromFile))
+	for i, image := range fromFile {
+		if known, ok := byURL[image.URL]; ok {
+			known.SortOrder = image.SortOrder
+			image = known
+		}
+		merged[i] = image
+	}
+	return merged
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+)
+
+var (
+	ErrCategoryExists   = errors.New("category slug already exists")
+	ErrCategoryNotEmpty = errors.New("category has subcategories or products")
+)
+
+// Дерево нужно каждому ответу с товаром, поэтому оно недолго живет в памяти.
+// Изменения, сделанные другим экземпляром сервиса, видны не позже чем через TTL
+const categoryTreeTTL = 30 * time.Second
+
+type categoryTreeCache struct {
+	mu       sync.Mutex
+	tree     *model.CategoryTree
+	loadedAt time.Time
+}
+
+// CategoryTree возвращает дерево категорий только для чтения
+func (s *productService) CategoryTree(ctx context.Context) (*model.CategoryTree, error) {
+	s.categoryTree.mu.Lock()
+	defer s.categoryTree.mu.Unlock()
+
+	if s.categoryTree.tree != nil && time.Since(s.categoryTree.loadedAt) < categoryTreeTTL {
+		return s.categoryTree.tree, nil
+	}
+
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.categoryTree.tree = tree
+	s.categoryTree.loadedAt = time.Now()
+	return tree, nil
+}
+
+// loadCategoryTree загружает дерево целиком: категорий немного, а навигация
+// в памяти проще запросов по уровням. Результат можно менять через Add
+func (s *productService) loadCategoryTree(ctx context.Context) (*model.CategoryTree, error) {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	return model.NewCategoryTree(categories), nil
+}
+
+func (s *productService) CreateCategory(ctx context.Context, category *model.Category) (*model.Category, error) {
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *model.Category
+	if category.ParentID != "" {
+		var ok bool
+		if parent, ok = tree.ByID(category.ParentID); !ok {
+			return nil, fmt.Errorf("%w: unknown parent %s", model.ErrInvalidCategory, category.ParentID)
+		}
+	}
+
+	category.ID = ids.New()
+	category.GenerateSlug(parent)
+	if _, ok := tree.BySlug(category.Slug); ok {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryExists, category.Slug)
+	}
+
+	createdCategory, err := s.categories.Create(ctx, category)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryExists, category.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	s.categoriesChanged(ctx)
+	return createdCategory, nil
+}
+
+// UpdateCategory меняет названия и порядок или переносит категорию вместе
+// с поддеревом. Slug не меняется: на него ссылаются товары и URL витрины
+func (s *productService) UpdateCategory(ctx context.Context, category *model.Category) (*model.Category, error) {
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, ok := tree.ByID(category.ID)
+	if !ok {
+		return nil, fmt.Errorf("failed to update category: %w", mongo.ErrNoDocuments)
+	}
+	if category.Slug != "" && category.Slug != existing.Slug {
+		return nil, fmt.Errorf("%w: slug cannot be changed", model.ErrInvalidCategory)
+	}
+	category.Slug = existing.Slug
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+	if category.ParentID != "" {
+		if _, ok := tree.ByID(category.ParentID); !ok {
+			return nil, fmt.Errorf("%w: unknown parent %s", model.ErrInvalidCategory, category.ParentID)
+		}
+		if tree.IsDescendant(category.ParentID, category.ID) {
+			return nil, fmt.Errorf("%w: category cannot be moved into its own subtree", model.ErrInvalidCategory)
+		}
+	}
+
+	updatedCategory, err := s.categories.Update(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	s.categoriesChanged(ctx)
+	return updatedCategory, nil
+}
+
+// DeleteCategory удаляет только пустую категорию, чтобы товары
+// и подкатегории не остались без места в дереве
+func (s *productService) DeleteCategory(ctx context.Context, id string) error {
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	category, ok := tree.ByID(id)
+	if !ok {
+		return fmt.Errorf("failed to delete category: %w", mongo.ErrNoDocuments)
+	}
+	if len(tree.Children(id)) > 0 {
+		return fmt.Errorf("%w: %s has subcategories", ErrCategoryNotEmpty, category.Slug)
+	}
+
+	count, err := s.repo.CountByCategory(ctx, category.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to count category products: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s has %d products", ErrCategoryNotEmpty, category.Slug, count)
+	}
+
+	if err := s.categories.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	s.categoriesChanged(ctx)
+	return nil
+}
+
+// resolveCategory приводит категорию товара к slug. Название из формы или
+// файла импорта находит категорию по имени, а незнакомое значение становится
+// новой корневой категорией, как при миграции строковых категорий.
+// При create == false ничего не создается (проверка импорта)
+func (s *productService) resolveCategory(ctx context.Context, tree *model.CategoryTree, value string, create bool) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if category, ok := tree.Resolve(value); ok {
+		return category.Slug, nil
+	}
+	if !create {
+		return value, nil
+	}
+
+	category, err := s.CreateCategory(ctx, &model.Category{
+		Names: map[string]string{model.DefaultLocale: value},
+	})
+	if errors.Is(err, ErrCategoryExists) {
+		// Slug из названия уже занят - значит, это та же категория
+		return model.Slugify(value), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tree.Add(category)
+	return category.Slug, nil
+}
+
+// categorySubtree возвращает slug категории фильтра и всех ее потомков
+func (s *productService) categorySubtree(ctx context.Context, value string) ([]string, error) {
+	tree, err := s.CategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category, ok := tree.Resolve(value)
+	if !ok {
+		return []string{value}, nil
+	}
+	return tree.Descendants(category.Slug), nil
+}
+
+// categoriesChanged сбрасывает дерево в памяти и закэшированные списки
+// по категориям: перенос узла меняет состав поддерева
+func (s *productService) categoriesChanged(ctx context.Context) {
+	s.categoryTree.mu.Lock()
+	s.categoryTree.tree = nil
+	s.categoryTree.mu.Unlock()
+
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.InvalidateTags(ctx, tagCategories); err != nil {
+		fmt.Printf("failed to invalidate cached category queries: %v\n", err)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"shoeshop/product-service/internal/imaging"
+	"shoeshop/product-service/internal/model"
+)
+
+// UploadImage проверяет файл, сохраняет его вместе с миниатюрами и добавляет
+// изображение в конец галереи товара
+func (s *productService) UploadImage(ctx context.Context, productID, alt string, r io.Reader) (*model.Image, error) {
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	// Читаем на байт больше лимита, чтобы отличить "ровно лимит" от "больше лимита"
+	data, err := io.ReadAll(io.LimitReader(r, imaging.MaxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	processed, err := imaging.Process(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process image: %w", err)
+	}
+
+	url, err := s.storage.Save(ctx, processed.Original.Name, processed.Original.ContentType, processed.Original.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+
+	image := model.Image{
+		ID:          processed.ID,
+		URL:         url,
+		Width:       int32(processed.Original.Width),
+		Height:      int32(processed.Original.Height),
+		Alt:         alt,
+		SortOrder:   int32(len(product.Images)),
+		ContentType: processed.Original.ContentType,
+		Size:        int64(len(processed.Original.Data)),
+		Thumbnails:  []model.Thumbnail{},
+	}
+
+	for _, thumbnail := range processed.Thumbnails {
+		thumbURL, err := s.storage.Save(ctx, thumbnail.Name, thumbnail.ContentType, thumbnail.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+		}
+		image.Thumbnails = append(image.Thumbnails, model.Thumbnail{
+			URL:    thumbURL,
+			Width:  int32(thumbnail.Width),
+			Height: int32(thumbnail.Height),
+		})
+	}
+
+	updatedProduct, err := s.repo.AddImage(ctx, productID, image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add image: %w", err)
+	}
+	s.productChanged(ctx, updatedProduct)
+
+	// Повторная загрузка того же файла возвращает уже сохраненную запись
+	return findImage(updatedProduct, image.ID), nil
+}
+
+func (s *productService) UpdateImage(ctx context.Context, productID, imageID, alt string, sortOrder int32) (*model.Image, error) {
+	product, err := s.repo.UpdateImage(ctx, productID, imageID, alt, sortOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update image: %w", err)
+	}
+	s.productChanged(ctx, product)
+
+	return findImage(product, imageID), nil
+}
+
+// DeleteImage убирает изображение из галереи товара. Файлы остаются в хранилище:
+// имена строятся из хэша содержимого, и тот же файл может быть у другого товара
+func (s *productService) DeleteImage(ctx context.Context, productID, imageID string) error {
+	product, err := s.repo.RemoveImage(ctx, productID, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+	s.productChanged(ctx, product)
+
+	return nil
+}
+
+// productChanged обновляет кэш и публикует событие после изменения товара
+func (s *productService) productChanged(ctx context.Context, product *model.Product) {
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, product.ID, product); err != nil {
+			fmt.Printf("failed to update product in cache: %v\n", err)
+		}
+	}
+
+	if err := s.publisher.PublishProductUpdated(product); err != nil {
+		fmt.Printf("failed to publish product updated event: %v\n", err)
+	}
+}
+
+func findImage(product *model.Product, imageID string) *model.Image {
+	for i := range product.Images {
+		if product.Images[i].ID == imageID {
+			return &product.Images[i]
+		}
+	}
+	return nil
+}
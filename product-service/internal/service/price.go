@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"shoeshop/ids"
+	"shoeshop/product-service/internal/model"
+)
+
+const (
+	// Окно "минимальной цены за 30 дней" для отметки о скидке
+	defaultPriceWindowDays = 30
+	maxPriceWindowDays     = 365
+	defaultHistoryLimit    = 50
+	maxHistoryLimit        = 500
+)
+
+var (
+	ErrInvalidPriceChange = errors.New("invalid price change")
+	ErrScheduleNotPending = errors.New("scheduled price change is not pending")
+)
+
+// recordPrice пишет изменение цены в историю. Ошибка не прерывает изменение
+// товара: цена уже сохранена, а пропуск в истории лучше, чем откат
+func (s *productService) recordPrice(ctx context.Context, productID string, previous, price float64, source model.PriceSource, at time.Time) {
+	if previous == price && source != model.PriceSourceCreate {
+		return
+	}
+
+	err := s.prices.Record(ctx, &model.PriceChange{
+		ID:            ids.New(),
+		ProductID:     productID,
+		Price:         price,
+		PreviousPrice: previous,
+		Source:        source,
+		ChangedAt:     at,
+	})
+	if err != nil {
+		fmt.Printf("failed to record price change of product %s: %v\n", productID, err)
+	}
+}
+
+// GetPriceHistory возвращает историю цен и минимальную цену, действовавшую
+// за последние days дней, включая текущую
+func (s *productService) GetPriceHistory(ctx context.Context, productID string, days, limit int) (*model.PriceHistory, error) {
+	if days <= 0 {
+		days = defaultPriceWindowDays
+	}
+	if days > maxPriceWindowDays {
+		days = maxPriceWindowDays
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	product, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	lowest, found, err := s.prices.LowestSince(ctx, productID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lowest price: %w", err)
+	}
+	if !found {
+		lowest = product.Price
+	}
+
+	changes, err := s.prices.History(ctx, productID, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	return &model.PriceHistory{
+		ProductID:    productID,
+		CurrentPrice: product.Price,
+		LowestPrice:  math.Min(lowest, product.Price),
+		Days:         days,
+		Changes:      changes,
+	}, nil
+}
+
+func (s *productService) SchedulePriceChange(ctx context.Context, productID string, price float64, effectiveAt time.Time) (*model.ScheduledPrice, error) {
+	if price <= 0 {
+		return nil, fmt.Errorf("%w: price must be positive", ErrInvalidPriceChange)
+	}
+	now := time.Now()
+	if !effectiveAt.After(now) {
+		return nil, fmt.Errorf("%w: effective_at must be in the future", ErrInvalidPriceChange)
+	}
+
+	if _, err := s.repo.GetByID(ctx, productID); err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	scheduled := &model.ScheduledPrice{
+		ID:          ids.New(),
+		ProductID:   productID,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+		Status:      model.ScheduleStatusPending,
+		CreatedAt:   now,
+	}
+	if err := s.prices.Schedule(ctx, scheduled); err != nil {
+		return nil, fmt.Errorf("failed to schedule price change: %w", err)
+	}
+	return scheduled, nil
+}
+
+func (s *productService) ListScheduledPrices(ctx context.Context, productID string, status model.ScheduleStatus) ([]*model.ScheduledPrice, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidPriceChange, status)
+	}
+
+	scheduled, err := s.prices.ListScheduled(ctx, productID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled prices: %w", err)
+	}
+	return scheduled, nil
+}
+
+func (s *productService) CancelScheduledPrice(ctx context.Context, id, productID string) (*model.ScheduledPrice, error) {
+	scheduled, err := s.prices.CancelScheduled(ctx, id, productID)
+	if err == mongo.ErrNoDocuments {
+		// Отличаем отсутствующее изменение от уже примененного или отмененного
+		existing, getErr := s.prices.GetScheduled(ctx, id)
+		if getErr == nil && existing.ProductID == productID {
+			return nil, fmt.Errorf("%w: status is %s", ErrScheduleNotPending, existing.Status)
+		}
+		return nil, fmt.Errorf("failed to cancel scheduled price: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel scheduled price: %w", err)
+	}
+	return scheduled, nil
+}
+
+// StartPriceScheduler раз в interval применяет наступившие изменения цен.
+// Изменение объявляется событием product.updated, как и обычное обновление
+func (s *productService) StartPriceScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.applyDuePrices(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Printf("Price scheduler started, checking every %s", interval)
+}
+
+func (s *productService) applyDuePrices(ctx context.Context) {
+	for {
+		scheduled, err := s.prices.ClaimDue(ctx, time.Now())
+		if err != nil {
+			fmt.Printf("failed to get due price changes: %v\n", err)
+			return
+		}
+		if scheduled == nil {
+			return
+		}
+
+		previous, product, err := s.repo.SetPrice(ctx, scheduled.ProductID, scheduled.Price)
+		if err == mongo.ErrNoDocuments {
+			// Товар удалили - изменение применять некуда
+			if err := s.prices.Unclaim(ctx, scheduled.ID); err == nil {
+				_, err = s.prices.CancelScheduled(ctx, scheduled.ID, scheduled.ProductID)
+			}
+			if err != nil {
+				fmt.Printf("failed to cancel price change %s of deleted product: %v\n", scheduled.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			fmt.Printf("failed to apply price change %s: %v\n", scheduled.ID, err)
+			if err := s.prices.Unclaim(ctx, scheduled.ID); err != nil {
+				fmt.Printf("failed to return price change %s to the queue: %v\n", scheduled.ID, err)
+			}
+			// Повторим на следующем тике, а не в плотном цикле
+			return
+		}
+
+		s.recordPrice(ctx, product.ID, previous, product.Price, model.PriceSourceSchedule, product.UpdatedAt)
+		s.productChanged(ctx, product)
+	}
+}
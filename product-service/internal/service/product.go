@@ -3,42 +3,110 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/sync/singleflight"
+	"shoeshop/product-service/internal/catalog"
 	"shoeshop/product-service/internal/model"
 	"shoeshop/product-service/internal/repository"
+	"shoeshop/product-service/internal/search"
+	"shoeshop/product-service/internal/storage"
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
 )
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, product *model.Product) (*model.Product, error)
 	GetProduct(ctx context.Context, id string) (*model.Product, error)
-	UpdateProduct(ctx context.Context, product *model.Product) (*model.Product, error)
+	UpdateProduct(ctx context.Context, product *model.Product, paths []string) (*model.Product, error)
 	DeleteProduct(ctx context.Context, id string) error
+	RestoreProduct(ctx context.Context, id string) (*model.Product, error)
+	ListArchivedProducts(ctx context.Context) ([]*model.Product, error)
+	StartArchivePurge(ctx context.Context, orders pb.OrderServiceClient, interval, retention time.Duration)
 	ListProducts(ctx context.Context, filter map[string]interface{}) ([]*model.Product, error)
 	SearchProducts(ctx context.Context, query string) ([]*model.Product, error)
+	Autocomplete(ctx context.Context, prefix string, limit int) ([]search.Suggestion, error)
+	StartIndexSync(ctx context.Context, subscriber repository.EventSubscriber) error
+	StartCacheInvalidation(subscriber repository.EventSubscriber) error
+	CacheStats(ctx context.Context) []repository.LayerStats
+	StartStockSync(subscriber repository.EventSubscriber) error
+	GetReorderReport(ctx context.Context, days, coverDays int) (*model.ReorderReport, error)
+	SubscribeBackInStock(ctx context.Context, userID, email, productID, size string, system sizing.System) (*model.StockSubscription, error)
+	UnsubscribeBackInStock(ctx context.Context, id, userID string) error
+	ListStockSubscriptions(ctx context.Context, userID string) ([]*model.StockSubscription, error)
+	StartBackInStockNotifications(subscriber repository.EventSubscriber) error
+	StartRecommendationSync(subscriber repository.EventSubscriber) error
+	GetRecommendations(ctx context.Context, productIDs []string, userID string, limit int) ([]*model.Recommendation, error)
+	ImportProducts(ctx context.Context, format catalog.Format, system sizing.System, r io.Reader, dryRun bool) (*catalog.ImportReport, error)
+	ExportProducts(ctx context.Context, format catalog.Format, system sizing.System, filter map[string]interface{}, w io.Writer) error
+	UploadImage(ctx context.Context, productID, alt string, r io.Reader) (*model.Image, error)
+	UpdateImage(ctx context.Context, productID, imageID, alt string, sortOrder int32) (*model.Image, error)
+	DeleteImage(ctx context.Context, productID, imageID string) error
+	StartRatingSync(subscriber repository.EventSubscriber) error
+	GetPriceHistory(ctx context.Context, productID string, days, limit int) (*model.PriceHistory, error)
+	SchedulePriceChange(ctx context.Context, productID string, price float64, effectiveAt time.Time) (*model.ScheduledPrice, error)
+	ListScheduledPrices(ctx context.Context, productID string, status model.ScheduleStatus) ([]*model.ScheduledPrice, error)
+	CancelScheduledPrice(ctx context.Context, id, productID string) (*model.ScheduledPrice, error)
+	StartPriceScheduler(ctx context.Context, interval time.Duration)
+	CategoryTree(ctx context.Context) (*model.CategoryTree, error)
+	CreateCategory(ctx context.Context, category *model.Category) (*model.Category, error)
+	UpdateCategory(ctx context.Context, category *model.Category) (*model.Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+	GetBrand(ctx context.Context, idOrSlug string) (*model.Brand, error)
+	ListBrands(ctx context.Context) ([]*model.Brand, error)
+	CreateBrand(ctx context.Context, brand *model.Brand) (*model.Brand, error)
+	UpdateBrand(ctx context.Context, brand *model.Brand) (*model.Brand, error)
+	DeleteBrand(ctx context.Context, id string) error
+	ResolveBrand(ctx context.Context, id, name string) (*model.Brand, error)
 }
 
 type productService struct {
-	repo      repository.ProductRepository
-	cache     repository.Cache
-	publisher repository.EventPublisher
+	repo            repository.ProductRepository
+	prices          repository.PriceRepository
+	categories      repository.CategoryRepository
+	brands          repository.BrandRepository
+	subscriptions   repository.StockSubscriptionRepository
+	recommendations repository.RecommendationRepository
+	cache           repository.Cache
+	publisher       repository.EventPublisher
+	storage         storage.Storage
+	index           *search.Index
+	group           singleflight.Group
+
+	categoryTree categoryTreeCache
+	brandCatalog brandCatalogCache
 }
 
-func NewProductService(repo repository.ProductRepository, cache repository.Cache, publisher repository.EventPublisher) ProductService {
+func NewProductService(repo repository.ProductRepository, prices repository.PriceRepository, categories repository.CategoryRepository, brands repository.BrandRepository, subscriptions repository.StockSubscriptionRepository, recommendations repository.RecommendationRepository, cache repository.Cache, publisher repository.EventPublisher, storage storage.Storage) ProductService {
 	return &productService{
-		repo:      repo,
-		cache:     cache,
-		publisher: publisher,
+		repo:            repo,
+		prices:          prices,
+		categories:      categories,
+		brands:          brands,
+		subscriptions:   subscriptions,
+		recommendations: recommendations,
+		cache:           cache,
+		publisher:       publisher,
+		storage:         storage,
+		index:           search.NewIndex(),
 	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, product *model.Product) (*model.Product, error) {
+	if err := s.setCategory(ctx, product); err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
 	// Создаем продукт в БД
 	createdProduct, err := s.repo.Create(ctx, product)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
+	s.recordPrice(ctx, createdProduct.ID, 0, createdProduct.Price, model.PriceSourceCreate, createdProduct.CreatedAt)
+
 	// Кэшируем продукт
 	if s.cache != nil {
 		if err := s.cache.Set(ctx, createdProduct.ID, createdProduct); err != nil {
@@ -51,6 +119,7 @@ func (s *productService) CreateProduct(ctx context.Context, product *model.Produ
 	if err := s.publisher.PublishProductCreated(createdProduct); err != nil {
 		fmt.Printf("failed to publish product created event: %v\n", err)
 	}
+	s.checkLowStock(false, createdProduct)
 
 	return createdProduct, nil
 }
@@ -63,11 +132,14 @@ func (s *productService) GetProduct(ctx context.Context, id string) (*model.Prod
 		}
 	}
 
-	// Получаем из БД
-	product, err := s.repo.GetByID(ctx, id)
+	// Получаем из БД; одновременные промахи по одному ключу идут в БД один раз
+	result, err, _ := s.group.Do("product:"+id, func() (interface{}, error) {
+		return s.repo.GetByID(ctx, id)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
+	product := result.(*model.Product)
 
 	// Кэшируем
 	if s.cache != nil {
@@ -79,13 +151,39 @@ func (s *productService) GetProduct(ctx context.Context, id string) (*model.Prod
 	return product, nil
 }
 
-func (s *productService) UpdateProduct(ctx context.Context, product *model.Product) (*model.Product, error) {
+// UpdateProduct меняет поля из маски paths; пустая маска перезаписывает товар целиком
+func (s *productService) UpdateProduct(ctx context.Context, product *model.Product, paths []string) (*model.Product, error) {
+	fields, err := model.UpdateFields(paths)
+	if err != nil {
+		return nil, err
+	}
+	if product.Version == 0 {
+		return nil, fmt.Errorf("failed to update product: %w", model.ErrVersionRequired)
+	}
+
+	// Прежняя цена нужна для истории цен
+	existing, err := s.repo.GetByID(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	// Устаревшую версию отклоняем сразу; гонку между чтением и записью
+	// все равно ловит условие на версию в Update
+	if product.Version != existing.Version {
+		return nil, fmt.Errorf("failed to update product: %w", model.ErrVersionConflict)
+	}
+
+	if err := s.setCategory(ctx, product); err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
 	// Обновляем в БД
-	updatedProduct, err := s.repo.Update(ctx, product)
+	updatedProduct, err := s.repo.Update(ctx, product, fields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
+	s.recordPrice(ctx, updatedProduct.ID, existing.Price, updatedProduct.Price, model.PriceSourceUpdate, updatedProduct.UpdatedAt)
+
 	// Обновляем кэш
 	if s.cache != nil {
 		if err := s.cache.Set(ctx, product.ID, updatedProduct); err != nil {
@@ -97,20 +195,28 @@ func (s *productService) UpdateProduct(ctx context.Context, product *model.Produ
 	if err := s.publisher.PublishProductUpdated(updatedProduct); err != nil {
 		fmt.Printf("failed to publish product updated event: %v\n", err)
 	}
+	s.checkLowStock(existing.LowStock(), updatedProduct)
 
 	return updatedProduct, nil
 }
 
+// DeleteProduct переносит товар в архив: из каталога и поиска он пропадает,
+// но GetProduct его находит, чтобы заказы со ссылкой на товар открывались
 func (s *productService) DeleteProduct(ctx context.Context, id string) error {
-	// Удаляем из БД
-	if err := s.repo.Delete(ctx, id); err != nil {
+	product, err := s.repo.Archive(ctx, id)
+	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
-	// Удаляем из кэша
+	// Запланированные цены удаленного товара больше не применятся
+	if err := s.prices.CancelPending(ctx, id); err != nil {
+		fmt.Printf("failed to cancel scheduled prices: %v\n", err)
+	}
+
+	// Кэш отдает товар уже с отметкой об удалении
 	if s.cache != nil {
-		if err := s.cache.Delete(ctx, id); err != nil {
-			fmt.Printf("failed to delete product from cache: %v\n", err)
+		if err := s.cache.Set(ctx, id, product); err != nil {
+			fmt.Printf("failed to update product in cache: %v\n", err)
 		}
 	}
 
@@ -123,15 +229,69 @@ func (s *productService) DeleteProduct(ctx context.Context, id string) error {
 }
 
 func (s *productService) ListProducts(ctx context.Context, filter map[string]interface{}) ([]*model.Product, error) {
-	// Конвертируем фильтр в BSON
+	var categories []string
+	var brandID string
+	return s.cachedQuery(ctx, listQueryKey(filter), func() ([]*model.Product, error) {
+		var bsonFilter bson.M
+		var err error
+		bsonFilter, categories, brandID, err = s.productFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return s.repo.List(ctx, bsonFilter)
+	}, func(products []*model.Product) []string {
+		return listTags(categories, brandID, products)
+	})
+}
+
+// productFilter конвертирует фильтр в BSON. Категория включает все
+// подкатегории, бренд задается ID, slug или названием. Найденные
+// категории и ID бренда нужны для тегов кэша
+func (s *productService) productFilter(ctx context.Context, filter map[string]interface{}) (bson.M, []string, string, error) {
 	bsonFilter := bson.M{}
 	for k, v := range filter {
 		bsonFilter[k] = v
 	}
 
-	return s.repo.List(ctx, bsonFilter)
+	var categories []string
+	if category, ok := filter["category"]; ok {
+		var err error
+		categories, err = s.categorySubtree(ctx, fmt.Sprint(category))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		bsonFilter["category"] = bson.M{"$in": categories}
+	}
+
+	var brandID string
+	if brand, ok := filter["brand"]; ok {
+		var err error
+		brandID, err = s.brandFilter(ctx, fmt.Sprint(brand))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		delete(bsonFilter, "brand")
+		bsonFilter["brand_id"] = brandID
+	}
+	return bsonFilter, categories, brandID, nil
+}
+
+// setCategory заменяет категорию товара ее slug в дереве
+func (s *productService) setCategory(ctx context.Context, product *model.Product) error {
+	if product.Category == "" {
+		return nil
+	}
+
+	tree, err := s.loadCategoryTree(ctx)
+	if err != nil {
+		return err
+	}
+	product.Category, err = s.resolveCategory(ctx, tree, product.Category, true)
+	return err
 }
 
 func (s *productService) SearchProducts(ctx context.Context, query string) ([]*model.Product, error) {
-	return s.repo.SearchProducts(ctx, query)
+	return s.cachedQuery(ctx, searchQueryKey(query), func() ([]*model.Product, error) {
+		return s.repo.SearchProducts(ctx, query)
+	}, searchTags)
 } 
\ No newline at end of file
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+)
+
+// Теги, которыми помечаются закэшированные результаты запросов
+const (
+	tagAllProducts = "list:all"
+	tagSearch      = "search"
+	// Списки по категории зависят от формы дерева
+	tagCategories = "categories"
+)
+
+func categoryTag(category string) string { return "category:" + category }
+func brandTag(brand string) string       { return "brand:" + brand }
+func productTag(id string) string        { return "product:" + id }
+
+// listQueryKey строит нормализованный ключ для фильтра ListProducts
+func listQueryKey(filter map[string]interface{}) string {
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, filter[k])
+	}
+	return "list?" + strings.Join(parts, "&")
+}
+
+// searchQueryKey строит ключ для поиска; $text регистронезависим, поэтому приводим к нижнему регистру
+func searchQueryKey(query string) string {
+	return "search?q=" + strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// listTags помечает список каждой категорией поддерева из фильтра и брендом
+func listTags(categories []string, brandID string, products []*model.Product) []string {
+	var tags []string
+	for _, category := range categories {
+		tags = append(tags, categoryTag(category))
+	}
+	if len(categories) > 0 {
+		tags = append(tags, tagCategories)
+	}
+	if brandID != "" {
+		tags = append(tags, brandTag(brandID))
+	}
+	if len(tags) == 0 {
+		tags = append(tags, tagAllProducts)
+	}
+	return append(tags, productTags(products)...)
+}
+
+func searchTags(products []*model.Product) []string {
+	return append([]string{tagSearch}, productTags(products)...)
+}
+
+func productTags(products []*model.Product) []string {
+	tags := make([]string, len(products))
+	for i, product := range products {
+		tags[i] = productTag(product.ID)
+	}
+	return tags
+}
+
+// cachedQuery отдает результат из кэша, а при промахе выполняет load один раз
+// для всех одновременных запросов с тем же ключом
+func (s *productService) cachedQuery(
+	ctx context.Context,
+	key string,
+	load func() ([]*model.Product, error),
+	tags func([]*model.Product) []string,
+) ([]*model.Product, error) {
+	if s.cache != nil {
+		if products, err := s.cache.GetQuery(ctx, key); err == nil && products != nil {
+			return products, nil
+		}
+	}
+
+	result, err, _ := s.group.Do(key, func() (interface{}, error) {
+		products, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		if s.cache != nil {
+			if err := s.cache.SetQuery(ctx, key, products, tags(products)); err != nil {
+				fmt.Printf("failed to cache query %s: %v\n", key, err)
+			}
+		}
+		return products, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*model.Product), nil
+}
+
+// StartCacheInvalidation подписывается на события товаров и сбрасывает
+// закэшированные списки и результаты поиска по тегам
+func (s *productService) StartCacheInvalidation(subscriber repository.EventSubscriber) error {
+	if s.cache == nil {
+		return nil
+	}
+
+	invalidate := func(tags ...string) {
+		if err := s.cache.InvalidateTags(context.Background(), tags...); err != nil {
+			fmt.Printf("failed to invalidate cached queries: %v\n", err)
+		}
+	}
+
+	err := subscriber.SubscribeProductCreated(func(product *model.Product) {
+		invalidate(categoryTag(product.Category), brandTag(product.BrandID), tagAllProducts, tagSearch)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to product.created: %w", err)
+	}
+
+	err = subscriber.SubscribeProductUpdated(func(product *model.Product) {
+		// Старые категория и бренд покрываются тегом товара
+		invalidate(productTag(product.ID), categoryTag(product.Category), brandTag(product.BrandID), tagSearch)
+		// Локальный кэш других экземпляров сервиса тоже должен забыть товар
+		s.cache.Delete(context.Background(), product.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to product.updated: %w", err)
+	}
+
+	err = subscriber.SubscribeProductDeleted(func(productID string) {
+		invalidate(productTag(productID))
+		s.cache.Delete(context.Background(), productID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to product.deleted: %w", err)
+	}
+
+	log.Println("Query cache invalidation started")
+	return nil
+}
+
+func (s *productService) CacheStats(ctx context.Context) []repository.LayerStats {
+	if s.cache == nil {
+		return nil
+	}
+	return s.cache.Stats()
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+)
+
+// StartRatingSync подписывается на пересчет рейтинга в review-service.
+// Событие несет агрегат целиком, поэтому его можно просто сохранить
+func (s *productService) StartRatingSync(subscriber repository.EventSubscriber) error {
+	err := subscriber.SubscribeReviewRatingChanged(func(event *model.ReviewRatingEvent) {
+		ctx := context.Background()
+		product, err := s.repo.SetRating(ctx, event.ProductID, event.Rating, event.Count)
+		if err != nil {
+			fmt.Printf("failed to update rating of product %s: %v\n", event.ProductID, err)
+			return
+		}
+		s.productChanged(ctx, product)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to review.rating_changed: %w", err)
+	}
+
+	log.Println("Product rating sync started")
+	return nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+)
+
+const (
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 50
+	// Сколько последних покупок пользователя берем для рекомендаций
+	purchaseHistoryLimit = 20
+	// Запас кандидатов на случай, если часть из них в архиве или закончилась
+	recommendationCandidates = 3
+	// Группа очереди order.created: покупки записывает один экземпляр сервиса
+	recommendationQueue = "product-service.recommendations"
+)
+
+// StartRecommendationSync подписывается на order.created и копит, какие
+// товары покупают вместе и что покупал каждый пользователь
+func (s *productService) StartRecommendationSync(subscriber repository.EventSubscriber) error {
+	if err := subscriber.QueueSubscribeOrderCreated(recommendationQueue, s.handleOrderRecommendations); err != nil {
+		return fmt.Errorf("failed to subscribe to order.created: %w", err)
+	}
+
+	log.Println("Recommendation sync started")
+	return nil
+}
+
+func (s *productService) handleOrderRecommendations(event *model.OrderCreatedEvent) {
+	// Разные размеры одного товара - одна покупка
+	productIDs := make([]string, 0, len(event.Items))
+	for _, item := range event.Items {
+		if !slices.Contains(productIDs, item.ProductID) {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	if err := s.recommendations.RecordOrder(context.Background(), event.UserID, productIDs, time.Now()); err != nil {
+		fmt.Printf("failed to record co-purchases of order %s: %v\n", event.ID, err)
+	}
+}
+
+// GetRecommendations рекомендует товары, которые покупали вместе с
+// productIDs, а без них - с прошлыми покупками пользователя. Недостающие
+// места заполняются самыми популярными товарами
+func (s *productService) GetRecommendations(ctx context.Context, productIDs []string, userID string, limit int) ([]*model.Recommendation, error) {
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit > maxRecommendationLimit {
+		limit = maxRecommendationLimit
+	}
+
+	seeds := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		if productID != "" && !slices.Contains(seeds, productID) {
+			seeds = append(seeds, productID)
+		}
+	}
+
+	// Уже купленное не рекомендуем
+	exclude := make(map[string]bool)
+	if userID != "" {
+		purchased, err := s.recommendations.Purchased(ctx, userID, purchaseHistoryLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get purchase history: %w", err)
+		}
+		for _, productID := range purchased {
+			exclude[productID] = true
+		}
+		if len(seeds) == 0 {
+			seeds = purchased
+		}
+	}
+	for _, productID := range seeds {
+		exclude[productID] = true
+	}
+
+	recommendations := []*model.Recommendation{}
+	if len(seeds) > 0 {
+		scores, err := s.recommendations.CoPurchased(ctx, seeds, (limit+len(exclude))*recommendationCandidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get co-purchases: %w", err)
+		}
+		recommendations, err = s.recommend(ctx, recommendations, scores, model.RecommendationCoPurchase, exclude, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(recommendations) < limit {
+		counts, err := s.repo.GetOrderCounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get popular products: %w", err)
+		}
+		popular := make([]repository.ProductScore, 0, len(counts))
+		for productID, count := range counts {
+			popular = append(popular, repository.ProductScore{ProductID: productID, Score: count})
+		}
+		sort.Slice(popular, func(i, j int) bool {
+			if popular[i].Score != popular[j].Score {
+				return popular[i].Score > popular[j].Score
+			}
+			return popular[i].ProductID < popular[j].ProductID
+		})
+		if n := (limit + len(exclude)) * recommendationCandidates; len(popular) > n {
+			popular = popular[:n]
+		}
+
+		recommendations, err = s.recommend(ctx, recommendations, popular, model.RecommendationPopular, exclude, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return recommendations, nil
+}
+
+// recommend добавляет к recommendations товары из scores в их порядке,
+// пропуская исключенные, архивные и закончившиеся, пока не наберется limit
+func (s *productService) recommend(ctx context.Context, recommendations []*model.Recommendation, scores []repository.ProductScore, reason model.RecommendationReason, exclude map[string]bool, limit int) ([]*model.Recommendation, error) {
+	ids := make([]string, 0, len(scores))
+	for _, score := range scores {
+		if !exclude[score.ProductID] {
+			ids = append(ids, score.ProductID)
+		}
+	}
+	if len(ids) == 0 {
+		return recommendations, nil
+	}
+
+	// List не возвращает архивные товары
+	products, err := s.repo.List(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommended products: %w", err)
+	}
+	byID := make(map[string]*model.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	for _, score := range scores {
+		if len(recommendations) >= limit {
+			break
+		}
+		product, ok := byID[score.ProductID]
+		if !ok || exclude[product.ID] || product.Stock <= 0 {
+			continue
+		}
+		exclude[product.ID] = true
+		recommendations = append(recommendations, &model.Recommendation{
+			Product: product,
+			Reason:  reason,
+			Score:   score.Score,
+		})
+	}
+	return recommendations, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"shoeshop/product-service/internal/model"
+	"shoeshop/product-service/internal/repository"
+)
+
+// stockQueue - группа очереди order.created для записи продаж и остатков:
+// при нескольких экземплярах сервиса заказ списывается один раз
+const stockQueue = "product-service.stock"
+
+const (
+	// Окно продаж для отчета о дозаказе
+	defaultSalesWindowDays = 30
+	maxSalesWindowDays     = 365
+)
+
+// StartStockSync подписывается на order.created: списывает проданный
+// остаток и учитывает продажи для подсказок и отчета о дозаказе
+func (s *productService) StartStockSync(subscriber repository.EventSubscriber) error {
+	if err := subscriber.QueueSubscribeOrderCreated(stockQueue, s.handleOrderStock); err != nil {
+		return fmt.Errorf("failed to subscribe to order.created: %w", err)
+	}
+
+	log.Println("Stock sync started")
+	return nil
+}
+
+func (s *productService) handleOrderStock(event *model.OrderCreatedEvent) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, item := range event.Items {
+		// order-service не принимает позиции без количества; событие из
+		// другого источника не должно вернуть товар на склад
+		if item.Quantity <= 0 {
+			fmt.Printf("skipping item of product %s with quantity %d in order %s\n", item.ProductID, item.Quantity, event.ID)
+			continue
+		}
+
+		if err := s.repo.RecordSale(ctx, item.ProductID, int64(item.Quantity), now); err != nil {
+			fmt.Printf("failed to record sale of product %s: %v\n", item.ProductID, err)
+		}
+		if err := s.repo.IncrementOrderCount(ctx, item.ProductID, int64(item.Quantity)); err != nil {
+			fmt.Printf("failed to increment order count for product %s: %v\n", item.ProductID, err)
+		}
+
+		product, oversold, err := s.repo.DecrementStock(ctx, item.ProductID, item.Quantity)
+		if err != nil {
+			fmt.Printf("failed to decrement stock of product %s: %v\n", item.ProductID, err)
+			continue
+		}
+		if oversold > 0 {
+			fmt.Printf("product %s oversold by %d in order %s\n", item.ProductID, oversold, event.ID)
+		}
+		s.productChanged(ctx, product)
+
+		// Остаток до списания восстанавливаем по списанному количеству
+		previous := *product
+		previous.Stock += item.Quantity - oversold
+		s.checkLowStock(previous.LowStock(), product)
+	}
+}
+
+// checkLowStock публикует product.low_stock, когда остаток товара дошел до
+// порога дозаказа; пока товар остается ниже порога, событие не повторяется
+func (s *productService) checkLowStock(wasLow bool, product *model.Product) {
+	if wasLow || !product.LowStock() || product.Archived() {
+		return
+	}
+
+	event := &model.LowStockEvent{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Stock:     product.Stock,
+		Threshold: product.ReorderThreshold,
+		At:        time.Now(),
+	}
+	if err := s.publisher.PublishLowStock(event); err != nil {
+		fmt.Printf("failed to publish low stock event: %v\n", err)
+	}
+}
+
+// GetReorderReport предлагает, сколько дозаказать, по средним продажам за
+// последние days дней: остатка должно хватить на coverDays дней продаж и
+// еще останется запас до порога дозаказа
+func (s *productService) GetReorderReport(ctx context.Context, days, coverDays int) (*model.ReorderReport, error) {
+	if days <= 0 {
+		days = defaultSalesWindowDays
+	}
+	if days > maxSalesWindowDays {
+		days = maxSalesWindowDays
+	}
+	if coverDays <= 0 {
+		coverDays = days
+	}
+	if coverDays > maxSalesWindowDays {
+		coverDays = maxSalesWindowDays
+	}
+
+	products, err := s.repo.List(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder report: %w", err)
+	}
+	sales, err := s.repo.SalesSince(ctx, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reorder report: %w", err)
+	}
+
+	report := &model.ReorderReport{Days: days, CoverDays: coverDays}
+	for _, product := range products {
+		sold := sales[product.ID]
+		velocity := float64(sold) / float64(days)
+
+		target := int64(math.Ceil(velocity*float64(coverDays))) + int64(product.ReorderThreshold)
+		suggested := max(target-int64(product.Stock), 0)
+		if suggested == 0 && !product.LowStock() {
+			continue
+		}
+
+		daysOfStock := -1.0
+		if velocity > 0 {
+			daysOfStock = math.Max(float64(product.Stock), 0) / velocity
+		}
+		report.Suggestions = append(report.Suggestions, &model.ReorderSuggestion{
+			Product:           product,
+			UnitsSold:         sold,
+			DailyVelocity:     velocity,
+			DaysOfStock:       daysOfStock,
+			SuggestedQuantity: int32(min(suggested, math.MaxInt32)),
+		})
+	}
+
+	// Сначала то, что кончится раньше; товары без продаж - в конце
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		a, b := report.Suggestions[i].DaysOfStock, report.Suggestions[j].DaysOfStock
+		if (a < 0) != (b < 0) {
+			return b < 0
+		}
+		return a < b
+	})
+
+	return report, nil
+}
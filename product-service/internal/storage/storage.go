@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage - хранилище файлов изображений. Реализация для S3 или CDN
+// должна только сохранять файл и отдавать его публичный URL
+type Storage interface {
+	// Save сохраняет файл под именем name и возвращает его публичный URL
+	Save(ctx context.Context, name string, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage хранит файлы в каталоге dir; URL строятся от baseURL,
+// по которому этот каталог раздается через Handler
+func NewLocalStorage(dir, baseURL string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &localStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *localStorage) Save(ctx context.Context, name string, contentType string, data []byte) (string, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	// Имена строятся из хэша содержимого: существующий файл уже тот же самый
+	if _, err := os.Stat(path); err == nil {
+		return s.url(name), nil
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не отдать недописанный файл
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return s.url(name), nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStorage) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+func (s *localStorage) url(name string) string {
+	return s.baseURL + "/" + name
+}
+
+// Handler раздает файлы из каталога dir без листинга каталогов
+func Handler(dir string) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Временные файлы загрузки начинаются с точки и наружу не отдаются
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") || strings.Contains(r.URL.Path, "/.") {
+			http.NotFound(w, r)
+			return
+		}
+		// Имена файлов содержат хэш, поэтому содержимое по URL никогда не меняется
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
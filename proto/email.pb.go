@@ -66,8 +66,12 @@ func (x *UserEmailRequest) GetUser() *User {
 }
 
 type OrderEmailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Files attached to the email, e.g. the invoice PDF
+	Attachments []*Attachment `protobuf:"bytes,2,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Customer mailbox the email is sent to
+	Recipient     string `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,6 +113,80 @@ func (x *OrderEmailRequest) GetOrder() *Order {
 	return nil
 }
 
+func (x *OrderEmailRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *OrderEmailRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_email_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
 type PasswordResetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -119,7 +197,7 @@ type PasswordResetRequest struct {
 
 func (x *PasswordResetRequest) Reset() {
 	*x = PasswordResetRequest{}
-	mi := &file_email_proto_msgTypes[2]
+	mi := &file_email_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -131,7 +209,7 @@ func (x *PasswordResetRequest) String() string {
 func (*PasswordResetRequest) ProtoMessage() {}
 
 func (x *PasswordResetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[2]
+	mi := &file_email_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +222,7 @@ func (x *PasswordResetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PasswordResetRequest.ProtoReflect.Descriptor instead.
 func (*PasswordResetRequest) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{2}
+	return file_email_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PasswordResetRequest) GetUser() *User {
@@ -170,7 +248,7 @@ type EmailResponse struct {
 
 func (x *EmailResponse) Reset() {
 	*x = EmailResponse{}
-	mi := &file_email_proto_msgTypes[3]
+	mi := &file_email_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -182,7 +260,7 @@ func (x *EmailResponse) String() string {
 func (*EmailResponse) ProtoMessage() {}
 
 func (x *EmailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[3]
+	mi := &file_email_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +273,7 @@ func (x *EmailResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmailResponse.ProtoReflect.Descriptor instead.
 func (*EmailResponse) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{3}
+	return file_email_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *EmailResponse) GetSuccess() bool {
@@ -212,9 +290,16 @@ const file_email_proto_rawDesc = "" +
 	"\vemail.proto\x12\x05proto\x1a\n" +
 	"user.proto\x1a\vorder.proto\"3\n" +
 	"\x10UserEmailRequest\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\"7\n" +
+	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\"\x8a\x01\n" +
 	"\x11OrderEmailRequest\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"X\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\x123\n" +
+	"\vattachments\x18\x02 \x03(\v2\x11.proto.AttachmentR\vattachments\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\"e\n" +
+	"\n" +
+	"Attachment\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"X\n" +
 	"\x14PasswordResetRequest\x12\x1f\n" +
 	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\x12\x1f\n" +
 	"\vreset_token\x18\x02 \x01(\tR\n" +
@@ -239,32 +324,34 @@ func file_email_proto_rawDescGZIP() []byte {
 	return file_email_proto_rawDescData
 }
 
-var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_email_proto_goTypes = []any{
 	(*UserEmailRequest)(nil),     // 0: proto.UserEmailRequest
 	(*OrderEmailRequest)(nil),    // 1: proto.OrderEmailRequest
-	(*PasswordResetRequest)(nil), // 2: proto.PasswordResetRequest
-	(*EmailResponse)(nil),        // 3: proto.EmailResponse
-	(*User)(nil),                 // 4: proto.User
-	(*Order)(nil),                // 5: proto.Order
+	(*Attachment)(nil),           // 2: proto.Attachment
+	(*PasswordResetRequest)(nil), // 3: proto.PasswordResetRequest
+	(*EmailResponse)(nil),        // 4: proto.EmailResponse
+	(*User)(nil),                 // 5: proto.User
+	(*Order)(nil),                // 6: proto.Order
 }
 var file_email_proto_depIdxs = []int32{
-	4, // 0: proto.UserEmailRequest.user:type_name -> proto.User
-	5, // 1: proto.OrderEmailRequest.order:type_name -> proto.Order
-	4, // 2: proto.PasswordResetRequest.user:type_name -> proto.User
-	0, // 3: proto.EmailService.SendRegistrationConfirmation:input_type -> proto.UserEmailRequest
-	1, // 4: proto.EmailService.SendOrderConfirmation:input_type -> proto.OrderEmailRequest
-	1, // 5: proto.EmailService.SendOrderStatusUpdate:input_type -> proto.OrderEmailRequest
-	2, // 6: proto.EmailService.SendPasswordReset:input_type -> proto.PasswordResetRequest
-	3, // 7: proto.EmailService.SendRegistrationConfirmation:output_type -> proto.EmailResponse
-	3, // 8: proto.EmailService.SendOrderConfirmation:output_type -> proto.EmailResponse
-	3, // 9: proto.EmailService.SendOrderStatusUpdate:output_type -> proto.EmailResponse
-	3, // 10: proto.EmailService.SendPasswordReset:output_type -> proto.EmailResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: proto.UserEmailRequest.user:type_name -> proto.User
+	6, // 1: proto.OrderEmailRequest.order:type_name -> proto.Order
+	2, // 2: proto.OrderEmailRequest.attachments:type_name -> proto.Attachment
+	5, // 3: proto.PasswordResetRequest.user:type_name -> proto.User
+	0, // 4: proto.EmailService.SendRegistrationConfirmation:input_type -> proto.UserEmailRequest
+	1, // 5: proto.EmailService.SendOrderConfirmation:input_type -> proto.OrderEmailRequest
+	1, // 6: proto.EmailService.SendOrderStatusUpdate:input_type -> proto.OrderEmailRequest
+	3, // 7: proto.EmailService.SendPasswordReset:input_type -> proto.PasswordResetRequest
+	4, // 8: proto.EmailService.SendRegistrationConfirmation:output_type -> proto.EmailResponse
+	4, // 9: proto.EmailService.SendOrderConfirmation:output_type -> proto.EmailResponse
+	4, // 10: proto.EmailService.SendOrderStatusUpdate:output_type -> proto.EmailResponse
+	4, // 11: proto.EmailService.SendPasswordReset:output_type -> proto.EmailResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_email_proto_init() }
@@ -280,7 +367,7 @@ func file_email_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_email_proto_rawDesc), len(file_email_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
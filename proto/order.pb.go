@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,18 +22,100 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// A promotion or coupon applied to an order line and the amount it took off the line
+type AppliedPromotion struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Amount      float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Set instead of promotion_id when the discount comes from a coupon
+	CouponCode    string `protobuf:"bytes,4,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedPromotion) Reset() {
+	*x = AppliedPromotion{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedPromotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedPromotion) ProtoMessage() {}
+
+func (x *AppliedPromotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedPromotion.ProtoReflect.Descriptor instead.
+func (*AppliedPromotion) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AppliedPromotion) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AppliedPromotion) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
 type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ProductId    string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity     int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price        float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Size         string                 `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	Returned     bool                   `protobuf:"varint,5,opt,name=returned,proto3" json:"returned,omitempty"`
+	ReturnReason string                 `protobuf:"bytes,6,opt,name=return_reason,json=returnReason,proto3" json:"return_reason,omitempty"`
+	// Fit feedback given with the return: RUNS_SMALL, TRUE_TO_SIZE or RUNS_LARGE
+	Fit string `protobuf:"bytes,7,opt,name=fit,proto3" json:"fit,omitempty"`
+	// Total discount of the line; price stays the list price of one unit
+	Discount   float64             `protobuf:"fixed64,8,opt,name=discount,proto3" json:"discount,omitempty"`
+	Promotions []*AppliedPromotion `protobuf:"bytes,9,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	// Tax of the line after discounts, read-only
+	Tax float64 `protobuf:"fixed64,10,opt,name=tax,proto3" json:"tax,omitempty"`
+	// Tax rate applied to the line, e.g. 0.2 for 20%
+	TaxRate       float64 `protobuf:"fixed64,11,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +127,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +140,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+	return file_order_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *OrderItem) GetProductId() string {
@@ -81,37 +164,2214 @@ func (x *OrderItem) GetPrice() float64 {
 	return 0
 }
 
-type Order struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items           []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	TotalAmount     float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	ShippingAddress string                 `protobuf:"bytes,6,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt       string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	PaymentMethod   string                 `protobuf:"bytes,9,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	PaymentId       string                 `protobuf:"bytes,10,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *OrderItem) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *OrderItem) GetReturned() bool {
+	if x != nil {
+		return x.Returned
+	}
+	return false
+}
+
+func (x *OrderItem) GetReturnReason() string {
+	if x != nil {
+		return x.ReturnReason
+	}
+	return ""
+}
+
+func (x *OrderItem) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *OrderItem) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPromotions() []*AppliedPromotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *OrderItem) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *OrderItem) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+type Order struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	TotalAmount     float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ShippingAddress string                 `protobuf:"bytes,6,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,9,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	PaymentId       string                 `protobuf:"bytes,10,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	// Size system of item sizes: EU (default), US_M, US_W, UK or CM
+	SizeSystem string `protobuf:"bytes,11,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	// total_amount = subtotal - discount_total + shipping_cost + tax_total,
+	// without tax_total when tax_inclusive
+	Subtotal      float64 `protobuf:"fixed64,12,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	DiscountTotal float64 `protobuf:"fixed64,13,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"`
+	// Coupon to redeem on creation; kept on the order once applied
+	CouponCode string `protobuf:"bytes,14,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	// Incremented on every write. Updates must send the version they read and
+	// only succeed if the order is still at that version
+	Version int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	// ISO 3166-1 alpha-2 code; the shop's home country when empty
+	ShippingCountry string `protobuf:"bytes,16,opt,name=shipping_country,json=shippingCountry,proto3" json:"shipping_country,omitempty"`
+	// Method code from QuoteShipping; the cheapest method when empty
+	ShippingMethod string `protobuf:"bytes,17,opt,name=shipping_method,json=shippingMethod,proto3" json:"shipping_method,omitempty"`
+	// Calculated from the items and the method, read-only
+	ShippingCost float64 `protobuf:"fixed64,18,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	// Packages sent with CreateShipment, read-only
+	Shipments []*Shipment `protobuf:"bytes,19,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	// State or province code without the country, e.g. CA or ON; decides
+	// the tax rates together with shipping_country
+	ShippingRegion string `protobuf:"bytes,20,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	// Tax of the items and of shipping, read-only
+	TaxTotal float64 `protobuf:"fixed64,21,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	// Prices already include tax_total, so it is not added to total_amount
+	TaxInclusive bool `protobuf:"varint,22,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
+	// Part of tax_total charged on shipping_cost, read-only
+	ShippingTax   float64 `protobuf:"fixed64,23,opt,name=shipping_tax,json=shippingTax,proto3" json:"shipping_tax,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetShippingAddress() string {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Order) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *Order) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Order) GetDiscountTotal() float64 {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return 0
+}
+
+func (x *Order) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetShippingCountry() string {
+	if x != nil {
+		return x.ShippingCountry
+	}
+	return ""
+}
+
+func (x *Order) GetShippingMethod() string {
+	if x != nil {
+		return x.ShippingMethod
+	}
+	return ""
+}
+
+func (x *Order) GetShippingCost() float64 {
+	if x != nil {
+		return x.ShippingCost
+	}
+	return 0
+}
+
+func (x *Order) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *Order) GetShippingRegion() string {
+	if x != nil {
+		return x.ShippingRegion
+	}
+	return ""
+}
+
+func (x *Order) GetTaxTotal() float64 {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return 0
+}
+
+func (x *Order) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *Order) GetShippingTax() float64 {
+	if x != nil {
+		return x.ShippingTax
+	}
+	return 0
+}
+
+type ShipmentItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// In the size system of the request, or of the order when it has none
+	Size          string `protobuf:"bytes,2,opt,name=size,proto3" json:"size,omitempty"`
+	Quantity      int32  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShipmentItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ShipmentItem) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *ShipmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ShipmentEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// LABEL_CREATED, IN_TRANSIT, OUT_FOR_DELIVERY, DELIVERED or EXCEPTION
+	Status      string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Location    string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// RFC 3339; the time the event was received when empty
+	OccurredAt    string `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentEvent) Reset() {
+	*x = ShipmentEvent{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentEvent) ProtoMessage() {}
+
+func (x *ShipmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentEvent.ProtoReflect.Descriptor instead.
+func (*ShipmentEvent) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ShipmentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+type Shipment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Carrier code, e.g. UPS or FEDEX
+	Carrier        string          `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string          `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Items          []*ShipmentItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// Status of the latest event
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// Oldest first
+	Events        []*ShipmentEvent `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	CreatedAt     string           `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt   string           `protobuf:"bytes,8,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetEvents() []*ShipmentEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Shipment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Shipment) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+type CreateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Overrides the size system the order was placed in
+	SizeSystem    string `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type UpdateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Fields to update, by proto field name. Without a mask every field
+	// is overwritten
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *UpdateOrderRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type OrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderResponse) Reset() {
+	*x = OrderResponse{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderResponse) ProtoMessage() {}
+
+func (x *OrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
+func (*OrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type UpdateOrderStatusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Expected order version, required
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateOrderStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type VerifyPurchaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPurchaseRequest) Reset() {
+	*x = VerifyPurchaseRequest{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPurchaseRequest) ProtoMessage() {}
+
+func (x *VerifyPurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPurchaseRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VerifyPurchaseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyPurchaseRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type VerifyPurchaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPurchaseResponse) Reset() {
+	*x = VerifyPurchaseResponse{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPurchaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPurchaseResponse) ProtoMessage() {}
+
+func (x *VerifyPurchaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPurchaseResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPurchaseResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VerifyPurchaseResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyPurchaseResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ReferencedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferencedProductsRequest) Reset() {
+	*x = ReferencedProductsRequest{}
+	mi := &file_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferencedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferencedProductsRequest) ProtoMessage() {}
+
+func (x *ReferencedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferencedProductsRequest.ProtoReflect.Descriptor instead.
+func (*ReferencedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReferencedProductsRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type ReferencedProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferencedProductsResponse) Reset() {
+	*x = ReferencedProductsResponse{}
+	mi := &file_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferencedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferencedProductsResponse) ProtoMessage() {}
+
+func (x *ReferencedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferencedProductsResponse.ProtoReflect.Descriptor instead.
+func (*ReferencedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReferencedProductsResponse) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type ReturnOrderItemRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OrderId   string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Distinguishes items of the same product bought in several sizes
+	Size          string `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Fit           string `protobuf:"bytes,5,opt,name=fit,proto3" json:"fit,omitempty"`
+	SizeSystem    string `protobuf:"bytes,6,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnOrderItemRequest) Reset() {
+	*x = ReturnOrderItemRequest{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnOrderItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnOrderItemRequest) ProtoMessage() {}
+
+func (x *ReturnOrderItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnOrderItemRequest.ProtoReflect.Descriptor instead.
+func (*ReturnOrderItemRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReturnOrderItemRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReturnOrderItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReturnOrderItemRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *ReturnOrderItemRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReturnOrderItemRequest) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *ReturnOrderItemRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type QuoteOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteOrderRequest) Reset() {
+	*x = QuoteOrderRequest{}
+	mi := &file_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteOrderRequest) ProtoMessage() {}
+
+func (x *QuoteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteOrderRequest.ProtoReflect.Descriptor instead.
+func (*QuoteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *QuoteOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type QuoteShippingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Items, shipping_country and coupon_code are used; free shipping
+	// thresholds apply to the total after discounts
+	Order         *Order `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRequest) Reset() {
+	*x = QuoteShippingRequest{}
+	mi := &file_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRequest) ProtoMessage() {}
+
+func (x *QuoteShippingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRequest.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QuoteShippingRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ShippingOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Zone          string                 `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`
+	Cost          float64                `protobuf:"fixed64,4,opt,name=cost,proto3" json:"cost,omitempty"`
+	MinDays       int32                  `protobuf:"varint,5,opt,name=min_days,json=minDays,proto3" json:"min_days,omitempty"`
+	MaxDays       int32                  `protobuf:"varint,6,opt,name=max_days,json=maxDays,proto3" json:"max_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingOption) Reset() {
+	*x = ShippingOption{}
+	mi := &file_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingOption) ProtoMessage() {}
+
+func (x *ShippingOption) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingOption.ProtoReflect.Descriptor instead.
+func (*ShippingOption) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ShippingOption) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ShippingOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingOption) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *ShippingOption) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *ShippingOption) GetMinDays() int32 {
+	if x != nil {
+		return x.MinDays
+	}
+	return 0
+}
+
+func (x *ShippingOption) GetMaxDays() int32 {
+	if x != nil {
+		return x.MaxDays
+	}
+	return 0
+}
+
+type QuoteShippingResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ShippingCountry string                 `protobuf:"bytes,1,opt,name=shipping_country,json=shippingCountry,proto3" json:"shipping_country,omitempty"`
+	// Cheapest first
+	Options       []*ShippingOption `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteShippingResponse) Reset() {
+	*x = QuoteShippingResponse{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingResponse) ProtoMessage() {}
+
+func (x *QuoteShippingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingResponse.ProtoReflect.Descriptor instead.
+func (*QuoteShippingResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QuoteShippingResponse) GetShippingCountry() string {
+	if x != nil {
+		return x.ShippingCountry
+	}
+	return ""
+}
+
+func (x *QuoteShippingResponse) GetOptions() []*ShippingOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CreateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	// Every item not shipped yet when empty
+	Items []*ShipmentItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// Size system of item sizes; the order's size system when empty
+	SizeSystem    string `protobuf:"bytes,5,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type RecordShipmentEventRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Carrier        string                 `protobuf:"bytes,1,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,2,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Event          *ShipmentEvent         `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecordShipmentEventRequest) Reset() {
+	*x = RecordShipmentEventRequest{}
+	mi := &file_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordShipmentEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordShipmentEventRequest) ProtoMessage() {}
+
+func (x *RecordShipmentEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordShipmentEventRequest.ProtoReflect.Descriptor instead.
+func (*RecordShipmentEventRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RecordShipmentEventRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *RecordShipmentEventRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *RecordShipmentEventRequest) GetEvent() *ShipmentEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetInvoiceRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type InvoiceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequential per year, e.g. INV-2026-000042
+	Number        string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	IssuedAt      string `protobuf:"bytes,2,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	Filename      string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Pdf           []byte `protobuf:"bytes,4,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceResponse) Reset() {
+	*x = InvoiceResponse{}
+	mi := &file_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceResponse) ProtoMessage() {}
+
+func (x *InvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceResponse.ProtoReflect.Descriptor instead.
+func (*InvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InvoiceResponse) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *InvoiceResponse) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
+}
+
+func (x *InvoiceResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *InvoiceResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+type Promotion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional campaign label grouping promotions of one sale
+	Campaign string `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// PERCENTAGE, FIXED or BUY_X_GET_Y
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// PERCENTAGE: percent off; FIXED: amount off each unit; unused for BUY_X_GET_Y
+	Value float64 `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	// BUY_X_GET_Y: for every buy_quantity + get_quantity units the get_quantity cheapest are free
+	BuyQuantity int32 `protobuf:"varint,6,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity int32 `protobuf:"varint,7,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	// Eligible products; all lists empty means a store-wide promotion
+	ProductIds []string `protobuf:"bytes,8,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Brands     []string `protobuf:"bytes,9,rep,name=brands,proto3" json:"brands,omitempty"`
+	// Category slugs; products of subcategories are eligible too
+	Categories []string `protobuf:"bytes,10,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Higher priority promotions are applied first
+	Priority int32 `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	// STACKABLE (default) combines with other promotions, EXCLUSIVE is the only one on a line
+	Stacking string `protobuf:"bytes,12,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Active   bool   `protobuf:"varint,13,opt,name=active,proto3" json:"active,omitempty"`
+	// RFC3339 bounds of a time-boxed campaign; empty means unbounded
+	StartsAt      string `protobuf:"bytes,14,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        string `protobuf:"bytes,15,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Promotion) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *Promotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Promotion) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Promotion) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *Promotion) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Promotion) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Promotion) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetEndsAt() string {
+	if x != nil {
+		return x.EndsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type UpdatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePromotionRequest) Reset() {
+	*x = UpdatePromotionRequest{}
+	mi := &file_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePromotionRequest) ProtoMessage() {}
+
+func (x *UpdatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type PromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromotionResponse) Reset() {
+	*x = PromotionResponse{}
+	mi := &file_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionResponse) ProtoMessage() {}
+
+func (x *PromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromotionResponse.ProtoReflect.Descriptor instead.
+func (*PromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type DeletePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionRequest) Reset() {
+	*x = DeletePromotionRequest{}
+	mi := &file_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionRequest) ProtoMessage() {}
+
+func (x *DeletePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeletePromotionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionResponse) Reset() {
+	*x = DeletePromotionResponse{}
+	mi := &file_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionResponse) ProtoMessage() {}
+
+func (x *DeletePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionResponse.ProtoReflect.Descriptor instead.
+func (*DeletePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeletePromotionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListPromotionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only promotions that apply right now
+	ActiveOnly    bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPromotionsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type Coupon struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive, stored upper-case
+	Code        string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// PERCENTAGE off eligible items or FIXED amount off the eligible subtotal
+	Type  string  `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Order total after promotions must reach this amount
+	MinOrderAmount float64 `protobuf:"fixed64,5,opt,name=min_order_amount,json=minOrderAmount,proto3" json:"min_order_amount,omitempty"`
+	// Eligible items; both lists empty means every item
+	Brands []string `protobuf:"bytes,6,rep,name=brands,proto3" json:"brands,omitempty"`
+	// Category slugs; products of subcategories are eligible too
+	Categories []string `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Zero means unlimited
+	UsageLimit   int32 `protobuf:"varint,8,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	PerUserLimit int32 `protobuf:"varint,9,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	UsedCount    int32 `protobuf:"varint,10,opt,name=used_count,json=usedCount,proto3" json:"used_count,omitempty"`
+	Active       bool  `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	// RFC3339; empty means the coupon does not expire
+	ExpiresAt     string `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_order_proto_msgTypes[1]
+func (x *Coupon) Reset() {
+	*x = Coupon{}
+	mi := &file_order_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *Coupon) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*Coupon) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[1]
+func (x *Coupon) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,103 +2382,131 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
+func (*Coupon) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *Order) GetId() string {
+func (x *Coupon) GetCode() string {
 	if x != nil {
-		return x.Id
+		return x.Code
 	}
 	return ""
 }
 
-func (x *Order) GetUserId() string {
+func (x *Coupon) GetDescription() string {
 	if x != nil {
-		return x.UserId
+		return x.Description
 	}
 	return ""
 }
 
-func (x *Order) GetItems() []*OrderItem {
+func (x *Coupon) GetType() string {
 	if x != nil {
-		return x.Items
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Coupon) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Coupon) GetMinOrderAmount() float64 {
+	if x != nil {
+		return x.MinOrderAmount
+	}
+	return 0
+}
+
+func (x *Coupon) GetBrands() []string {
+	if x != nil {
+		return x.Brands
 	}
 	return nil
 }
 
-func (x *Order) GetTotalAmount() float64 {
+func (x *Coupon) GetCategories() []string {
 	if x != nil {
-		return x.TotalAmount
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Coupon) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
 	}
 	return 0
 }
 
-func (x *Order) GetStatus() string {
+func (x *Coupon) GetPerUserLimit() int32 {
 	if x != nil {
-		return x.Status
+		return x.PerUserLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *Order) GetShippingAddress() string {
+func (x *Coupon) GetUsedCount() int32 {
 	if x != nil {
-		return x.ShippingAddress
+		return x.UsedCount
 	}
-	return ""
+	return 0
 }
 
-func (x *Order) GetCreatedAt() string {
+func (x *Coupon) GetActive() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Active
 	}
-	return ""
+	return false
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *Coupon) GetExpiresAt() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ExpiresAt
 	}
 	return ""
 }
 
-func (x *Order) GetPaymentMethod() string {
+func (x *Coupon) GetCreatedAt() string {
 	if x != nil {
-		return x.PaymentMethod
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *Order) GetPaymentId() string {
+func (x *Coupon) GetUpdatedAt() string {
 	if x != nil {
-		return x.PaymentId
+		return x.UpdatedAt
 	}
 	return ""
 }
 
-type CreateOrderRequest struct {
+type CreateCouponRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_order_proto_msgTypes[2]
+func (x *CreateCouponRequest) Reset() {
+	*x = CreateCouponRequest{}
+	mi := &file_order_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *CreateCouponRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*CreateCouponRequest) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+func (x *CreateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,40 +2517,40 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateCouponRequest.ProtoReflect.Descriptor instead.
+func (*CreateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *CreateOrderRequest) GetOrder() *Order {
+func (x *CreateCouponRequest) GetCoupon() *Coupon {
 	if x != nil {
-		return x.Order
+		return x.Coupon
 	}
 	return nil
 }
 
-type GetOrderRequest struct {
+type UpdateCouponRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
+func (x *UpdateCouponRequest) Reset() {
+	*x = UpdateCouponRequest{}
+	mi := &file_order_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *UpdateCouponRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*UpdateCouponRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+func (x *UpdateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,40 +2561,40 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UpdateCouponRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetOrderRequest) GetId() string {
+func (x *UpdateCouponRequest) GetCoupon() *Coupon {
 	if x != nil {
-		return x.Id
+		return x.Coupon
 	}
-	return ""
+	return nil
 }
 
-type UpdateOrderRequest struct {
+type CouponResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderRequest) Reset() {
-	*x = UpdateOrderRequest{}
-	mi := &file_order_proto_msgTypes[4]
+func (x *CouponResponse) Reset() {
+	*x = CouponResponse{}
+	mi := &file_order_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderRequest) String() string {
+func (x *CouponResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderRequest) ProtoMessage() {}
+func (*CouponResponse) ProtoMessage() {}
 
-func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+func (x *CouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,40 +2605,41 @@ func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CouponResponse.ProtoReflect.Descriptor instead.
+func (*CouponResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UpdateOrderRequest) GetOrder() *Order {
+func (x *CouponResponse) GetCoupon() *Coupon {
 	if x != nil {
-		return x.Order
+		return x.Coupon
 	}
 	return nil
 }
 
-type OrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+type ListCouponsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only active coupons that have not expired
+	ActiveOnly    bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderResponse) Reset() {
-	*x = OrderResponse{}
-	mi := &file_order_proto_msgTypes[5]
+func (x *ListCouponsRequest) Reset() {
+	*x = ListCouponsRequest{}
+	mi := &file_order_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderResponse) String() string {
+func (x *ListCouponsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderResponse) ProtoMessage() {}
+func (*ListCouponsRequest) ProtoMessage() {}
 
-func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+func (x *ListCouponsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,40 +2650,40 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
-func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListCouponsRequest.ProtoReflect.Descriptor instead.
+func (*ListCouponsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *OrderResponse) GetOrder() *Order {
+func (x *ListCouponsRequest) GetActiveOnly() bool {
 	if x != nil {
-		return x.Order
+		return x.ActiveOnly
 	}
-	return nil
+	return false
 }
 
-type ListOrdersRequest struct {
+type ListCouponsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Coupons       []*Coupon              `protobuf:"bytes,1,rep,name=coupons,proto3" json:"coupons,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_order_proto_msgTypes[6]
+func (x *ListCouponsResponse) Reset() {
+	*x = ListCouponsResponse{}
+	mi := &file_order_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *ListCouponsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*ListCouponsResponse) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+func (x *ListCouponsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,40 +2694,42 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListCouponsResponse.ProtoReflect.Descriptor instead.
+func (*ListCouponsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ListOrdersRequest) GetUserId() string {
+func (x *ListCouponsResponse) GetCoupons() []*Coupon {
 	if x != nil {
-		return x.UserId
+		return x.Coupons
 	}
-	return ""
+	return nil
 }
 
-type ListOrdersResponse struct {
+type GetCouponReportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_order_proto_msgTypes[7]
+func (x *GetCouponReportRequest) Reset() {
+	*x = GetCouponReportRequest{}
+	mi := &file_order_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *GetCouponReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*GetCouponReportRequest) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+func (x *GetCouponReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,41 +2740,57 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetCouponReportRequest.ProtoReflect.Descriptor instead.
+func (*GetCouponReportRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ListOrdersResponse) GetOrders() []*Order {
+func (x *GetCouponReportRequest) GetCode() string {
 	if x != nil {
-		return x.Orders
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-type UpdateOrderStatusRequest struct {
+func (x *GetCouponReportRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetCouponReportRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CouponRedemption struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Discount      float64                `protobuf:"fixed64,3,opt,name=discount,proto3" json:"discount,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusRequest) Reset() {
-	*x = UpdateOrderStatusRequest{}
-	mi := &file_order_proto_msgTypes[8]
+func (x *CouponRedemption) Reset() {
+	*x = CouponRedemption{}
+	mi := &file_order_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusRequest) String() string {
+func (x *CouponRedemption) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusRequest) ProtoMessage() {}
+func (*CouponRedemption) ProtoMessage() {}
 
-func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[8]
+func (x *CouponRedemption) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -494,47 +2801,66 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CouponRedemption.ProtoReflect.Descriptor instead.
+func (*CouponRedemption) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UpdateOrderStatusRequest) GetId() string {
+func (x *CouponRedemption) GetOrderId() string {
 	if x != nil {
-		return x.Id
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *UpdateOrderStatusRequest) GetStatus() string {
+func (x *CouponRedemption) GetUserId() string {
 	if x != nil {
-		return x.Status
+		return x.UserId
 	}
 	return ""
 }
 
-type UpdateOrderStatusResponse struct {
+func (x *CouponRedemption) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *CouponRedemption) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CouponReport struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Redemptions   int64                  `protobuf:"varint,2,opt,name=redemptions,proto3" json:"redemptions,omitempty"`
+	UniqueUsers   int64                  `protobuf:"varint,3,opt,name=unique_users,json=uniqueUsers,proto3" json:"unique_users,omitempty"`
+	TotalDiscount float64                `protobuf:"fixed64,4,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"`
+	// Latest redemptions first
+	Recent        []*CouponRedemption `protobuf:"bytes,5,rep,name=recent,proto3" json:"recent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusResponse) Reset() {
-	*x = UpdateOrderStatusResponse{}
-	mi := &file_order_proto_msgTypes[9]
+func (x *CouponReport) Reset() {
+	*x = CouponReport{}
+	mi := &file_order_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusResponse) String() string {
+func (x *CouponReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusResponse) ProtoMessage() {}
+func (*CouponReport) ProtoMessage() {}
 
-func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[9]
+func (x *CouponReport) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,28 +2871,73 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CouponReport.ProtoReflect.Descriptor instead.
+func (*CouponReport) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *UpdateOrderStatusResponse) GetSuccess() bool {
+func (x *CouponReport) GetCoupon() *Coupon {
 	if x != nil {
-		return x.Success
+		return x.Coupon
 	}
-	return false
+	return nil
+}
+
+func (x *CouponReport) GetRedemptions() int64 {
+	if x != nil {
+		return x.Redemptions
+	}
+	return 0
+}
+
+func (x *CouponReport) GetUniqueUsers() int64 {
+	if x != nil {
+		return x.UniqueUsers
+	}
+	return 0
+}
+
+func (x *CouponReport) GetTotalDiscount() float64 {
+	if x != nil {
+		return x.TotalDiscount
+	}
+	return 0
+}
+
+func (x *CouponReport) GetRecent() []*CouponRedemption {
+	if x != nil {
+		return x.Recent
+	}
+	return nil
 }
 
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05proto\"\\\n" +
+	"\vorder.proto\x12\x05proto\x1a google/protobuf/field_mask.proto\"\x82\x01\n" +
+	"\x10AppliedPromotion\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1f\n" +
+	"\vcoupon_code\x18\x04 \x01(\tR\n" +
+	"couponCode\"\xc5\x02\n" +
 	"\tOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"\xc2\x02\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\x1a\n" +
+	"\breturned\x18\x05 \x01(\bR\breturned\x12#\n" +
+	"\rreturn_reason\x18\x06 \x01(\tR\freturnReason\x12\x10\n" +
+	"\x03fit\x18\a \x01(\tR\x03fit\x12\x1a\n" +
+	"\bdiscount\x18\b \x01(\x01R\bdiscount\x127\n" +
+	"\n" +
+	"promotions\x18\t \x03(\v2\x17.proto.AppliedPromotionR\n" +
+	"promotions\x12\x10\n" +
+	"\x03tax\x18\n" +
+	" \x01(\x01R\x03tax\x12\x19\n" +
+	"\btax_rate\x18\v \x01(\x01R\ataxRate\"\x97\x06\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
@@ -581,31 +2952,237 @@ const file_order_proto_rawDesc = "" +
 	"\x0epayment_method\x18\t \x01(\tR\rpaymentMethod\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\n" +
-	" \x01(\tR\tpaymentId\"8\n" +
+	" \x01(\tR\tpaymentId\x12\x1f\n" +
+	"\vsize_system\x18\v \x01(\tR\n" +
+	"sizeSystem\x12\x1a\n" +
+	"\bsubtotal\x18\f \x01(\x01R\bsubtotal\x12%\n" +
+	"\x0ediscount_total\x18\r \x01(\x01R\rdiscountTotal\x12\x1f\n" +
+	"\vcoupon_code\x18\x0e \x01(\tR\n" +
+	"couponCode\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\x12)\n" +
+	"\x10shipping_country\x18\x10 \x01(\tR\x0fshippingCountry\x12'\n" +
+	"\x0fshipping_method\x18\x11 \x01(\tR\x0eshippingMethod\x12#\n" +
+	"\rshipping_cost\x18\x12 \x01(\x01R\fshippingCost\x12-\n" +
+	"\tshipments\x18\x13 \x03(\v2\x0f.proto.ShipmentR\tshipments\x12'\n" +
+	"\x0fshipping_region\x18\x14 \x01(\tR\x0eshippingRegion\x12\x1b\n" +
+	"\ttax_total\x18\x15 \x01(\x01R\btaxTotal\x12#\n" +
+	"\rtax_inclusive\x18\x16 \x01(\bR\ftaxInclusive\x12!\n" +
+	"\fshipping_tax\x18\x17 \x01(\x01R\vshippingTax\"]\n" +
+	"\fShipmentItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\tR\x04size\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\x86\x01\n" +
+	"\rShipmentEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x1f\n" +
+	"\voccurred_at\x18\x04 \x01(\tR\n" +
+	"occurredAt\"\x90\x02\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tR\x0etrackingNumber\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.proto.ShipmentItemR\x05items\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12,\n" +
+	"\x06events\x18\x06 \x03(\v2\x14.proto.ShipmentEventR\x06events\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12!\n" +
+	"\fdelivered_at\x18\b \x01(\tR\vdeliveredAt\"8\n" +
 	"\x12CreateOrderRequest\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"!\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"B\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\"u\n" +
 	"\x12UpdateOrderRequest\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"3\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"3\n" +
 	"\rOrderResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\",\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"M\n" +
 	"\x11ListOrdersRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\":\n" +
 	"\x12ListOrdersResponse\x12$\n" +
-	"\x06orders\x18\x01 \x03(\v2\f.proto.OrderR\x06orders\"B\n" +
+	"\x06orders\x18\x01 \x03(\v2\f.proto.OrderR\x06orders\"\\\n" +
 	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"5\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"5\n" +
 	"\x19UpdateOrderStatusResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe3\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"O\n" +
+	"\x15VerifyPurchaseRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"O\n" +
+	"\x16VerifyPurchaseResponse\x12\x1a\n" +
+	"\bverified\x18\x01 \x01(\bR\bverified\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"<\n" +
+	"\x19ReferencedProductsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\"=\n" +
+	"\x1aReferencedProductsResponse\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\"\xb1\x01\n" +
+	"\x16ReturnOrderItemRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x10\n" +
+	"\x03fit\x18\x05 \x01(\tR\x03fit\x12\x1f\n" +
+	"\vsize_system\x18\x06 \x01(\tR\n" +
+	"sizeSystem\"7\n" +
+	"\x11QuoteOrderRequest\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\":\n" +
+	"\x14QuoteShippingRequest\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.proto.OrderR\x05order\"\x9a\x01\n" +
+	"\x0eShippingOption\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04zone\x18\x03 \x01(\tR\x04zone\x12\x12\n" +
+	"\x04cost\x18\x04 \x01(\x01R\x04cost\x12\x19\n" +
+	"\bmin_days\x18\x05 \x01(\x05R\aminDays\x12\x19\n" +
+	"\bmax_days\x18\x06 \x01(\x05R\amaxDays\"s\n" +
+	"\x15QuoteShippingResponse\x12)\n" +
+	"\x10shipping_country\x18\x01 \x01(\tR\x0fshippingCountry\x12/\n" +
+	"\aoptions\x18\x02 \x03(\v2\x15.proto.ShippingOptionR\aoptions\"\xc1\x01\n" +
+	"\x15CreateShipmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tR\x0etrackingNumber\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.proto.ShipmentItemR\x05items\x12\x1f\n" +
+	"\vsize_system\x18\x05 \x01(\tR\n" +
+	"sizeSystem\"\x8b\x01\n" +
+	"\x1aRecordShipmentEventRequest\x12\x18\n" +
+	"\acarrier\x18\x01 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x02 \x01(\tR\x0etrackingNumber\x12*\n" +
+	"\x05event\x18\x03 \x01(\v2\x14.proto.ShipmentEventR\x05event\".\n" +
+	"\x11GetInvoiceRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"t\n" +
+	"\x0fInvoiceResponse\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12\x1b\n" +
+	"\tissued_at\x18\x02 \x01(\tR\bissuedAt\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x10\n" +
+	"\x03pdf\x18\x04 \x01(\fR\x03pdf\"\xd8\x03\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcampaign\x18\x03 \x01(\tR\bcampaign\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12!\n" +
+	"\fbuy_quantity\x18\x06 \x01(\x05R\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\a \x01(\x05R\vgetQuantity\x12\x1f\n" +
+	"\vproduct_ids\x18\b \x03(\tR\n" +
+	"productIds\x12\x16\n" +
+	"\x06brands\x18\t \x03(\tR\x06brands\x12\x1e\n" +
+	"\n" +
+	"categories\x18\n" +
+	" \x03(\tR\n" +
+	"categories\x12\x1a\n" +
+	"\bpriority\x18\v \x01(\x05R\bpriority\x12\x1a\n" +
+	"\bstacking\x18\f \x01(\tR\bstacking\x12\x16\n" +
+	"\x06active\x18\r \x01(\bR\x06active\x12\x1b\n" +
+	"\tstarts_at\x18\x0e \x01(\tR\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\x0f \x01(\tR\x06endsAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\tR\tupdatedAt\"H\n" +
+	"\x16CreatePromotionRequest\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.proto.PromotionR\tpromotion\"H\n" +
+	"\x16UpdatePromotionRequest\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.proto.PromotionR\tpromotion\"C\n" +
+	"\x11PromotionResponse\x12.\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x10.proto.PromotionR\tpromotion\"(\n" +
+	"\x16DeletePromotionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
+	"\x17DeletePromotionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x15ListPromotionsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\"J\n" +
+	"\x16ListPromotionsResponse\x120\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x10.proto.PromotionR\n" +
+	"promotions\"\xa5\x03\n" +
+	"\x06Coupon\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12(\n" +
+	"\x10min_order_amount\x18\x05 \x01(\x01R\x0eminOrderAmount\x12\x16\n" +
+	"\x06brands\x18\x06 \x03(\tR\x06brands\x12\x1e\n" +
+	"\n" +
+	"categories\x18\a \x03(\tR\n" +
+	"categories\x12\x1f\n" +
+	"\vusage_limit\x18\b \x01(\x05R\n" +
+	"usageLimit\x12$\n" +
+	"\x0eper_user_limit\x18\t \x01(\x05R\fperUserLimit\x12\x1d\n" +
+	"\n" +
+	"used_count\x18\n" +
+	" \x01(\x05R\tusedCount\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\tR\tupdatedAt\"<\n" +
+	"\x13CreateCouponRequest\x12%\n" +
+	"\x06coupon\x18\x01 \x01(\v2\r.proto.CouponR\x06coupon\"<\n" +
+	"\x13UpdateCouponRequest\x12%\n" +
+	"\x06coupon\x18\x01 \x01(\v2\r.proto.CouponR\x06coupon\"7\n" +
+	"\x0eCouponResponse\x12%\n" +
+	"\x06coupon\x18\x01 \x01(\v2\r.proto.CouponR\x06coupon\"5\n" +
+	"\x12ListCouponsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\">\n" +
+	"\x13ListCouponsResponse\x12'\n" +
+	"\acoupons\x18\x01 \x03(\v2\r.proto.CouponR\acoupons\"Z\n" +
+	"\x16GetCouponReportRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x81\x01\n" +
+	"\x10CouponRedemption\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x01R\bdiscount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\xd2\x01\n" +
+	"\fCouponReport\x12%\n" +
+	"\x06coupon\x18\x01 \x01(\v2\r.proto.CouponR\x06coupon\x12 \n" +
+	"\vredemptions\x18\x02 \x01(\x03R\vredemptions\x12!\n" +
+	"\funique_users\x18\x03 \x01(\x03R\vuniqueUsers\x12%\n" +
+	"\x0etotal_discount\x18\x04 \x01(\x01R\rtotalDiscount\x12/\n" +
+	"\x06recent\x18\x05 \x03(\v2\x17.proto.CouponRedemptionR\x06recent2\x81\f\n" +
 	"\fOrderService\x12>\n" +
 	"\vCreateOrder\x12\x19.proto.CreateOrderRequest\x1a\x14.proto.OrderResponse\x128\n" +
 	"\bGetOrder\x12\x16.proto.GetOrderRequest\x1a\x14.proto.OrderResponse\x12>\n" +
 	"\vUpdateOrder\x12\x19.proto.UpdateOrderRequest\x1a\x14.proto.OrderResponse\x12A\n" +
 	"\n" +
 	"ListOrders\x12\x18.proto.ListOrdersRequest\x1a\x19.proto.ListOrdersResponse\x12V\n" +
-	"\x11UpdateOrderStatus\x12\x1f.proto.UpdateOrderStatusRequest\x1a .proto.UpdateOrderStatusResponseB\x10Z\x0eshoeshop/protob\x06proto3"
+	"\x11UpdateOrderStatus\x12\x1f.proto.UpdateOrderStatusRequest\x1a .proto.UpdateOrderStatusResponse\x12M\n" +
+	"\x0eVerifyPurchase\x12\x1c.proto.VerifyPurchaseRequest\x1a\x1d.proto.VerifyPurchaseResponse\x12Y\n" +
+	"\x12ReferencedProducts\x12 .proto.ReferencedProductsRequest\x1a!.proto.ReferencedProductsResponse\x12F\n" +
+	"\x0fReturnOrderItem\x12\x1d.proto.ReturnOrderItemRequest\x1a\x14.proto.OrderResponse\x12<\n" +
+	"\n" +
+	"QuoteOrder\x12\x18.proto.QuoteOrderRequest\x1a\x14.proto.OrderResponse\x12J\n" +
+	"\rQuoteShipping\x12\x1b.proto.QuoteShippingRequest\x1a\x1c.proto.QuoteShippingResponse\x12D\n" +
+	"\x0eCreateShipment\x12\x1c.proto.CreateShipmentRequest\x1a\x14.proto.OrderResponse\x12N\n" +
+	"\x13RecordShipmentEvent\x12!.proto.RecordShipmentEventRequest\x1a\x14.proto.OrderResponse\x12>\n" +
+	"\n" +
+	"GetInvoice\x12\x18.proto.GetInvoiceRequest\x1a\x16.proto.InvoiceResponse\x12J\n" +
+	"\x0fCreatePromotion\x12\x1d.proto.CreatePromotionRequest\x1a\x18.proto.PromotionResponse\x12J\n" +
+	"\x0fUpdatePromotion\x12\x1d.proto.UpdatePromotionRequest\x1a\x18.proto.PromotionResponse\x12P\n" +
+	"\x0fDeletePromotion\x12\x1d.proto.DeletePromotionRequest\x1a\x1e.proto.DeletePromotionResponse\x12M\n" +
+	"\x0eListPromotions\x12\x1c.proto.ListPromotionsRequest\x1a\x1d.proto.ListPromotionsResponse\x12A\n" +
+	"\fCreateCoupon\x12\x1a.proto.CreateCouponRequest\x1a\x15.proto.CouponResponse\x12A\n" +
+	"\fUpdateCoupon\x12\x1a.proto.UpdateCouponRequest\x1a\x15.proto.CouponResponse\x12D\n" +
+	"\vListCoupons\x12\x19.proto.ListCouponsRequest\x1a\x1a.proto.ListCouponsResponse\x12E\n" +
+	"\x0fGetCouponReport\x12\x1d.proto.GetCouponReportRequest\x1a\x13.proto.CouponReportB\x10Z\x0eshoeshop/protob\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -619,40 +3196,127 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_order_proto_goTypes = []any{
-	(*OrderItem)(nil),                 // 0: proto.OrderItem
-	(*Order)(nil),                     // 1: proto.Order
-	(*CreateOrderRequest)(nil),        // 2: proto.CreateOrderRequest
-	(*GetOrderRequest)(nil),           // 3: proto.GetOrderRequest
-	(*UpdateOrderRequest)(nil),        // 4: proto.UpdateOrderRequest
-	(*OrderResponse)(nil),             // 5: proto.OrderResponse
-	(*ListOrdersRequest)(nil),         // 6: proto.ListOrdersRequest
-	(*ListOrdersResponse)(nil),        // 7: proto.ListOrdersResponse
-	(*UpdateOrderStatusRequest)(nil),  // 8: proto.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 9: proto.UpdateOrderStatusResponse
+	(*AppliedPromotion)(nil),           // 0: proto.AppliedPromotion
+	(*OrderItem)(nil),                  // 1: proto.OrderItem
+	(*Order)(nil),                      // 2: proto.Order
+	(*ShipmentItem)(nil),               // 3: proto.ShipmentItem
+	(*ShipmentEvent)(nil),              // 4: proto.ShipmentEvent
+	(*Shipment)(nil),                   // 5: proto.Shipment
+	(*CreateOrderRequest)(nil),         // 6: proto.CreateOrderRequest
+	(*GetOrderRequest)(nil),            // 7: proto.GetOrderRequest
+	(*UpdateOrderRequest)(nil),         // 8: proto.UpdateOrderRequest
+	(*OrderResponse)(nil),              // 9: proto.OrderResponse
+	(*ListOrdersRequest)(nil),          // 10: proto.ListOrdersRequest
+	(*ListOrdersResponse)(nil),         // 11: proto.ListOrdersResponse
+	(*UpdateOrderStatusRequest)(nil),   // 12: proto.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),  // 13: proto.UpdateOrderStatusResponse
+	(*VerifyPurchaseRequest)(nil),      // 14: proto.VerifyPurchaseRequest
+	(*VerifyPurchaseResponse)(nil),     // 15: proto.VerifyPurchaseResponse
+	(*ReferencedProductsRequest)(nil),  // 16: proto.ReferencedProductsRequest
+	(*ReferencedProductsResponse)(nil), // 17: proto.ReferencedProductsResponse
+	(*ReturnOrderItemRequest)(nil),     // 18: proto.ReturnOrderItemRequest
+	(*QuoteOrderRequest)(nil),          // 19: proto.QuoteOrderRequest
+	(*QuoteShippingRequest)(nil),       // 20: proto.QuoteShippingRequest
+	(*ShippingOption)(nil),             // 21: proto.ShippingOption
+	(*QuoteShippingResponse)(nil),      // 22: proto.QuoteShippingResponse
+	(*CreateShipmentRequest)(nil),      // 23: proto.CreateShipmentRequest
+	(*RecordShipmentEventRequest)(nil), // 24: proto.RecordShipmentEventRequest
+	(*GetInvoiceRequest)(nil),          // 25: proto.GetInvoiceRequest
+	(*InvoiceResponse)(nil),            // 26: proto.InvoiceResponse
+	(*Promotion)(nil),                  // 27: proto.Promotion
+	(*CreatePromotionRequest)(nil),     // 28: proto.CreatePromotionRequest
+	(*UpdatePromotionRequest)(nil),     // 29: proto.UpdatePromotionRequest
+	(*PromotionResponse)(nil),          // 30: proto.PromotionResponse
+	(*DeletePromotionRequest)(nil),     // 31: proto.DeletePromotionRequest
+	(*DeletePromotionResponse)(nil),    // 32: proto.DeletePromotionResponse
+	(*ListPromotionsRequest)(nil),      // 33: proto.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),     // 34: proto.ListPromotionsResponse
+	(*Coupon)(nil),                     // 35: proto.Coupon
+	(*CreateCouponRequest)(nil),        // 36: proto.CreateCouponRequest
+	(*UpdateCouponRequest)(nil),        // 37: proto.UpdateCouponRequest
+	(*CouponResponse)(nil),             // 38: proto.CouponResponse
+	(*ListCouponsRequest)(nil),         // 39: proto.ListCouponsRequest
+	(*ListCouponsResponse)(nil),        // 40: proto.ListCouponsResponse
+	(*GetCouponReportRequest)(nil),     // 41: proto.GetCouponReportRequest
+	(*CouponRedemption)(nil),           // 42: proto.CouponRedemption
+	(*CouponReport)(nil),               // 43: proto.CouponReport
+	(*fieldmaskpb.FieldMask)(nil),      // 44: google.protobuf.FieldMask
 }
 var file_order_proto_depIdxs = []int32{
-	0,  // 0: proto.Order.items:type_name -> proto.OrderItem
-	1,  // 1: proto.CreateOrderRequest.order:type_name -> proto.Order
-	1,  // 2: proto.UpdateOrderRequest.order:type_name -> proto.Order
-	1,  // 3: proto.OrderResponse.order:type_name -> proto.Order
-	1,  // 4: proto.ListOrdersResponse.orders:type_name -> proto.Order
-	2,  // 5: proto.OrderService.CreateOrder:input_type -> proto.CreateOrderRequest
-	3,  // 6: proto.OrderService.GetOrder:input_type -> proto.GetOrderRequest
-	4,  // 7: proto.OrderService.UpdateOrder:input_type -> proto.UpdateOrderRequest
-	6,  // 8: proto.OrderService.ListOrders:input_type -> proto.ListOrdersRequest
-	8,  // 9: proto.OrderService.UpdateOrderStatus:input_type -> proto.UpdateOrderStatusRequest
-	5,  // 10: proto.OrderService.CreateOrder:output_type -> proto.OrderResponse
-	5,  // 11: proto.OrderService.GetOrder:output_type -> proto.OrderResponse
-	5,  // 12: proto.OrderService.UpdateOrder:output_type -> proto.OrderResponse
-	7,  // 13: proto.OrderService.ListOrders:output_type -> proto.ListOrdersResponse
-	9,  // 14: proto.OrderService.UpdateOrderStatus:output_type -> proto.UpdateOrderStatusResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: proto.OrderItem.promotions:type_name -> proto.AppliedPromotion
+	1,  // 1: proto.Order.items:type_name -> proto.OrderItem
+	5,  // 2: proto.Order.shipments:type_name -> proto.Shipment
+	3,  // 3: proto.Shipment.items:type_name -> proto.ShipmentItem
+	4,  // 4: proto.Shipment.events:type_name -> proto.ShipmentEvent
+	2,  // 5: proto.CreateOrderRequest.order:type_name -> proto.Order
+	2,  // 6: proto.UpdateOrderRequest.order:type_name -> proto.Order
+	44, // 7: proto.UpdateOrderRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 8: proto.OrderResponse.order:type_name -> proto.Order
+	2,  // 9: proto.ListOrdersResponse.orders:type_name -> proto.Order
+	2,  // 10: proto.QuoteOrderRequest.order:type_name -> proto.Order
+	2,  // 11: proto.QuoteShippingRequest.order:type_name -> proto.Order
+	21, // 12: proto.QuoteShippingResponse.options:type_name -> proto.ShippingOption
+	3,  // 13: proto.CreateShipmentRequest.items:type_name -> proto.ShipmentItem
+	4,  // 14: proto.RecordShipmentEventRequest.event:type_name -> proto.ShipmentEvent
+	27, // 15: proto.CreatePromotionRequest.promotion:type_name -> proto.Promotion
+	27, // 16: proto.UpdatePromotionRequest.promotion:type_name -> proto.Promotion
+	27, // 17: proto.PromotionResponse.promotion:type_name -> proto.Promotion
+	27, // 18: proto.ListPromotionsResponse.promotions:type_name -> proto.Promotion
+	35, // 19: proto.CreateCouponRequest.coupon:type_name -> proto.Coupon
+	35, // 20: proto.UpdateCouponRequest.coupon:type_name -> proto.Coupon
+	35, // 21: proto.CouponResponse.coupon:type_name -> proto.Coupon
+	35, // 22: proto.ListCouponsResponse.coupons:type_name -> proto.Coupon
+	35, // 23: proto.CouponReport.coupon:type_name -> proto.Coupon
+	42, // 24: proto.CouponReport.recent:type_name -> proto.CouponRedemption
+	6,  // 25: proto.OrderService.CreateOrder:input_type -> proto.CreateOrderRequest
+	7,  // 26: proto.OrderService.GetOrder:input_type -> proto.GetOrderRequest
+	8,  // 27: proto.OrderService.UpdateOrder:input_type -> proto.UpdateOrderRequest
+	10, // 28: proto.OrderService.ListOrders:input_type -> proto.ListOrdersRequest
+	12, // 29: proto.OrderService.UpdateOrderStatus:input_type -> proto.UpdateOrderStatusRequest
+	14, // 30: proto.OrderService.VerifyPurchase:input_type -> proto.VerifyPurchaseRequest
+	16, // 31: proto.OrderService.ReferencedProducts:input_type -> proto.ReferencedProductsRequest
+	18, // 32: proto.OrderService.ReturnOrderItem:input_type -> proto.ReturnOrderItemRequest
+	19, // 33: proto.OrderService.QuoteOrder:input_type -> proto.QuoteOrderRequest
+	20, // 34: proto.OrderService.QuoteShipping:input_type -> proto.QuoteShippingRequest
+	23, // 35: proto.OrderService.CreateShipment:input_type -> proto.CreateShipmentRequest
+	24, // 36: proto.OrderService.RecordShipmentEvent:input_type -> proto.RecordShipmentEventRequest
+	25, // 37: proto.OrderService.GetInvoice:input_type -> proto.GetInvoiceRequest
+	28, // 38: proto.OrderService.CreatePromotion:input_type -> proto.CreatePromotionRequest
+	29, // 39: proto.OrderService.UpdatePromotion:input_type -> proto.UpdatePromotionRequest
+	31, // 40: proto.OrderService.DeletePromotion:input_type -> proto.DeletePromotionRequest
+	33, // 41: proto.OrderService.ListPromotions:input_type -> proto.ListPromotionsRequest
+	36, // 42: proto.OrderService.CreateCoupon:input_type -> proto.CreateCouponRequest
+	37, // 43: proto.OrderService.UpdateCoupon:input_type -> proto.UpdateCouponRequest
+	39, // 44: proto.OrderService.ListCoupons:input_type -> proto.ListCouponsRequest
+	41, // 45: proto.OrderService.GetCouponReport:input_type -> proto.GetCouponReportRequest
+	9,  // 46: proto.OrderService.CreateOrder:output_type -> proto.OrderResponse
+	9,  // 47: proto.OrderService.GetOrder:output_type -> proto.OrderResponse
+	9,  // 48: proto.OrderService.UpdateOrder:output_type -> proto.OrderResponse
+	11, // 49: proto.OrderService.ListOrders:output_type -> proto.ListOrdersResponse
+	13, // 50: proto.OrderService.UpdateOrderStatus:output_type -> proto.UpdateOrderStatusResponse
+	15, // 51: proto.OrderService.VerifyPurchase:output_type -> proto.VerifyPurchaseResponse
+	17, // 52: proto.OrderService.ReferencedProducts:output_type -> proto.ReferencedProductsResponse
+	9,  // 53: proto.OrderService.ReturnOrderItem:output_type -> proto.OrderResponse
+	9,  // 54: proto.OrderService.QuoteOrder:output_type -> proto.OrderResponse
+	22, // 55: proto.OrderService.QuoteShipping:output_type -> proto.QuoteShippingResponse
+	9,  // 56: proto.OrderService.CreateShipment:output_type -> proto.OrderResponse
+	9,  // 57: proto.OrderService.RecordShipmentEvent:output_type -> proto.OrderResponse
+	26, // 58: proto.OrderService.GetInvoice:output_type -> proto.InvoiceResponse
+	30, // 59: proto.OrderService.CreatePromotion:output_type -> proto.PromotionResponse
+	30, // 60: proto.OrderService.UpdatePromotion:output_type -> proto.PromotionResponse
+	32, // 61: proto.OrderService.DeletePromotion:output_type -> proto.DeletePromotionResponse
+	34, // 62: proto.OrderService.ListPromotions:output_type -> proto.ListPromotionsResponse
+	38, // 63: proto.OrderService.CreateCoupon:output_type -> proto.CouponResponse
+	38, // 64: proto.OrderService.UpdateCoupon:output_type -> proto.CouponResponse
+	40, // 65: proto.OrderService.ListCoupons:output_type -> proto.ListCouponsResponse
+	43, // 66: proto.OrderService.GetCouponReport:output_type -> proto.CouponReport
+	46, // [46:67] is the sub-list for method output_type
+	25, // [25:46] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -666,7 +3330,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
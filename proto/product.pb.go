@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,19 +23,48 @@ const (
 )
 
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
-	Brand         string                 `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
-	Sizes         []string               `protobuf:"bytes,7,rep,name=sizes,proto3" json:"sizes,omitempty"`
-	Colors        []string               `protobuf:"bytes,8,rep,name=colors,proto3" json:"colors,omitempty"`
-	Images        []string               `protobuf:"bytes,9,rep,name=images,proto3" json:"images,omitempty"`
-	Stock         int32                  `protobuf:"varint,10,opt,name=stock,proto3" json:"stock,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Slug of the category node; a category name is accepted on writes
+	Category string `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	// Brand name; on writes without brand_id it finds or creates the brand
+	Brand       string          `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	Sizes       []string        `protobuf:"bytes,7,rep,name=sizes,proto3" json:"sizes,omitempty"`
+	Colors      []string        `protobuf:"bytes,8,rep,name=colors,proto3" json:"colors,omitempty"`
+	Stock       int32           `protobuf:"varint,10,opt,name=stock,proto3" json:"stock,omitempty"`
+	CreatedAt   string          `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string          `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sku         string          `protobuf:"bytes,13,opt,name=sku,proto3" json:"sku,omitempty"`
+	Images      []*ProductImage `protobuf:"bytes,14,rep,name=images,proto3" json:"images,omitempty"`
+	Rating      float64         `protobuf:"fixed64,15,opt,name=rating,proto3" json:"rating,omitempty"`
+	ReviewCount int32           `protobuf:"varint,16,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	// Size system of sizes: EU (default), US_M, US_W, UK or CM
+	SizeSystem string `protobuf:"bytes,17,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	// Path from the root category to the product's category, read-only
+	Breadcrumbs []*Breadcrumb `protobuf:"bytes,18,rep,name=breadcrumbs,proto3" json:"breadcrumbs,omitempty"`
+	BrandId     string        `protobuf:"bytes,19,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	// Shift of the brand size table in mm, read-only
+	SizeOffset int32 `protobuf:"varint,20,opt,name=size_offset,json=sizeOffset,proto3" json:"size_offset,omitempty"`
+	// Incremented on every write. Updates must send the version they read and
+	// only succeed if the product is still at that version
+	Version int64 `protobuf:"varint,21,opt,name=version,proto3" json:"version,omitempty"`
+	// Set while the product is archived, read-only
+	DeletedAt string `protobuf:"bytes,22,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// A product.low_stock event is published when stock drops to this level;
+	// zero disables alerts
+	ReorderThreshold int32         `protobuf:"varint,23,opt,name=reorder_threshold,json=reorderThreshold,proto3" json:"reorder_threshold,omitempty"`
+	ShippingInfo     *ShippingInfo `protobuf:"bytes,24,opt,name=shipping_info,json=shippingInfo,proto3" json:"shipping_info,omitempty"`
+	// Markdown as a fraction of the price, 0.15 takes 15% off; applied to
+	// orders before promotions
+	Discount float64 `protobuf:"fixed64,25,opt,name=discount,proto3" json:"discount,omitempty"`
+	// Upper material, e.g. Leather or Mesh
+	Material string   `protobuf:"bytes,26,opt,name=material,proto3" json:"material,omitempty"`
+	Features []string `protobuf:"bytes,27,rep,name=features,proto3" json:"features,omitempty"`
+	// Calendar date in YYYY-MM-DD
+	ReleaseDate   string `protobuf:"bytes,28,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,56 +155,3675 @@ func (x *Product) GetColors() []string {
 	return nil
 }
 
-func (x *Product) GetImages() []string {
+func (x *Product) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Product) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Product) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetImages() []*ProductImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Product) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Product) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *Product) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *Product) GetBreadcrumbs() []*Breadcrumb {
+	if x != nil {
+		return x.Breadcrumbs
+	}
+	return nil
+}
+
+func (x *Product) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *Product) GetSizeOffset() int32 {
+	if x != nil {
+		return x.SizeOffset
+	}
+	return 0
+}
+
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *Product) GetReorderThreshold() int32 {
+	if x != nil {
+		return x.ReorderThreshold
+	}
+	return 0
+}
+
+func (x *Product) GetShippingInfo() *ShippingInfo {
+	if x != nil {
+		return x.ShippingInfo
+	}
+	return nil
+}
+
+func (x *Product) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *Product) GetMaterial() string {
+	if x != nil {
+		return x.Material
+	}
+	return ""
+}
+
+func (x *Product) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Product) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+// Package of one unit, used to quote shipping
+type ShippingInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Zero uses the default weight of a shoe box
+	WeightGrams int32 `protobuf:"varint,1,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	LengthMm    int32 `protobuf:"varint,2,opt,name=length_mm,json=lengthMm,proto3" json:"length_mm,omitempty"`
+	WidthMm     int32 `protobuf:"varint,3,opt,name=width_mm,json=widthMm,proto3" json:"width_mm,omitempty"`
+	HeightMm    int32 `protobuf:"varint,4,opt,name=height_mm,json=heightMm,proto3" json:"height_mm,omitempty"`
+	// Shipping method codes the product can ship with; empty allows every method
+	Methods []string `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	// Ships free with methods that offer free shipping
+	FreeShipping bool `protobuf:"varint,6,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	// Can ship to international zones
+	International bool `protobuf:"varint,7,opt,name=international,proto3" json:"international,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingInfo) Reset() {
+	*x = ShippingInfo{}
+	mi := &file_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingInfo) ProtoMessage() {}
+
+func (x *ShippingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingInfo.ProtoReflect.Descriptor instead.
+func (*ShippingInfo) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ShippingInfo) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+func (x *ShippingInfo) GetLengthMm() int32 {
+	if x != nil {
+		return x.LengthMm
+	}
+	return 0
+}
+
+func (x *ShippingInfo) GetWidthMm() int32 {
+	if x != nil {
+		return x.WidthMm
+	}
+	return 0
+}
+
+func (x *ShippingInfo) GetHeightMm() int32 {
+	if x != nil {
+		return x.HeightMm
+	}
+	return 0
+}
+
+func (x *ShippingInfo) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *ShippingInfo) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *ShippingInfo) GetInternational() bool {
+	if x != nil {
+		return x.International
+	}
+	return false
+}
+
+type ImageThumbnail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageThumbnail) Reset() {
+	*x = ImageThumbnail{}
+	mi := &file_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageThumbnail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageThumbnail) ProtoMessage() {}
+
+func (x *ImageThumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageThumbnail.ProtoReflect.Descriptor instead.
+func (*ImageThumbnail) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImageThumbnail) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageThumbnail) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageThumbnail) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type ProductImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	AltText       string                 `protobuf:"bytes,5,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	SortOrder     int32                  `protobuf:"varint,6,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	ContentType   string                 `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	Thumbnails    []*ImageThumbnail      `protobuf:"bytes,9,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProductImage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProductImage) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ProductImage) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+func (x *ProductImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductImage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProductImage) GetThumbnails() []*ImageThumbnail {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SizeSystem string                 `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	// Locale of breadcrumb names, "en" by default
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type UpdateProductRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// Fields to update, by proto field name. Without a mask every field
+	// is overwritten
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductResponse) Reset() {
+	*x = ProductResponse{}
+	mi := &file_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductResponse) ProtoMessage() {}
+
+func (x *ProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
+func (*ProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteProductResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListArchivedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SizeSystem    string                 `protobuf:"bytes,1,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedProductsRequest) Reset() {
+	*x = ListArchivedProductsRequest{}
+	mi := &file_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedProductsRequest) ProtoMessage() {}
+
+func (x *ListArchivedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListArchivedProductsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category slug; products of its descendants are included
+	Category string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Brand id, slug or name
+	Brand         string `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
+	SizeSystem    string `protobuf:"bytes,3,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type SearchProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type AutocompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutocompleteRequest) Reset() {
+	*x = AutocompleteRequest{}
+	mi := &file_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutocompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutocompleteRequest) ProtoMessage() {}
+
+func (x *AutocompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutocompleteRequest.ProtoReflect.Descriptor instead.
+func (*AutocompleteRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AutocompleteRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *AutocompleteRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Score         int64                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Suggestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Suggestion) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type AutocompleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutocompleteResponse) Reset() {
+	*x = AutocompleteResponse{}
+	mi := &file_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutocompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutocompleteResponse) ProtoMessage() {}
+
+func (x *AutocompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutocompleteResponse.ProtoReflect.Descriptor instead.
+func (*AutocompleteResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AutocompleteResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsRequest) Reset() {
+	*x = GetCacheStatsRequest{}
+	mi := &file_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{18}
+}
+
+type CacheLayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Layer         string                 `protobuf:"bytes,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Hits          uint64                 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses        uint64                 `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Errors        uint64                 `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`
+	Entries       int64                  `protobuf:"varint,5,opt,name=entries,proto3" json:"entries,omitempty"`
+	Available     bool                   `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheLayerStats) Reset() {
+	*x = CacheLayerStats{}
+	mi := &file_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheLayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheLayerStats) ProtoMessage() {}
+
+func (x *CacheLayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheLayerStats.ProtoReflect.Descriptor instead.
+func (*CacheLayerStats) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CacheLayerStats) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
+func (x *CacheLayerStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *CacheLayerStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *CacheLayerStats) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *CacheLayerStats) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *CacheLayerStats) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+type CacheStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Layers        []*CacheLayerStats     `protobuf:"bytes,1,rep,name=layers,proto3" json:"layers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheStatsResponse) Reset() {
+	*x = CacheStatsResponse{}
+	mi := &file_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheStatsResponse) ProtoMessage() {}
+
+func (x *CacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*CacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CacheStatsResponse) GetLayers() []*CacheLayerStats {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+type ReorderReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sales window for the velocity, 30 days by default
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// How many days of sales a reorder should cover, same as days by default
+	CoverDays     int32 `protobuf:"varint,2,opt,name=cover_days,json=coverDays,proto3" json:"cover_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderReportRequest) Reset() {
+	*x = ReorderReportRequest{}
+	mi := &file_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderReportRequest) ProtoMessage() {}
+
+func (x *ReorderReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderReportRequest.ProtoReflect.Descriptor instead.
+func (*ReorderReportRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReorderReportRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *ReorderReportRequest) GetCoverDays() int32 {
+	if x != nil {
+		return x.CoverDays
+	}
+	return 0
+}
+
+type ReorderSuggestion struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku              string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Stock            int32                  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	ReorderThreshold int32                  `protobuf:"varint,5,opt,name=reorder_threshold,json=reorderThreshold,proto3" json:"reorder_threshold,omitempty"`
+	UnitsSold        int64                  `protobuf:"varint,6,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	// Average units sold per day over the window
+	DailyVelocity float64 `protobuf:"fixed64,7,opt,name=daily_velocity,json=dailyVelocity,proto3" json:"daily_velocity,omitempty"`
+	// Days until the stock runs out at the current velocity, -1 without sales
+	DaysOfStock       float64 `protobuf:"fixed64,8,opt,name=days_of_stock,json=daysOfStock,proto3" json:"days_of_stock,omitempty"`
+	SuggestedQuantity int32   `protobuf:"varint,9,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReorderSuggestion) Reset() {
+	*x = ReorderSuggestion{}
+	mi := &file_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSuggestion) ProtoMessage() {}
+
+func (x *ReorderSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSuggestion.ProtoReflect.Descriptor instead.
+func (*ReorderSuggestion) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReorderSuggestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetReorderThreshold() int32 {
+	if x != nil {
+		return x.ReorderThreshold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetUnitsSold() int64 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDailyVelocity() float64 {
+	if x != nil {
+		return x.DailyVelocity
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDaysOfStock() float64 {
+	if x != nil {
+		return x.DaysOfStock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetSuggestedQuantity() int32 {
+	if x != nil {
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+type ReorderReport struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Days      int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	CoverDays int32                  `protobuf:"varint,2,opt,name=cover_days,json=coverDays,proto3" json:"cover_days,omitempty"`
+	// Most urgent first
+	Suggestions   []*ReorderSuggestion `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderReport) Reset() {
+	*x = ReorderReport{}
+	mi := &file_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderReport) ProtoMessage() {}
+
+func (x *ReorderReport) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderReport.ProtoReflect.Descriptor instead.
+func (*ReorderReport) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReorderReport) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *ReorderReport) GetCoverDays() int32 {
+	if x != nil {
+		return x.CoverDays
+	}
+	return 0
+}
+
+func (x *ReorderReport) GetSuggestions() []*ReorderSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// format, dry_run and size_system are read from the first message; data carries the next chunk of the file
+type ImportProductsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	DryRun bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Data   []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Size system of sizes without a system prefix in the file
+	SizeSystem    string `protobuf:"bytes,4,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsRequest) ProtoMessage() {}
+
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportProductsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportProductsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportProductsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportProductsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportRowError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsResponse) ProtoMessage() {}
+
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportProductsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportProductsResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ExportProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Brand         string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,4,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsRequest) ProtoMessage() {}
+
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportProductsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ExportProductsRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ExportProductsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ExportProductsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsChunk) Reset() {
+	*x = ExportProductsChunk{}
+	mi := &file_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsChunk) ProtoMessage() {}
+
+func (x *ExportProductsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsChunk.ProtoReflect.Descriptor instead.
+func (*ExportProductsChunk) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportProductsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// product_id and alt_text are read from the first message; data carries the next chunk of the file
+type UploadProductImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	AltText       string                 `protobuf:"bytes,2,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadProductImageRequest) Reset() {
+	*x = UploadProductImageRequest{}
+	mi := &file_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadProductImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadProductImageRequest) ProtoMessage() {}
+
+func (x *UploadProductImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadProductImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadProductImageRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UploadProductImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UploadProductImageRequest) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *UploadProductImageRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateProductImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	AltText       string                 `protobuf:"bytes,3,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	SortOrder     int32                  `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductImageRequest) Reset() {
+	*x = UpdateProductImageRequest{}
+	mi := &file_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductImageRequest) ProtoMessage() {}
+
+func (x *UpdateProductImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductImageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductImageRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateProductImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateProductImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *UpdateProductImageRequest) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *UpdateProductImageRequest) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+type DeleteProductImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductImageRequest) Reset() {
+	*x = DeleteProductImageRequest{}
+	mi := &file_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductImageRequest) ProtoMessage() {}
+
+func (x *DeleteProductImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductImageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductImageRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteProductImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteProductImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+type DeleteProductImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductImageResponse) Reset() {
+	*x = DeleteProductImageResponse{}
+	mi := &file_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductImageResponse) ProtoMessage() {}
+
+func (x *DeleteProductImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductImageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductImageResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteProductImageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	PreviousPrice float64                `protobuf:"fixed64,2,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	// CREATE, UPDATE, IMPORT or SCHEDULE
+	Source        string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	ChangedAt     string `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PriceChange) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceChange) GetPreviousPrice() float64 {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return 0
+}
+
+func (x *PriceChange) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PriceChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type GetPriceHistoryRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Window for lowest_price in days, 30 by default
+	Days int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	// Maximum number of changes returned, newest first
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_product_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetPriceHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PriceHistoryResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ProductId    string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CurrentPrice float64                `protobuf:"fixed64,2,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	// Lowest price in effect at any point during the last `days` days
+	LowestPrice   float64        `protobuf:"fixed64,3,opt,name=lowest_price,json=lowestPrice,proto3" json:"lowest_price,omitempty"`
+	Days          int32          `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+	Changes       []*PriceChange `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistoryResponse) Reset() {
+	*x = PriceHistoryResponse{}
+	mi := &file_product_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryResponse) ProtoMessage() {}
+
+func (x *PriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*PriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PriceHistoryResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceHistoryResponse) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *PriceHistoryResponse) GetLowestPrice() float64 {
+	if x != nil {
+		return x.LowestPrice
+	}
+	return 0
+}
+
+func (x *PriceHistoryResponse) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *PriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ScheduledPrice struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price     float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	// RFC3339
+	EffectiveAt string `protobuf:"bytes,4,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	// PENDING, APPLIED or CANCELED
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AppliedAt     string `protobuf:"bytes,7,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledPrice) Reset() {
+	*x = ScheduledPrice{}
+	mi := &file_product_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPrice) ProtoMessage() {}
+
+func (x *ScheduledPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPrice.ProtoReflect.Descriptor instead.
+func (*ScheduledPrice) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ScheduledPrice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ScheduledPrice) GetEffectiveAt() string {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetAppliedAt() string {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return ""
+}
+
+type SchedulePriceChangeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price     float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	// RFC3339, must be in the future
+	EffectiveAt   string `protobuf:"bytes,3,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeRequest) Reset() {
+	*x = SchedulePriceChangeRequest{}
+	mi := &file_product_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeRequest) ProtoMessage() {}
+
+func (x *SchedulePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SchedulePriceChangeRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SchedulePriceChangeRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeRequest) GetEffectiveAt() string {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return ""
+}
+
+type ListScheduledPricesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Empty lists changes in every status
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledPricesRequest) Reset() {
+	*x = ListScheduledPricesRequest{}
+	mi := &file_product_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledPricesRequest) ProtoMessage() {}
+
+func (x *ListScheduledPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledPricesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledPricesRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListScheduledPricesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListScheduledPricesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListScheduledPricesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledPrices []*ScheduledPrice      `protobuf:"bytes,1,rep,name=scheduled_prices,json=scheduledPrices,proto3" json:"scheduled_prices,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListScheduledPricesResponse) Reset() {
+	*x = ListScheduledPricesResponse{}
+	mi := &file_product_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledPricesResponse) ProtoMessage() {}
+
+func (x *ListScheduledPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledPricesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledPricesResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListScheduledPricesResponse) GetScheduledPrices() []*ScheduledPrice {
+	if x != nil {
+		return x.ScheduledPrices
+	}
+	return nil
+}
+
+type CancelScheduledPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledPriceRequest) Reset() {
+	*x = CancelScheduledPriceRequest{}
+	mi := &file_product_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledPriceRequest) ProtoMessage() {}
+
+func (x *CancelScheduledPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledPriceRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPriceRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelScheduledPriceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelScheduledPriceRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type Breadcrumb struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Breadcrumb) Reset() {
+	*x = Breadcrumb{}
+	mi := &file_product_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breadcrumb) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breadcrumb) ProtoMessage() {}
+
+func (x *Breadcrumb) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breadcrumb.ProtoReflect.Descriptor instead.
+func (*Breadcrumb) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Breadcrumb) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Breadcrumb) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Breadcrumb) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique URL key; generated from the parent slug and name when empty
+	Slug string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Empty for root categories
+	ParentId string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Names keyed by locale, e.g. {"en": "Trail", "ru": "Трейл"}
+	Names map[string]string `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Name in the requested locale, falling back to "en"
+	Name          string        `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	SortOrder     int32         `protobuf:"varint,6,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Children      []*Category   `protobuf:"bytes,7,rep,name=children,proto3" json:"children,omitempty"`
+	Breadcrumbs   []*Breadcrumb `protobuf:"bytes,8,rep,name=breadcrumbs,proto3" json:"breadcrumbs,omitempty"`
+	CreatedAt     string        `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string        `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_product_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Category) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+func (x *Category) GetChildren() []*Category {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Category) GetBreadcrumbs() []*Breadcrumb {
+	if x != nil {
+		return x.Breadcrumbs
+	}
+	return nil
+}
+
+func (x *Category) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_product_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category id or slug
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Locale        string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_product_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetCategoryRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type UpdateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Slug cannot be changed; parent_id moves the category with its subtree
+	Category      *Category `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_product_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type DeleteCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_product_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_product_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteCategoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_product_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListCategoriesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ListCategoriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Root categories with nested children, ordered by sort_order and name
+	Categories    []*Category `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_product_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type SizeChartRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Foot length in mm
+	Length        int32   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Eu            float64 `protobuf:"fixed64,2,opt,name=eu,proto3" json:"eu,omitempty"`
+	UsM           float64 `protobuf:"fixed64,3,opt,name=us_m,json=usM,proto3" json:"us_m,omitempty"`
+	UsW           float64 `protobuf:"fixed64,4,opt,name=us_w,json=usW,proto3" json:"us_w,omitempty"`
+	Uk            float64 `protobuf:"fixed64,5,opt,name=uk,proto3" json:"uk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SizeChartRow) Reset() {
+	*x = SizeChartRow{}
+	mi := &file_product_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SizeChartRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SizeChartRow) ProtoMessage() {}
+
+func (x *SizeChartRow) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SizeChartRow.ProtoReflect.Descriptor instead.
+func (*SizeChartRow) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SizeChartRow) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *SizeChartRow) GetEu() float64 {
+	if x != nil {
+		return x.Eu
+	}
+	return 0
+}
+
+func (x *SizeChartRow) GetUsM() float64 {
+	if x != nil {
+		return x.UsM
+	}
+	return 0
+}
+
+func (x *SizeChartRow) GetUsW() float64 {
+	if x != nil {
+		return x.UsW
+	}
+	return 0
+}
+
+func (x *SizeChartRow) GetUk() float64 {
+	if x != nil {
+		return x.Uk
+	}
+	return 0
+}
+
+type Brand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique URL key; generated from the name when empty and never changed
+	Slug        string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	LogoUrl     string `protobuf:"bytes,4,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Country     string `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	// Millimetres the brand's sizes are shifted from the standard table:
+	// negative for brands that run small, positive for brands that run large
+	SizeOffset int32 `protobuf:"varint,7,opt,name=size_offset,json=sizeOffset,proto3" json:"size_offset,omitempty"`
+	// Conversion table derived from size_offset, read-only
+	SizeChart     []*SizeChartRow `protobuf:"bytes,8,rep,name=size_chart,json=sizeChart,proto3" json:"size_chart,omitempty"`
+	CreatedAt     string          `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string          `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Brand) Reset() {
+	*x = Brand{}
+	mi := &file_product_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Brand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brand) ProtoMessage() {}
+
+func (x *Brand) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brand.ProtoReflect.Descriptor instead.
+func (*Brand) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Brand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Brand) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Brand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Brand) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *Brand) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Brand) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Brand) GetSizeOffset() int32 {
+	if x != nil {
+		return x.SizeOffset
+	}
+	return 0
+}
+
+func (x *Brand) GetSizeChart() []*SizeChartRow {
+	if x != nil {
+		return x.SizeChart
+	}
+	return nil
+}
+
+func (x *Brand) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Brand) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateBrandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         *Brand                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBrandRequest) Reset() {
+	*x = CreateBrandRequest{}
+	mi := &file_product_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBrandRequest) ProtoMessage() {}
+
+func (x *CreateBrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBrandRequest.ProtoReflect.Descriptor instead.
+func (*CreateBrandRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateBrandRequest) GetBrand() *Brand {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+type GetBrandRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Brand id or slug
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrandRequest) Reset() {
+	*x = GetBrandRequest{}
+	mi := &file_product_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrandRequest) ProtoMessage() {}
+
+func (x *GetBrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrandRequest.ProtoReflect.Descriptor instead.
+func (*GetBrandRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetBrandRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateBrandRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A new name or size_offset is applied to every product of the brand
+	Brand         *Brand `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBrandRequest) Reset() {
+	*x = UpdateBrandRequest{}
+	mi := &file_product_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBrandRequest) ProtoMessage() {}
+
+func (x *UpdateBrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBrandRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBrandRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UpdateBrandRequest) GetBrand() *Brand {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+type DeleteBrandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBrandRequest) Reset() {
+	*x = DeleteBrandRequest{}
+	mi := &file_product_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBrandRequest) ProtoMessage() {}
+
+func (x *DeleteBrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBrandRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBrandRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteBrandRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteBrandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBrandResponse) Reset() {
+	*x = DeleteBrandResponse{}
+	mi := &file_product_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBrandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBrandResponse) ProtoMessage() {}
+
+func (x *DeleteBrandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBrandResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBrandResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeleteBrandResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListBrandsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrandsRequest) Reset() {
+	*x = ListBrandsRequest{}
+	mi := &file_product_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrandsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrandsRequest) ProtoMessage() {}
+
+func (x *ListBrandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrandsRequest.ProtoReflect.Descriptor instead.
+func (*ListBrandsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{57}
+}
+
+type ListBrandsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brands        []*Brand               `protobuf:"bytes,1,rep,name=brands,proto3" json:"brands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrandsResponse) Reset() {
+	*x = ListBrandsResponse{}
+	mi := &file_product_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrandsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrandsResponse) ProtoMessage() {}
+
+func (x *ListBrandsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrandsResponse.ProtoReflect.Descriptor instead.
+func (*ListBrandsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListBrandsResponse) GetBrands() []*Brand {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+type StockSubscription struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Empty when any size will do
+	Size          string `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	SizeSystem    string `protobuf:"bytes,5,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	CreatedAt     string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockSubscription) Reset() {
+	*x = StockSubscription{}
+	mi := &file_product_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSubscription) ProtoMessage() {}
+
+func (x *StockSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockSubscription.ProtoReflect.Descriptor instead.
+func (*StockSubscription) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *StockSubscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockSubscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StockSubscription) GetProductId() string {
 	if x != nil {
-		return x.Images
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-func (x *Product) GetStock() int32 {
+func (x *StockSubscription) GetSize() string {
 	if x != nil {
-		return x.Stock
+		return x.Size
 	}
-	return 0
+	return ""
 }
 
-func (x *Product) GetCreatedAt() string {
+func (x *StockSubscription) GetSizeSystem() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.SizeSystem
 	}
 	return ""
 }
 
-func (x *Product) GetUpdatedAt() string {
+func (x *StockSubscription) GetCreatedAt() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CreatedAt
 	}
 	return ""
 }
 
-type CreateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+type SubscribeBackInStockRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Address the notification is sent to
+	Email         string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	ProductId     string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Size          string `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	SizeSystem    string `protobuf:"bytes,5,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
-	mi := &file_product_proto_msgTypes[1]
+func (x *SubscribeBackInStockRequest) Reset() {
+	*x = SubscribeBackInStockRequest{}
+	mi := &file_product_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductRequest) String() string {
+func (x *SubscribeBackInStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (*SubscribeBackInStockRequest) ProtoMessage() {}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[1]
+func (x *SubscribeBackInStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,40 +3834,69 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SubscribeBackInStockRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBackInStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *CreateProductRequest) GetProduct() *Product {
+func (x *SubscribeBackInStockRequest) GetUserId() string {
 	if x != nil {
-		return x.Product
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type GetProductRequest struct {
+func (x *SubscribeBackInStockRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SubscribeBackInStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SubscribeBackInStockRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *SubscribeBackInStockRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type UnsubscribeBackInStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_product_proto_msgTypes[2]
+func (x *UnsubscribeBackInStockRequest) Reset() {
+	*x = UnsubscribeBackInStockRequest{}
+	mi := &file_product_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *UnsubscribeBackInStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*UnsubscribeBackInStockRequest) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[2]
+func (x *UnsubscribeBackInStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,40 +3907,47 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use UnsubscribeBackInStockRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeBackInStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *UnsubscribeBackInStockRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type UpdateProductRequest struct {
+func (x *UnsubscribeBackInStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnsubscribeBackInStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_product_proto_msgTypes[3]
+func (x *UnsubscribeBackInStockResponse) Reset() {
+	*x = UnsubscribeBackInStockResponse{}
+	mi := &file_product_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *UnsubscribeBackInStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*UnsubscribeBackInStockResponse) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[3]
+func (x *UnsubscribeBackInStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,40 +3958,40 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UnsubscribeBackInStockResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeBackInStockResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *UpdateProductRequest) GetProduct() *Product {
+func (x *UnsubscribeBackInStockResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Product
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type ProductResponse struct {
+type ListStockSubscriptionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProductResponse) Reset() {
-	*x = ProductResponse{}
-	mi := &file_product_proto_msgTypes[4]
+func (x *ListStockSubscriptionsRequest) Reset() {
+	*x = ListStockSubscriptionsRequest{}
+	mi := &file_product_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProductResponse) String() string {
+func (x *ListStockSubscriptionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProductResponse) ProtoMessage() {}
+func (*ListStockSubscriptionsRequest) ProtoMessage() {}
 
-func (x *ProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[4]
+func (x *ListStockSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,40 +4002,40 @@ func (x *ProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
-func (*ProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListStockSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *ProductResponse) GetProduct() *Product {
+func (x *ListStockSubscriptionsRequest) GetUserId() string {
 	if x != nil {
-		return x.Product
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type DeleteProductRequest struct {
+type ListStockSubscriptionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subscriptions []*StockSubscription   `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_product_proto_msgTypes[5]
+func (x *ListStockSubscriptionsResponse) Reset() {
+	*x = ListStockSubscriptionsResponse{}
+	mi := &file_product_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *ListStockSubscriptionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*ListStockSubscriptionsResponse) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[5]
+func (x *ListStockSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,40 +4046,50 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListStockSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *ListStockSubscriptionsResponse) GetSubscriptions() []*StockSubscription {
 	if x != nil {
-		return x.Id
+		return x.Subscriptions
 	}
-	return ""
+	return nil
 }
 
-type DeleteProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+type RecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Product page the recommendations are shown on
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Recommends for the user's past purchases when no products are given;
+	// purchased products are never recommended
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Other products to recommend for, e.g. the cart
+	ProductIds []string `protobuf:"bytes,3,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// 10 by default, at most 50
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	SizeSystem    string `protobuf:"bytes,5,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	Locale        string `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
-	mi := &file_product_proto_msgTypes[6]
+func (x *RecommendationsRequest) Reset() {
+	*x = RecommendationsRequest{}
+	mi := &file_product_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *RecommendationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*RecommendationsRequest) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[6]
+func (x *RecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,92 +4100,79 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*RecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *DeleteProductResponse) GetSuccess() bool {
+func (x *RecommendationsRequest) GetProductId() string {
 	if x != nil {
-		return x.Success
+		return x.ProductId
 	}
-	return false
-}
-
-type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Brand         string                 `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_product_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *ListProductsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
-
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[7]
+func (x *RecommendationsRequest) GetProductIds() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ProductIds
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{7}
+func (x *RecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
-func (x *ListProductsRequest) GetCategory() string {
+func (x *RecommendationsRequest) GetSizeSystem() string {
 	if x != nil {
-		return x.Category
+		return x.SizeSystem
 	}
 	return ""
 }
 
-func (x *ListProductsRequest) GetBrand() string {
+func (x *RecommendationsRequest) GetLocale() string {
 	if x != nil {
-		return x.Brand
+		return x.Locale
 	}
 	return ""
 }
 
-type ListProductsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+type Recommendation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// CO_PURCHASE or POPULAR
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Orders with this and a requested product for CO_PURCHASE, units ordered for POPULAR
+	Score         int64 `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_product_proto_msgTypes[8]
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_product_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *Recommendation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*Recommendation) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[8]
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,40 +4183,54 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *Recommendation) GetProduct() *Product {
 	if x != nil {
-		return x.Products
+		return x.Product
 	}
 	return nil
 }
 
-type SearchProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Recommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *SearchProductsRequest) Reset() {
-	*x = SearchProductsRequest{}
-	mi := &file_product_proto_msgTypes[9]
+func (x *Recommendation) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type RecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecommendationsResponse) Reset() {
+	*x = RecommendationsResponse{}
+	mi := &file_product_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsRequest) String() string {
+func (x *RecommendationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsRequest) ProtoMessage() {}
+func (*RecommendationsResponse) ProtoMessage() {}
 
-func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[9]
+func (x *RecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,23 +4241,23 @@ func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
-func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *SearchProductsRequest) GetQuery() string {
+func (x *RecommendationsResponse) GetRecommendations() []*Recommendation {
 	if x != nil {
-		return x.Query
+		return x.Recommendations
 	}
-	return ""
+	return nil
 }
 
 var File_product_proto protoreflect.FileDescriptor
 
 const file_product_proto_rawDesc = "" +
 	"\n" +
-	"\rproduct.proto\x12\x05proto\"\xb1\x02\n" +
+	"\rproduct.proto\x12\x05proto\x1a google/protobuf/field_mask.proto\"\xc2\x06\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -570,41 +4266,378 @@ const file_product_proto_rawDesc = "" +
 	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x14\n" +
 	"\x05brand\x18\x06 \x01(\tR\x05brand\x12\x14\n" +
 	"\x05sizes\x18\a \x03(\tR\x05sizes\x12\x16\n" +
-	"\x06colors\x18\b \x03(\tR\x06colors\x12\x16\n" +
-	"\x06images\x18\t \x03(\tR\x06images\x12\x14\n" +
+	"\x06colors\x18\b \x03(\tR\x06colors\x12\x14\n" +
 	"\x05stock\x18\n" +
 	" \x01(\x05R\x05stock\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\tR\tupdatedAt\"@\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\x12\x10\n" +
+	"\x03sku\x18\r \x01(\tR\x03sku\x12+\n" +
+	"\x06images\x18\x0e \x03(\v2\x13.proto.ProductImageR\x06images\x12\x16\n" +
+	"\x06rating\x18\x0f \x01(\x01R\x06rating\x12!\n" +
+	"\freview_count\x18\x10 \x01(\x05R\vreviewCount\x12\x1f\n" +
+	"\vsize_system\x18\x11 \x01(\tR\n" +
+	"sizeSystem\x123\n" +
+	"\vbreadcrumbs\x18\x12 \x03(\v2\x11.proto.BreadcrumbR\vbreadcrumbs\x12\x19\n" +
+	"\bbrand_id\x18\x13 \x01(\tR\abrandId\x12\x1f\n" +
+	"\vsize_offset\x18\x14 \x01(\x05R\n" +
+	"sizeOffset\x12\x18\n" +
+	"\aversion\x18\x15 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x16 \x01(\tR\tdeletedAt\x12+\n" +
+	"\x11reorder_threshold\x18\x17 \x01(\x05R\x10reorderThreshold\x128\n" +
+	"\rshipping_info\x18\x18 \x01(\v2\x13.proto.ShippingInfoR\fshippingInfo\x12\x1a\n" +
+	"\bdiscount\x18\x19 \x01(\x01R\bdiscount\x12\x1a\n" +
+	"\bmaterial\x18\x1a \x01(\tR\bmaterial\x12\x1a\n" +
+	"\bfeatures\x18\x1b \x03(\tR\bfeatures\x12!\n" +
+	"\frelease_date\x18\x1c \x01(\tR\vreleaseDateJ\x04\b\t\x10\n" +
+	"\"\xeb\x01\n" +
+	"\fShippingInfo\x12!\n" +
+	"\fweight_grams\x18\x01 \x01(\x05R\vweightGrams\x12\x1b\n" +
+	"\tlength_mm\x18\x02 \x01(\x05R\blengthMm\x12\x19\n" +
+	"\bwidth_mm\x18\x03 \x01(\x05R\awidthMm\x12\x1b\n" +
+	"\theight_mm\x18\x04 \x01(\x05R\bheightMm\x12\x18\n" +
+	"\amethods\x18\x05 \x03(\tR\amethods\x12#\n" +
+	"\rfree_shipping\x18\x06 \x01(\bR\ffreeShipping\x12$\n" +
+	"\rinternational\x18\a \x01(\bR\rinternational\"P\n" +
+	"\x0eImageThumbnail\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\"\x86\x02\n" +
+	"\fProductImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x19\n" +
+	"\balt_text\x18\x05 \x01(\tR\aaltText\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x06 \x01(\x05R\tsortOrder\x12!\n" +
+	"\fcontent_type\x18\a \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\b \x01(\x03R\x04size\x125\n" +
+	"\n" +
+	"thumbnails\x18\t \x03(\v2\x15.proto.ImageThumbnailR\n" +
+	"thumbnails\"@\n" +
 	"\x14CreateProductRequest\x12(\n" +
-	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\"#\n" +
+	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\"\\\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"}\n" +
 	"\x14UpdateProductRequest\x12(\n" +
-	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\";\n" +
+	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\";\n" +
 	"\x0fProductResponse\x12(\n" +
 	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"G\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"'\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x1bListArchivedProductsRequest\x12\x1f\n" +
+	"\vsize_system\x18\x01 \x01(\tR\n" +
+	"sizeSystem\"\x80\x01\n" +
 	"\x13ListProductsRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05brand\x18\x02 \x01(\tR\x05brand\"B\n" +
+	"\x05brand\x18\x02 \x01(\tR\x05brand\x12\x1f\n" +
+	"\vsize_system\x18\x03 \x01(\tR\n" +
+	"sizeSystem\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"B\n" +
 	"\x14ListProductsResponse\x12*\n" +
-	"\bproducts\x18\x01 \x03(\v2\x0e.proto.ProductR\bproducts\"-\n" +
+	"\bproducts\x18\x01 \x03(\v2\x0e.proto.ProductR\bproducts\"f\n" +
 	"\x15SearchProductsRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query2\xbe\x03\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"C\n" +
+	"\x13AutocompleteRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"i\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\"K\n" +
+	"\x14AutocompleteResponse\x123\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x11.proto.SuggestionR\vsuggestions\"\x16\n" +
+	"\x14GetCacheStatsRequest\"\xa3\x01\n" +
+	"\x0fCacheLayerStats\x12\x14\n" +
+	"\x05layer\x18\x01 \x01(\tR\x05layer\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x04R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x04R\x06misses\x12\x16\n" +
+	"\x06errors\x18\x04 \x01(\x04R\x06errors\x12\x18\n" +
+	"\aentries\x18\x05 \x01(\x03R\aentries\x12\x1c\n" +
+	"\tavailable\x18\x06 \x01(\bR\tavailable\"D\n" +
+	"\x12CacheStatsResponse\x12.\n" +
+	"\x06layers\x18\x01 \x03(\v2\x16.proto.CacheLayerStatsR\x06layers\"I\n" +
+	"\x14ReorderReportRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\x12\x1d\n" +
+	"\n" +
+	"cover_days\x18\x02 \x01(\x05R\tcoverDays\"\xb4\x02\n" +
+	"\x11ReorderSuggestion\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05stock\x18\x04 \x01(\x05R\x05stock\x12+\n" +
+	"\x11reorder_threshold\x18\x05 \x01(\x05R\x10reorderThreshold\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x06 \x01(\x03R\tunitsSold\x12%\n" +
+	"\x0edaily_velocity\x18\a \x01(\x01R\rdailyVelocity\x12\"\n" +
+	"\rdays_of_stock\x18\b \x01(\x01R\vdaysOfStock\x12-\n" +
+	"\x12suggested_quantity\x18\t \x01(\x05R\x11suggestedQuantity\"~\n" +
+	"\rReorderReport\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\x12\x1d\n" +
+	"\n" +
+	"cover_days\x18\x02 \x01(\x05R\tcoverDays\x12:\n" +
+	"\vsuggestions\x18\x03 \x03(\v2\x18.proto.ReorderSuggestionR\vsuggestions\"}\n" +
+	"\x15ImportProductsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1f\n" +
+	"\vsize_system\x18\x04 \x01(\tR\n" +
+	"sizeSystem\"P\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xac\x01\n" +
+	"\x16ImportProductsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x12-\n" +
+	"\x06errors\x18\x05 \x03(\v2\x15.proto.ImportRowErrorR\x06errors\"\x82\x01\n" +
+	"\x15ExportProductsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x1f\n" +
+	"\vsize_system\x18\x04 \x01(\tR\n" +
+	"sizeSystem\")\n" +
+	"\x13ExportProductsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"i\n" +
+	"\x19UploadProductImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\balt_text\x18\x02 \x01(\tR\aaltText\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x8f\x01\n" +
+	"\x19UpdateProductImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\x12\x19\n" +
+	"\balt_text\x18\x03 \x01(\tR\aaltText\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x04 \x01(\x05R\tsortOrder\"U\n" +
+	"\x19DeleteProductImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\"6\n" +
+	"\x1aDeleteProductImageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x81\x01\n" +
+	"\vPriceChange\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12%\n" +
+	"\x0eprevious_price\x18\x02 \x01(\x01R\rpreviousPrice\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\tR\tchangedAt\"a\n" +
+	"\x16GetPriceHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xbf\x01\n" +
+	"\x14PriceHistoryResponse\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12#\n" +
+	"\rcurrent_price\x18\x02 \x01(\x01R\fcurrentPrice\x12!\n" +
+	"\flowest_price\x18\x03 \x01(\x01R\vlowestPrice\x12\x12\n" +
+	"\x04days\x18\x04 \x01(\x05R\x04days\x12,\n" +
+	"\achanges\x18\x05 \x03(\v2\x12.proto.PriceChangeR\achanges\"\xce\x01\n" +
+	"\x0eScheduledPrice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12!\n" +
+	"\feffective_at\x18\x04 \x01(\tR\veffectiveAt\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"applied_at\x18\a \x01(\tR\tappliedAt\"t\n" +
+	"\x1aSchedulePriceChangeRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12!\n" +
+	"\feffective_at\x18\x03 \x01(\tR\veffectiveAt\"S\n" +
+	"\x1aListScheduledPricesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"_\n" +
+	"\x1bListScheduledPricesResponse\x12@\n" +
+	"\x10scheduled_prices\x18\x01 \x03(\v2\x15.proto.ScheduledPriceR\x0fscheduledPrices\"L\n" +
+	"\x1bCancelScheduledPriceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"D\n" +
+	"\n" +
+	"Breadcrumb\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x8a\x03\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x120\n" +
+	"\x05names\x18\x04 \x03(\v2\x1a.proto.Category.NamesEntryR\x05names\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x06 \x01(\x05R\tsortOrder\x12+\n" +
+	"\bchildren\x18\a \x03(\v2\x0f.proto.CategoryR\bchildren\x123\n" +
+	"\vbreadcrumbs\x18\b \x03(\v2\x11.proto.BreadcrumbR\vbreadcrumbs\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
+	"\x15CreateCategoryRequest\x12+\n" +
+	"\bcategory\x18\x01 \x01(\v2\x0f.proto.CategoryR\bcategory\"<\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"D\n" +
+	"\x15UpdateCategoryRequest\x12+\n" +
+	"\bcategory\x18\x01 \x01(\v2\x0f.proto.CategoryR\bcategory\"'\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x16DeleteCategoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"/\n" +
+	"\x15ListCategoriesRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\"I\n" +
+	"\x16ListCategoriesResponse\x12/\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x0f.proto.CategoryR\n" +
+	"categories\"l\n" +
+	"\fSizeChartRow\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x05R\x06length\x12\x0e\n" +
+	"\x02eu\x18\x02 \x01(\x01R\x02eu\x12\x11\n" +
+	"\x04us_m\x18\x03 \x01(\x01R\x03usM\x12\x11\n" +
+	"\x04us_w\x18\x04 \x01(\x01R\x03usW\x12\x0e\n" +
+	"\x02uk\x18\x05 \x01(\x01R\x02uk\"\xa9\x02\n" +
+	"\x05Brand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x19\n" +
+	"\blogo_url\x18\x04 \x01(\tR\alogoUrl\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x1f\n" +
+	"\vsize_offset\x18\a \x01(\x05R\n" +
+	"sizeOffset\x122\n" +
+	"\n" +
+	"size_chart\x18\b \x03(\v2\x13.proto.SizeChartRowR\tsizeChart\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\"8\n" +
+	"\x12CreateBrandRequest\x12\"\n" +
+	"\x05brand\x18\x01 \x01(\v2\f.proto.BrandR\x05brand\"!\n" +
+	"\x0fGetBrandRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x12UpdateBrandRequest\x12\"\n" +
+	"\x05brand\x18\x01 \x01(\v2\f.proto.BrandR\x05brand\"$\n" +
+	"\x12DeleteBrandRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x13DeleteBrandResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x13\n" +
+	"\x11ListBrandsRequest\":\n" +
+	"\x12ListBrandsResponse\x12$\n" +
+	"\x06brands\x18\x01 \x03(\v2\f.proto.BrandR\x06brands\"\xaf\x01\n" +
+	"\x11StockSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\x1f\n" +
+	"\vsize_system\x18\x05 \x01(\tR\n" +
+	"sizeSystem\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\xa0\x01\n" +
+	"\x1bSubscribeBackInStockRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\x1f\n" +
+	"\vsize_system\x18\x05 \x01(\tR\n" +
+	"sizeSystem\"H\n" +
+	"\x1dUnsubscribeBackInStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
+	"\x1eUnsubscribeBackInStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x1dListStockSubscriptionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"`\n" +
+	"\x1eListStockSubscriptionsResponse\x12>\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x18.proto.StockSubscriptionR\rsubscriptions\"\xc0\x01\n" +
+	"\x16RecommendationsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vproduct_ids\x18\x03 \x03(\tR\n" +
+	"productIds\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vsize_system\x18\x05 \x01(\tR\n" +
+	"sizeSystem\x12\x16\n" +
+	"\x06locale\x18\x06 \x01(\tR\x06locale\"h\n" +
+	"\x0eRecommendation\x12(\n" +
+	"\aproduct\x18\x01 \x01(\v2\x0e.proto.ProductR\aproduct\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\"Z\n" +
+	"\x17RecommendationsResponse\x12?\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x15.proto.RecommendationR\x0frecommendations2\x9e\x14\n" +
 	"\x0eProductService\x12D\n" +
 	"\rCreateProduct\x12\x1b.proto.CreateProductRequest\x1a\x16.proto.ProductResponse\x12>\n" +
 	"\n" +
 	"GetProduct\x12\x18.proto.GetProductRequest\x1a\x16.proto.ProductResponse\x12D\n" +
 	"\rUpdateProduct\x12\x1b.proto.UpdateProductRequest\x1a\x16.proto.ProductResponse\x12J\n" +
-	"\rDeleteProduct\x12\x1b.proto.DeleteProductRequest\x1a\x1c.proto.DeleteProductResponse\x12G\n" +
+	"\rDeleteProduct\x12\x1b.proto.DeleteProductRequest\x1a\x1c.proto.DeleteProductResponse\x12F\n" +
+	"\x0eRestoreProduct\x12\x1c.proto.RestoreProductRequest\x1a\x16.proto.ProductResponse\x12W\n" +
+	"\x14ListArchivedProducts\x12\".proto.ListArchivedProductsRequest\x1a\x1b.proto.ListProductsResponse\x12G\n" +
 	"\fListProducts\x12\x1a.proto.ListProductsRequest\x1a\x1b.proto.ListProductsResponse\x12K\n" +
-	"\x0eSearchProducts\x12\x1c.proto.SearchProductsRequest\x1a\x1b.proto.ListProductsResponseB\x10Z\x0eshoeshop/protob\x06proto3"
+	"\x0eSearchProducts\x12\x1c.proto.SearchProductsRequest\x1a\x1b.proto.ListProductsResponse\x12G\n" +
+	"\fAutocomplete\x12\x1a.proto.AutocompleteRequest\x1a\x1b.proto.AutocompleteResponse\x12G\n" +
+	"\rGetCacheStats\x12\x1b.proto.GetCacheStatsRequest\x1a\x19.proto.CacheStatsResponse\x12E\n" +
+	"\x10GetReorderReport\x12\x1b.proto.ReorderReportRequest\x1a\x14.proto.ReorderReport\x12O\n" +
+	"\x0eImportProducts\x12\x1c.proto.ImportProductsRequest\x1a\x1d.proto.ImportProductsResponse(\x01\x12L\n" +
+	"\x0eExportProducts\x12\x1c.proto.ExportProductsRequest\x1a\x1a.proto.ExportProductsChunk0\x01\x12M\n" +
+	"\x12UploadProductImage\x12 .proto.UploadProductImageRequest\x1a\x13.proto.ProductImage(\x01\x12K\n" +
+	"\x12UpdateProductImage\x12 .proto.UpdateProductImageRequest\x1a\x13.proto.ProductImage\x12Y\n" +
+	"\x12DeleteProductImage\x12 .proto.DeleteProductImageRequest\x1a!.proto.DeleteProductImageResponse\x12M\n" +
+	"\x0fGetPriceHistory\x12\x1d.proto.GetPriceHistoryRequest\x1a\x1b.proto.PriceHistoryResponse\x12O\n" +
+	"\x13SchedulePriceChange\x12!.proto.SchedulePriceChangeRequest\x1a\x15.proto.ScheduledPrice\x12\\\n" +
+	"\x13ListScheduledPrices\x12!.proto.ListScheduledPricesRequest\x1a\".proto.ListScheduledPricesResponse\x12Q\n" +
+	"\x14CancelScheduledPrice\x12\".proto.CancelScheduledPriceRequest\x1a\x15.proto.ScheduledPrice\x12?\n" +
+	"\x0eCreateCategory\x12\x1c.proto.CreateCategoryRequest\x1a\x0f.proto.Category\x129\n" +
+	"\vGetCategory\x12\x19.proto.GetCategoryRequest\x1a\x0f.proto.Category\x12?\n" +
+	"\x0eUpdateCategory\x12\x1c.proto.UpdateCategoryRequest\x1a\x0f.proto.Category\x12M\n" +
+	"\x0eDeleteCategory\x12\x1c.proto.DeleteCategoryRequest\x1a\x1d.proto.DeleteCategoryResponse\x12M\n" +
+	"\x0eListCategories\x12\x1c.proto.ListCategoriesRequest\x1a\x1d.proto.ListCategoriesResponse\x126\n" +
+	"\vCreateBrand\x12\x19.proto.CreateBrandRequest\x1a\f.proto.Brand\x120\n" +
+	"\bGetBrand\x12\x16.proto.GetBrandRequest\x1a\f.proto.Brand\x126\n" +
+	"\vUpdateBrand\x12\x19.proto.UpdateBrandRequest\x1a\f.proto.Brand\x12D\n" +
+	"\vDeleteBrand\x12\x19.proto.DeleteBrandRequest\x1a\x1a.proto.DeleteBrandResponse\x12A\n" +
+	"\n" +
+	"ListBrands\x12\x18.proto.ListBrandsRequest\x1a\x19.proto.ListBrandsResponse\x12T\n" +
+	"\x14SubscribeBackInStock\x12\".proto.SubscribeBackInStockRequest\x1a\x18.proto.StockSubscription\x12e\n" +
+	"\x16UnsubscribeBackInStock\x12$.proto.UnsubscribeBackInStockRequest\x1a%.proto.UnsubscribeBackInStockResponse\x12e\n" +
+	"\x16ListStockSubscriptions\x12$.proto.ListStockSubscriptionsRequest\x1a%.proto.ListStockSubscriptionsResponse\x12S\n" +
+	"\x12GetRecommendations\x12\x1d.proto.RecommendationsRequest\x1a\x1e.proto.RecommendationsResponseB\x10Z\x0eshoeshop/protob\x06proto3"
 
 var (
 	file_product_proto_rawDescOnce sync.Once
@@ -618,41 +4651,181 @@ func file_product_proto_rawDescGZIP() []byte {
 	return file_product_proto_rawDescData
 }
 
-var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_product_proto_goTypes = []any{
-	(*Product)(nil),               // 0: proto.Product
-	(*CreateProductRequest)(nil),  // 1: proto.CreateProductRequest
-	(*GetProductRequest)(nil),     // 2: proto.GetProductRequest
-	(*UpdateProductRequest)(nil),  // 3: proto.UpdateProductRequest
-	(*ProductResponse)(nil),       // 4: proto.ProductResponse
-	(*DeleteProductRequest)(nil),  // 5: proto.DeleteProductRequest
-	(*DeleteProductResponse)(nil), // 6: proto.DeleteProductResponse
-	(*ListProductsRequest)(nil),   // 7: proto.ListProductsRequest
-	(*ListProductsResponse)(nil),  // 8: proto.ListProductsResponse
-	(*SearchProductsRequest)(nil), // 9: proto.SearchProductsRequest
+	(*Product)(nil),                        // 0: proto.Product
+	(*ShippingInfo)(nil),                   // 1: proto.ShippingInfo
+	(*ImageThumbnail)(nil),                 // 2: proto.ImageThumbnail
+	(*ProductImage)(nil),                   // 3: proto.ProductImage
+	(*CreateProductRequest)(nil),           // 4: proto.CreateProductRequest
+	(*GetProductRequest)(nil),              // 5: proto.GetProductRequest
+	(*UpdateProductRequest)(nil),           // 6: proto.UpdateProductRequest
+	(*ProductResponse)(nil),                // 7: proto.ProductResponse
+	(*DeleteProductRequest)(nil),           // 8: proto.DeleteProductRequest
+	(*DeleteProductResponse)(nil),          // 9: proto.DeleteProductResponse
+	(*RestoreProductRequest)(nil),          // 10: proto.RestoreProductRequest
+	(*ListArchivedProductsRequest)(nil),    // 11: proto.ListArchivedProductsRequest
+	(*ListProductsRequest)(nil),            // 12: proto.ListProductsRequest
+	(*ListProductsResponse)(nil),           // 13: proto.ListProductsResponse
+	(*SearchProductsRequest)(nil),          // 14: proto.SearchProductsRequest
+	(*AutocompleteRequest)(nil),            // 15: proto.AutocompleteRequest
+	(*Suggestion)(nil),                     // 16: proto.Suggestion
+	(*AutocompleteResponse)(nil),           // 17: proto.AutocompleteResponse
+	(*GetCacheStatsRequest)(nil),           // 18: proto.GetCacheStatsRequest
+	(*CacheLayerStats)(nil),                // 19: proto.CacheLayerStats
+	(*CacheStatsResponse)(nil),             // 20: proto.CacheStatsResponse
+	(*ReorderReportRequest)(nil),           // 21: proto.ReorderReportRequest
+	(*ReorderSuggestion)(nil),              // 22: proto.ReorderSuggestion
+	(*ReorderReport)(nil),                  // 23: proto.ReorderReport
+	(*ImportProductsRequest)(nil),          // 24: proto.ImportProductsRequest
+	(*ImportRowError)(nil),                 // 25: proto.ImportRowError
+	(*ImportProductsResponse)(nil),         // 26: proto.ImportProductsResponse
+	(*ExportProductsRequest)(nil),          // 27: proto.ExportProductsRequest
+	(*ExportProductsChunk)(nil),            // 28: proto.ExportProductsChunk
+	(*UploadProductImageRequest)(nil),      // 29: proto.UploadProductImageRequest
+	(*UpdateProductImageRequest)(nil),      // 30: proto.UpdateProductImageRequest
+	(*DeleteProductImageRequest)(nil),      // 31: proto.DeleteProductImageRequest
+	(*DeleteProductImageResponse)(nil),     // 32: proto.DeleteProductImageResponse
+	(*PriceChange)(nil),                    // 33: proto.PriceChange
+	(*GetPriceHistoryRequest)(nil),         // 34: proto.GetPriceHistoryRequest
+	(*PriceHistoryResponse)(nil),           // 35: proto.PriceHistoryResponse
+	(*ScheduledPrice)(nil),                 // 36: proto.ScheduledPrice
+	(*SchedulePriceChangeRequest)(nil),     // 37: proto.SchedulePriceChangeRequest
+	(*ListScheduledPricesRequest)(nil),     // 38: proto.ListScheduledPricesRequest
+	(*ListScheduledPricesResponse)(nil),    // 39: proto.ListScheduledPricesResponse
+	(*CancelScheduledPriceRequest)(nil),    // 40: proto.CancelScheduledPriceRequest
+	(*Breadcrumb)(nil),                     // 41: proto.Breadcrumb
+	(*Category)(nil),                       // 42: proto.Category
+	(*CreateCategoryRequest)(nil),          // 43: proto.CreateCategoryRequest
+	(*GetCategoryRequest)(nil),             // 44: proto.GetCategoryRequest
+	(*UpdateCategoryRequest)(nil),          // 45: proto.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),          // 46: proto.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),         // 47: proto.DeleteCategoryResponse
+	(*ListCategoriesRequest)(nil),          // 48: proto.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),         // 49: proto.ListCategoriesResponse
+	(*SizeChartRow)(nil),                   // 50: proto.SizeChartRow
+	(*Brand)(nil),                          // 51: proto.Brand
+	(*CreateBrandRequest)(nil),             // 52: proto.CreateBrandRequest
+	(*GetBrandRequest)(nil),                // 53: proto.GetBrandRequest
+	(*UpdateBrandRequest)(nil),             // 54: proto.UpdateBrandRequest
+	(*DeleteBrandRequest)(nil),             // 55: proto.DeleteBrandRequest
+	(*DeleteBrandResponse)(nil),            // 56: proto.DeleteBrandResponse
+	(*ListBrandsRequest)(nil),              // 57: proto.ListBrandsRequest
+	(*ListBrandsResponse)(nil),             // 58: proto.ListBrandsResponse
+	(*StockSubscription)(nil),              // 59: proto.StockSubscription
+	(*SubscribeBackInStockRequest)(nil),    // 60: proto.SubscribeBackInStockRequest
+	(*UnsubscribeBackInStockRequest)(nil),  // 61: proto.UnsubscribeBackInStockRequest
+	(*UnsubscribeBackInStockResponse)(nil), // 62: proto.UnsubscribeBackInStockResponse
+	(*ListStockSubscriptionsRequest)(nil),  // 63: proto.ListStockSubscriptionsRequest
+	(*ListStockSubscriptionsResponse)(nil), // 64: proto.ListStockSubscriptionsResponse
+	(*RecommendationsRequest)(nil),         // 65: proto.RecommendationsRequest
+	(*Recommendation)(nil),                 // 66: proto.Recommendation
+	(*RecommendationsResponse)(nil),        // 67: proto.RecommendationsResponse
+	nil,                                    // 68: proto.Category.NamesEntry
+	(*fieldmaskpb.FieldMask)(nil),          // 69: google.protobuf.FieldMask
 }
 var file_product_proto_depIdxs = []int32{
-	0,  // 0: proto.CreateProductRequest.product:type_name -> proto.Product
-	0,  // 1: proto.UpdateProductRequest.product:type_name -> proto.Product
-	0,  // 2: proto.ProductResponse.product:type_name -> proto.Product
-	0,  // 3: proto.ListProductsResponse.products:type_name -> proto.Product
-	1,  // 4: proto.ProductService.CreateProduct:input_type -> proto.CreateProductRequest
-	2,  // 5: proto.ProductService.GetProduct:input_type -> proto.GetProductRequest
-	3,  // 6: proto.ProductService.UpdateProduct:input_type -> proto.UpdateProductRequest
-	5,  // 7: proto.ProductService.DeleteProduct:input_type -> proto.DeleteProductRequest
-	7,  // 8: proto.ProductService.ListProducts:input_type -> proto.ListProductsRequest
-	9,  // 9: proto.ProductService.SearchProducts:input_type -> proto.SearchProductsRequest
-	4,  // 10: proto.ProductService.CreateProduct:output_type -> proto.ProductResponse
-	4,  // 11: proto.ProductService.GetProduct:output_type -> proto.ProductResponse
-	4,  // 12: proto.ProductService.UpdateProduct:output_type -> proto.ProductResponse
-	6,  // 13: proto.ProductService.DeleteProduct:output_type -> proto.DeleteProductResponse
-	8,  // 14: proto.ProductService.ListProducts:output_type -> proto.ListProductsResponse
-	8,  // 15: proto.ProductService.SearchProducts:output_type -> proto.ListProductsResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	3,  // 0: proto.Product.images:type_name -> proto.ProductImage
+	41, // 1: proto.Product.breadcrumbs:type_name -> proto.Breadcrumb
+	1,  // 2: proto.Product.shipping_info:type_name -> proto.ShippingInfo
+	2,  // 3: proto.ProductImage.thumbnails:type_name -> proto.ImageThumbnail
+	0,  // 4: proto.CreateProductRequest.product:type_name -> proto.Product
+	0,  // 5: proto.UpdateProductRequest.product:type_name -> proto.Product
+	69, // 6: proto.UpdateProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 7: proto.ProductResponse.product:type_name -> proto.Product
+	0,  // 8: proto.ListProductsResponse.products:type_name -> proto.Product
+	16, // 9: proto.AutocompleteResponse.suggestions:type_name -> proto.Suggestion
+	19, // 10: proto.CacheStatsResponse.layers:type_name -> proto.CacheLayerStats
+	22, // 11: proto.ReorderReport.suggestions:type_name -> proto.ReorderSuggestion
+	25, // 12: proto.ImportProductsResponse.errors:type_name -> proto.ImportRowError
+	33, // 13: proto.PriceHistoryResponse.changes:type_name -> proto.PriceChange
+	36, // 14: proto.ListScheduledPricesResponse.scheduled_prices:type_name -> proto.ScheduledPrice
+	68, // 15: proto.Category.names:type_name -> proto.Category.NamesEntry
+	42, // 16: proto.Category.children:type_name -> proto.Category
+	41, // 17: proto.Category.breadcrumbs:type_name -> proto.Breadcrumb
+	42, // 18: proto.CreateCategoryRequest.category:type_name -> proto.Category
+	42, // 19: proto.UpdateCategoryRequest.category:type_name -> proto.Category
+	42, // 20: proto.ListCategoriesResponse.categories:type_name -> proto.Category
+	50, // 21: proto.Brand.size_chart:type_name -> proto.SizeChartRow
+	51, // 22: proto.CreateBrandRequest.brand:type_name -> proto.Brand
+	51, // 23: proto.UpdateBrandRequest.brand:type_name -> proto.Brand
+	51, // 24: proto.ListBrandsResponse.brands:type_name -> proto.Brand
+	59, // 25: proto.ListStockSubscriptionsResponse.subscriptions:type_name -> proto.StockSubscription
+	0,  // 26: proto.Recommendation.product:type_name -> proto.Product
+	66, // 27: proto.RecommendationsResponse.recommendations:type_name -> proto.Recommendation
+	4,  // 28: proto.ProductService.CreateProduct:input_type -> proto.CreateProductRequest
+	5,  // 29: proto.ProductService.GetProduct:input_type -> proto.GetProductRequest
+	6,  // 30: proto.ProductService.UpdateProduct:input_type -> proto.UpdateProductRequest
+	8,  // 31: proto.ProductService.DeleteProduct:input_type -> proto.DeleteProductRequest
+	10, // 32: proto.ProductService.RestoreProduct:input_type -> proto.RestoreProductRequest
+	11, // 33: proto.ProductService.ListArchivedProducts:input_type -> proto.ListArchivedProductsRequest
+	12, // 34: proto.ProductService.ListProducts:input_type -> proto.ListProductsRequest
+	14, // 35: proto.ProductService.SearchProducts:input_type -> proto.SearchProductsRequest
+	15, // 36: proto.ProductService.Autocomplete:input_type -> proto.AutocompleteRequest
+	18, // 37: proto.ProductService.GetCacheStats:input_type -> proto.GetCacheStatsRequest
+	21, // 38: proto.ProductService.GetReorderReport:input_type -> proto.ReorderReportRequest
+	24, // 39: proto.ProductService.ImportProducts:input_type -> proto.ImportProductsRequest
+	27, // 40: proto.ProductService.ExportProducts:input_type -> proto.ExportProductsRequest
+	29, // 41: proto.ProductService.UploadProductImage:input_type -> proto.UploadProductImageRequest
+	30, // 42: proto.ProductService.UpdateProductImage:input_type -> proto.UpdateProductImageRequest
+	31, // 43: proto.ProductService.DeleteProductImage:input_type -> proto.DeleteProductImageRequest
+	34, // 44: proto.ProductService.GetPriceHistory:input_type -> proto.GetPriceHistoryRequest
+	37, // 45: proto.ProductService.SchedulePriceChange:input_type -> proto.SchedulePriceChangeRequest
+	38, // 46: proto.ProductService.ListScheduledPrices:input_type -> proto.ListScheduledPricesRequest
+	40, // 47: proto.ProductService.CancelScheduledPrice:input_type -> proto.CancelScheduledPriceRequest
+	43, // 48: proto.ProductService.CreateCategory:input_type -> proto.CreateCategoryRequest
+	44, // 49: proto.ProductService.GetCategory:input_type -> proto.GetCategoryRequest
+	45, // 50: proto.ProductService.UpdateCategory:input_type -> proto.UpdateCategoryRequest
+	46, // 51: proto.ProductService.DeleteCategory:input_type -> proto.DeleteCategoryRequest
+	48, // 52: proto.ProductService.ListCategories:input_type -> proto.ListCategoriesRequest
+	52, // 53: proto.ProductService.CreateBrand:input_type -> proto.CreateBrandRequest
+	53, // 54: proto.ProductService.GetBrand:input_type -> proto.GetBrandRequest
+	54, // 55: proto.ProductService.UpdateBrand:input_type -> proto.UpdateBrandRequest
+	55, // 56: proto.ProductService.DeleteBrand:input_type -> proto.DeleteBrandRequest
+	57, // 57: proto.ProductService.ListBrands:input_type -> proto.ListBrandsRequest
+	60, // 58: proto.ProductService.SubscribeBackInStock:input_type -> proto.SubscribeBackInStockRequest
+	61, // 59: proto.ProductService.UnsubscribeBackInStock:input_type -> proto.UnsubscribeBackInStockRequest
+	63, // 60: proto.ProductService.ListStockSubscriptions:input_type -> proto.ListStockSubscriptionsRequest
+	65, // 61: proto.ProductService.GetRecommendations:input_type -> proto.RecommendationsRequest
+	7,  // 62: proto.ProductService.CreateProduct:output_type -> proto.ProductResponse
+	7,  // 63: proto.ProductService.GetProduct:output_type -> proto.ProductResponse
+	7,  // 64: proto.ProductService.UpdateProduct:output_type -> proto.ProductResponse
+	9,  // 65: proto.ProductService.DeleteProduct:output_type -> proto.DeleteProductResponse
+	7,  // 66: proto.ProductService.RestoreProduct:output_type -> proto.ProductResponse
+	13, // 67: proto.ProductService.ListArchivedProducts:output_type -> proto.ListProductsResponse
+	13, // 68: proto.ProductService.ListProducts:output_type -> proto.ListProductsResponse
+	13, // 69: proto.ProductService.SearchProducts:output_type -> proto.ListProductsResponse
+	17, // 70: proto.ProductService.Autocomplete:output_type -> proto.AutocompleteResponse
+	20, // 71: proto.ProductService.GetCacheStats:output_type -> proto.CacheStatsResponse
+	23, // 72: proto.ProductService.GetReorderReport:output_type -> proto.ReorderReport
+	26, // 73: proto.ProductService.ImportProducts:output_type -> proto.ImportProductsResponse
+	28, // 74: proto.ProductService.ExportProducts:output_type -> proto.ExportProductsChunk
+	3,  // 75: proto.ProductService.UploadProductImage:output_type -> proto.ProductImage
+	3,  // 76: proto.ProductService.UpdateProductImage:output_type -> proto.ProductImage
+	32, // 77: proto.ProductService.DeleteProductImage:output_type -> proto.DeleteProductImageResponse
+	35, // 78: proto.ProductService.GetPriceHistory:output_type -> proto.PriceHistoryResponse
+	36, // 79: proto.ProductService.SchedulePriceChange:output_type -> proto.ScheduledPrice
+	39, // 80: proto.ProductService.ListScheduledPrices:output_type -> proto.ListScheduledPricesResponse
+	36, // 81: proto.ProductService.CancelScheduledPrice:output_type -> proto.ScheduledPrice
+	42, // 82: proto.ProductService.CreateCategory:output_type -> proto.Category
+	42, // 83: proto.ProductService.GetCategory:output_type -> proto.Category
+	42, // 84: proto.ProductService.UpdateCategory:output_type -> proto.Category
+	47, // 85: proto.ProductService.DeleteCategory:output_type -> proto.DeleteCategoryResponse
+	49, // 86: proto.ProductService.ListCategories:output_type -> proto.ListCategoriesResponse
+	51, // 87: proto.ProductService.CreateBrand:output_type -> proto.Brand
+	51, // 88: proto.ProductService.GetBrand:output_type -> proto.Brand
+	51, // 89: proto.ProductService.UpdateBrand:output_type -> proto.Brand
+	56, // 90: proto.ProductService.DeleteBrand:output_type -> proto.DeleteBrandResponse
+	58, // 91: proto.ProductService.ListBrands:output_type -> proto.ListBrandsResponse
+	59, // 92: proto.ProductService.SubscribeBackInStock:output_type -> proto.StockSubscription
+	62, // 93: proto.ProductService.UnsubscribeBackInStock:output_type -> proto.UnsubscribeBackInStockResponse
+	64, // 94: proto.ProductService.ListStockSubscriptions:output_type -> proto.ListStockSubscriptionsResponse
+	67, // 95: proto.ProductService.GetRecommendations:output_type -> proto.RecommendationsResponse
+	62, // [62:96] is the sub-list for method output_type
+	28, // [28:62] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_product_proto_init() }
@@ -666,7 +4839,7 @@ func file_product_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_proto_rawDesc), len(file_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
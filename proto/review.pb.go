@@ -0,0 +1,795 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: review.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Review struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Rating         int32                  `protobuf:"varint,5,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text           string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Size           string                 `protobuf:"bytes,7,opt,name=size,proto3" json:"size,omitempty"`
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ModerationNote string                 `protobuf:"bytes,9,opt,name=moderation_note,json=moderationNote,proto3" json:"moderation_note,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// RUNS_SMALL, TRUE_TO_SIZE or RUNS_LARGE; empty when not given
+	Fit string `protobuf:"bytes,12,opt,name=fit,proto3" json:"fit,omitempty"`
+	// Size system of size: EU (default), US_M, US_W, UK or CM
+	SizeSystem    string `protobuf:"bytes,13,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_review_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Review) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Review) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Review) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Review) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Review) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *Review) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Review) GetModerationNote() string {
+	if x != nil {
+		return x.ModerationNote
+	}
+	return ""
+}
+
+func (x *Review) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Review) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Review) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *Review) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type CreateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReviewRequest) Reset() {
+	*x = CreateReviewRequest{}
+	mi := &file_review_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReviewRequest) ProtoMessage() {}
+
+func (x *CreateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReviewRequest.ProtoReflect.Descriptor instead.
+func (*CreateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateReviewRequest) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+type ReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewResponse) Reset() {
+	*x = ReviewResponse{}
+	mi := &file_review_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewResponse) ProtoMessage() {}
+
+func (x *ReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewResponse.ProtoReflect.Descriptor instead.
+func (*ReviewResponse) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+type ListReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,6,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_review_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListReviewsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ListReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_review_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ModerateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateReviewRequest) Reset() {
+	*x = ModerateReviewRequest{}
+	mi := &file_review_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateReviewRequest) ProtoMessage() {}
+
+func (x *ModerateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateReviewRequest.ProtoReflect.Descriptor instead.
+func (*ModerateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModerateReviewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Fit feedback collected from approved reviews and returns
+type FitSummary struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RunsSmall  int32                  `protobuf:"varint,1,opt,name=runs_small,json=runsSmall,proto3" json:"runs_small,omitempty"`
+	TrueToSize int32                  `protobuf:"varint,2,opt,name=true_to_size,json=trueToSize,proto3" json:"true_to_size,omitempty"`
+	RunsLarge  int32                  `protobuf:"varint,3,opt,name=runs_large,json=runsLarge,proto3" json:"runs_large,omitempty"`
+	// RUNS_SMALL, TRUE_TO_SIZE or RUNS_LARGE; empty without feedback
+	Verdict       string `protobuf:"bytes,4,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FitSummary) Reset() {
+	*x = FitSummary{}
+	mi := &file_review_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FitSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FitSummary) ProtoMessage() {}
+
+func (x *FitSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FitSummary.ProtoReflect.Descriptor instead.
+func (*FitSummary) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FitSummary) GetRunsSmall() int32 {
+	if x != nil {
+		return x.RunsSmall
+	}
+	return 0
+}
+
+func (x *FitSummary) GetTrueToSize() int32 {
+	if x != nil {
+		return x.TrueToSize
+	}
+	return 0
+}
+
+func (x *FitSummary) GetRunsLarge() int32 {
+	if x != nil {
+		return x.RunsLarge
+	}
+	return 0
+}
+
+func (x *FitSummary) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+type RecommendSizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,3,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendSizeRequest) Reset() {
+	*x = RecommendSizeRequest{}
+	mi := &file_review_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendSizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendSizeRequest) ProtoMessage() {}
+
+func (x *RecommendSizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendSizeRequest.ProtoReflect.Descriptor instead.
+func (*RecommendSizeRequest) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecommendSizeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecommendSizeRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RecommendSizeRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type RecommendSizeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of Product.sizes in size_system; empty when the user has no kept purchases with a size
+	Size          string      `protobuf:"bytes,1,opt,name=size,proto3" json:"size,omitempty"`
+	Personalized  bool        `protobuf:"varint,2,opt,name=personalized,proto3" json:"personalized,omitempty"`
+	PurchasesUsed int32       `protobuf:"varint,3,opt,name=purchases_used,json=purchasesUsed,proto3" json:"purchases_used,omitempty"`
+	Fit           *FitSummary `protobuf:"bytes,4,opt,name=fit,proto3" json:"fit,omitempty"`
+	SizeSystem    string      `protobuf:"bytes,5,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendSizeResponse) Reset() {
+	*x = RecommendSizeResponse{}
+	mi := &file_review_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendSizeResponse) ProtoMessage() {}
+
+func (x *RecommendSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_review_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendSizeResponse.ProtoReflect.Descriptor instead.
+func (*RecommendSizeResponse) Descriptor() ([]byte, []int) {
+	return file_review_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RecommendSizeResponse) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *RecommendSizeResponse) GetPersonalized() bool {
+	if x != nil {
+		return x.Personalized
+	}
+	return false
+}
+
+func (x *RecommendSizeResponse) GetPurchasesUsed() int32 {
+	if x != nil {
+		return x.PurchasesUsed
+	}
+	return 0
+}
+
+func (x *RecommendSizeResponse) GetFit() *FitSummary {
+	if x != nil {
+		return x.Fit
+	}
+	return nil
+}
+
+func (x *RecommendSizeResponse) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+var File_review_proto protoreflect.FileDescriptor
+
+const file_review_proto_rawDesc = "" +
+	"\n" +
+	"\freview.proto\x12\x05proto\"\xdd\x02\n" +
+	"\x06Review\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\x05R\x06rating\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x12\n" +
+	"\x04size\x18\a \x01(\tR\x04size\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12'\n" +
+	"\x0fmoderation_note\x18\t \x01(\tR\x0emoderationNote\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\x12\x10\n" +
+	"\x03fit\x18\f \x01(\tR\x03fit\x12\x1f\n" +
+	"\vsize_system\x18\r \x01(\tR\n" +
+	"sizeSystem\"<\n" +
+	"\x13CreateReviewRequest\x12%\n" +
+	"\x06review\x18\x01 \x01(\v2\r.proto.ReviewR\x06review\"7\n" +
+	"\x0eReviewResponse\x12%\n" +
+	"\x06review\x18\x01 \x01(\v2\r.proto.ReviewR\x06review\"\xb3\x01\n" +
+	"\x12ListReviewsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1f\n" +
+	"\vsize_system\x18\x06 \x01(\tR\n" +
+	"sizeSystem\"T\n" +
+	"\x13ListReviewsResponse\x12'\n" +
+	"\areviews\x18\x01 \x03(\v2\r.proto.ReviewR\areviews\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"S\n" +
+	"\x15ModerateReviewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\x86\x01\n" +
+	"\n" +
+	"FitSummary\x12\x1d\n" +
+	"\n" +
+	"runs_small\x18\x01 \x01(\x05R\trunsSmall\x12 \n" +
+	"\ftrue_to_size\x18\x02 \x01(\x05R\n" +
+	"trueToSize\x12\x1d\n" +
+	"\n" +
+	"runs_large\x18\x03 \x01(\x05R\trunsLarge\x12\x18\n" +
+	"\averdict\x18\x04 \x01(\tR\averdict\"o\n" +
+	"\x14RecommendSizeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vsize_system\x18\x03 \x01(\tR\n" +
+	"sizeSystem\"\xbc\x01\n" +
+	"\x15RecommendSizeResponse\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\tR\x04size\x12\"\n" +
+	"\fpersonalized\x18\x02 \x01(\bR\fpersonalized\x12%\n" +
+	"\x0epurchases_used\x18\x03 \x01(\x05R\rpurchasesUsed\x12#\n" +
+	"\x03fit\x18\x04 \x01(\v2\x11.proto.FitSummaryR\x03fit\x12\x1f\n" +
+	"\vsize_system\x18\x05 \x01(\tR\n" +
+	"sizeSystem2\xab\x02\n" +
+	"\rReviewService\x12A\n" +
+	"\fCreateReview\x12\x1a.proto.CreateReviewRequest\x1a\x15.proto.ReviewResponse\x12D\n" +
+	"\vListReviews\x12\x19.proto.ListReviewsRequest\x1a\x1a.proto.ListReviewsResponse\x12E\n" +
+	"\x0eModerateReview\x12\x1c.proto.ModerateReviewRequest\x1a\x15.proto.ReviewResponse\x12J\n" +
+	"\rRecommendSize\x12\x1b.proto.RecommendSizeRequest\x1a\x1c.proto.RecommendSizeResponseB\x10Z\x0eshoeshop/protob\x06proto3"
+
+var (
+	file_review_proto_rawDescOnce sync.Once
+	file_review_proto_rawDescData []byte
+)
+
+func file_review_proto_rawDescGZIP() []byte {
+	file_review_proto_rawDescOnce.Do(func() {
+		file_review_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_review_proto_rawDesc), len(file_review_proto_rawDesc)))
+	})
+	return file_review_proto_rawDescData
+}
+
+var file_review_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_review_proto_goTypes = []any{
+	(*Review)(nil),                // 0: proto.Review
+	(*CreateReviewRequest)(nil),   // 1: proto.CreateReviewRequest
+	(*ReviewResponse)(nil),        // 2: proto.ReviewResponse
+	(*ListReviewsRequest)(nil),    // 3: proto.ListReviewsRequest
+	(*ListReviewsResponse)(nil),   // 4: proto.ListReviewsResponse
+	(*ModerateReviewRequest)(nil), // 5: proto.ModerateReviewRequest
+	(*FitSummary)(nil),            // 6: proto.FitSummary
+	(*RecommendSizeRequest)(nil),  // 7: proto.RecommendSizeRequest
+	(*RecommendSizeResponse)(nil), // 8: proto.RecommendSizeResponse
+}
+var file_review_proto_depIdxs = []int32{
+	0, // 0: proto.CreateReviewRequest.review:type_name -> proto.Review
+	0, // 1: proto.ReviewResponse.review:type_name -> proto.Review
+	0, // 2: proto.ListReviewsResponse.reviews:type_name -> proto.Review
+	6, // 3: proto.RecommendSizeResponse.fit:type_name -> proto.FitSummary
+	1, // 4: proto.ReviewService.CreateReview:input_type -> proto.CreateReviewRequest
+	3, // 5: proto.ReviewService.ListReviews:input_type -> proto.ListReviewsRequest
+	5, // 6: proto.ReviewService.ModerateReview:input_type -> proto.ModerateReviewRequest
+	7, // 7: proto.ReviewService.RecommendSize:input_type -> proto.RecommendSizeRequest
+	2, // 8: proto.ReviewService.CreateReview:output_type -> proto.ReviewResponse
+	4, // 9: proto.ReviewService.ListReviews:output_type -> proto.ListReviewsResponse
+	2, // 10: proto.ReviewService.ModerateReview:output_type -> proto.ReviewResponse
+	8, // 11: proto.ReviewService.RecommendSize:output_type -> proto.RecommendSizeResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_review_proto_init() }
+func file_review_proto_init() {
+	if File_review_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_review_proto_rawDesc), len(file_review_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_review_proto_goTypes,
+		DependencyIndexes: file_review_proto_depIdxs,
+		MessageInfos:      file_review_proto_msgTypes,
+	}.Build()
+	File_review_proto = out.File
+	file_review_proto_goTypes = nil
+	file_review_proto_depIdxs = nil
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -37,8 +38,11 @@ type User struct {
 	OrderIds         []string               `protobuf:"bytes,12,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
 	IsAdmin          bool                   `protobuf:"varint,13,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
 	Balance          float64                `protobuf:"fixed64,14,opt,name=balance,proto3" json:"balance,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Incremented on every write. Updates must send the version they read and
+	// only succeed if the user is still at that version
+	Version       int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -169,6 +173,13 @@ func (x *User) GetBalance() float64 {
 	return 0
 }
 
+func (x *User) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type RegisterRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Username        string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -454,8 +465,11 @@ func (x *GetUserByEmailRequest) GetEmail() string {
 }
 
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	User  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// Fields to update, by proto field name. Without a mask every field
+	// is overwritten
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -497,6 +511,13 @@ func (x *UpdateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -722,7 +743,7 @@ var File_user_proto protoreflect.FileDescriptor
 const file_user_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"user.proto\x12\x05proto\"\xa4\x03\n" +
+	"user.proto\x12\x05proto\x1a google/protobuf/field_mask.proto\"\xbe\x03\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -740,7 +761,8 @@ const file_user_proto_rawDesc = "" +
 	"\x11registration_date\x18\v \x01(\tR\x10registrationDate\x12\x1b\n" +
 	"\torder_ids\x18\f \x03(\tR\borderIds\x12\x19\n" +
 	"\bis_admin\x18\r \x01(\bR\aisAdmin\x12\x18\n" +
-	"\abalance\x18\x0e \x01(\x01R\abalance\"\xd9\x01\n" +
+	"\abalance\x18\x0e \x01(\x01R\abalance\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\"\xd9\x01\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
@@ -758,9 +780,11 @@ const file_user_proto_rawDesc = "" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
 	"\x15GetUserByEmailRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"4\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"q\n" +
 	"\x11UpdateUserRequest\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\"/\n" +
+	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"/\n" +
 	"\fUserResponse\x12\x1f\n" +
 	"\x04user\x18\x01 \x01(\v2\v.proto.UserR\x04user\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
@@ -808,30 +832,32 @@ var file_user_proto_goTypes = []any{
 	(*DeleteUserResponse)(nil),    // 9: proto.DeleteUserResponse
 	(*ResetPasswordRequest)(nil),  // 10: proto.ResetPasswordRequest
 	(*ResetPasswordResponse)(nil), // 11: proto.ResetPasswordResponse
+	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
 }
 var file_user_proto_depIdxs = []int32{
 	0,  // 0: proto.LoginResponse.user:type_name -> proto.User
 	0,  // 1: proto.UpdateUserRequest.user:type_name -> proto.User
-	0,  // 2: proto.UserResponse.user:type_name -> proto.User
-	1,  // 3: proto.UserService.Register:input_type -> proto.RegisterRequest
-	2,  // 4: proto.UserService.Login:input_type -> proto.LoginRequest
-	4,  // 5: proto.UserService.GetUser:input_type -> proto.GetUserRequest
-	5,  // 6: proto.UserService.GetUserByEmail:input_type -> proto.GetUserByEmailRequest
-	6,  // 7: proto.UserService.UpdateUser:input_type -> proto.UpdateUserRequest
-	8,  // 8: proto.UserService.DeleteUser:input_type -> proto.DeleteUserRequest
-	10, // 9: proto.UserService.ResetPassword:input_type -> proto.ResetPasswordRequest
-	7,  // 10: proto.UserService.Register:output_type -> proto.UserResponse
-	3,  // 11: proto.UserService.Login:output_type -> proto.LoginResponse
-	7,  // 12: proto.UserService.GetUser:output_type -> proto.UserResponse
-	7,  // 13: proto.UserService.GetUserByEmail:output_type -> proto.UserResponse
-	7,  // 14: proto.UserService.UpdateUser:output_type -> proto.UserResponse
-	9,  // 15: proto.UserService.DeleteUser:output_type -> proto.DeleteUserResponse
-	11, // 16: proto.UserService.ResetPassword:output_type -> proto.ResetPasswordResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	12, // 2: proto.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 3: proto.UserResponse.user:type_name -> proto.User
+	1,  // 4: proto.UserService.Register:input_type -> proto.RegisterRequest
+	2,  // 5: proto.UserService.Login:input_type -> proto.LoginRequest
+	4,  // 6: proto.UserService.GetUser:input_type -> proto.GetUserRequest
+	5,  // 7: proto.UserService.GetUserByEmail:input_type -> proto.GetUserByEmailRequest
+	6,  // 8: proto.UserService.UpdateUser:input_type -> proto.UpdateUserRequest
+	8,  // 9: proto.UserService.DeleteUser:input_type -> proto.DeleteUserRequest
+	10, // 10: proto.UserService.ResetPassword:input_type -> proto.ResetPasswordRequest
+	7,  // 11: proto.UserService.Register:output_type -> proto.UserResponse
+	3,  // 12: proto.UserService.Login:output_type -> proto.LoginResponse
+	7,  // 13: proto.UserService.GetUser:output_type -> proto.UserResponse
+	7,  // 14: proto.UserService.GetUserByEmail:output_type -> proto.UserResponse
+	7,  // 15: proto.UserService.UpdateUser:output_type -> proto.UserResponse
+	9,  // 16: proto.UserService.DeleteUser:output_type -> proto.DeleteUserResponse
+	11, // 17: proto.UserService.ResetPassword:output_type -> proto.ResetPasswordResponse
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -0,0 +1,844 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: wishlist.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WishlistItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Preferred size in size_system; empty when not chosen
+	Size string `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Current price of the product
+	Price float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Price when the item was saved
+	PriceAtAdd    float64 `protobuf:"fixed64,5,opt,name=price_at_add,json=priceAtAdd,proto3" json:"price_at_add,omitempty"`
+	AddedAt       string  `protobuf:"bytes,6,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistItem) Reset() {
+	*x = WishlistItem{}
+	mi := &file_wishlist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistItem) ProtoMessage() {}
+
+func (x *WishlistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistItem.ProtoReflect.Descriptor instead.
+func (*WishlistItem) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WishlistItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *WishlistItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WishlistItem) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *WishlistItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *WishlistItem) GetPriceAtAdd() float64 {
+	if x != nil {
+		return x.PriceAtAdd
+	}
+	return 0
+}
+
+func (x *WishlistItem) GetAddedAt() string {
+	if x != nil {
+		return x.AddedAt
+	}
+	return ""
+}
+
+type Wishlist struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*WishlistItem        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Size system of item sizes: EU (default), US_M, US_W, UK or CM
+	SizeSystem string `protobuf:"bytes,3,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	// Empty until the wishlist is shared
+	ShareToken    string `protobuf:"bytes,4,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wishlist) Reset() {
+	*x = Wishlist{}
+	mi := &file_wishlist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wishlist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wishlist) ProtoMessage() {}
+
+func (x *Wishlist) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wishlist.ProtoReflect.Descriptor instead.
+func (*Wishlist) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Wishlist) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Wishlist) GetItems() []*WishlistItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Wishlist) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *Wishlist) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *Wishlist) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type WishlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wishlist      *Wishlist              `protobuf:"bytes,1,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistResponse) Reset() {
+	*x = WishlistResponse{}
+	mi := &file_wishlist_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistResponse) ProtoMessage() {}
+
+func (x *WishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistResponse.ProtoReflect.Descriptor instead.
+func (*WishlistResponse) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WishlistResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+type AddWishlistItemRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Optional preferred size in size_system
+	Size          string `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	SizeSystem    string `protobuf:"bytes,4,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWishlistItemRequest) Reset() {
+	*x = AddWishlistItemRequest{}
+	mi := &file_wishlist_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWishlistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWishlistItemRequest) ProtoMessage() {}
+
+func (x *AddWishlistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWishlistItemRequest.ProtoReflect.Descriptor instead.
+func (*AddWishlistItemRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddWishlistItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddWishlistItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddWishlistItemRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *AddWishlistItemRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type RemoveWishlistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,3,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWishlistItemRequest) Reset() {
+	*x = RemoveWishlistItemRequest{}
+	mi := &file_wishlist_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWishlistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWishlistItemRequest) ProtoMessage() {}
+
+func (x *RemoveWishlistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWishlistItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWishlistItemRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoveWishlistItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveWishlistItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RemoveWishlistItemRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type GetWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWishlistRequest) Reset() {
+	*x = GetWishlistRequest{}
+	mi := &file_wishlist_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWishlistRequest) ProtoMessage() {}
+
+func (x *GetWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWishlistRequest.ProtoReflect.Descriptor instead.
+func (*GetWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetWishlistRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type ShareWishlistRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Replaces the current token so old links stop working
+	Regenerate    bool `protobuf:"varint,2,opt,name=regenerate,proto3" json:"regenerate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareWishlistRequest) Reset() {
+	*x = ShareWishlistRequest{}
+	mi := &file_wishlist_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareWishlistRequest) ProtoMessage() {}
+
+func (x *ShareWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareWishlistRequest.ProtoReflect.Descriptor instead.
+func (*ShareWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShareWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareWishlistRequest) GetRegenerate() bool {
+	if x != nil {
+		return x.Regenerate
+	}
+	return false
+}
+
+type ShareWishlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareWishlistResponse) Reset() {
+	*x = ShareWishlistResponse{}
+	mi := &file_wishlist_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareWishlistResponse) ProtoMessage() {}
+
+func (x *ShareWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareWishlistResponse.ProtoReflect.Descriptor instead.
+func (*ShareWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ShareWishlistResponse) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+type GetSharedWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	SizeSystem    string                 `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedWishlistRequest) Reset() {
+	*x = GetSharedWishlistRequest{}
+	mi := &file_wishlist_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedWishlistRequest) ProtoMessage() {}
+
+func (x *GetSharedWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedWishlistRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSharedWishlistRequest) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *GetSharedWishlistRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type MoveToCartRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Overrides the preferred size; required when the item has none
+	Size          string `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	SizeSystem    string `protobuf:"bytes,4,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCartRequest) Reset() {
+	*x = MoveToCartRequest{}
+	mi := &file_wishlist_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartRequest) ProtoMessage() {}
+
+func (x *MoveToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartRequest.ProtoReflect.Descriptor instead.
+func (*MoveToCartRequest) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveToCartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+type MoveToCartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cart line priced at the current price, quantity 1
+	Item *OrderItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// Size system of item.size
+	SizeSystem    string    `protobuf:"bytes,2,opt,name=size_system,json=sizeSystem,proto3" json:"size_system,omitempty"`
+	Wishlist      *Wishlist `protobuf:"bytes,3,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCartResponse) Reset() {
+	*x = MoveToCartResponse{}
+	mi := &file_wishlist_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartResponse) ProtoMessage() {}
+
+func (x *MoveToCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wishlist_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartResponse.ProtoReflect.Descriptor instead.
+func (*MoveToCartResponse) Descriptor() ([]byte, []int) {
+	return file_wishlist_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MoveToCartResponse) GetItem() *OrderItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *MoveToCartResponse) GetSizeSystem() string {
+	if x != nil {
+		return x.SizeSystem
+	}
+	return ""
+}
+
+func (x *MoveToCartResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+var File_wishlist_proto protoreflect.FileDescriptor
+
+const file_wishlist_proto_rawDesc = "" +
+	"\n" +
+	"\x0ewishlist.proto\x12\x05proto\x1a\vorder.proto\"\xa8\x01\n" +
+	"\fWishlistItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12 \n" +
+	"\fprice_at_add\x18\x05 \x01(\x01R\n" +
+	"priceAtAdd\x12\x19\n" +
+	"\badded_at\x18\x06 \x01(\tR\aaddedAt\"\xaf\x01\n" +
+	"\bWishlist\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x05items\x18\x02 \x03(\v2\x13.proto.WishlistItemR\x05items\x12\x1f\n" +
+	"\vsize_system\x18\x03 \x01(\tR\n" +
+	"sizeSystem\x12\x1f\n" +
+	"\vshare_token\x18\x04 \x01(\tR\n" +
+	"shareToken\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"?\n" +
+	"\x10WishlistResponse\x12+\n" +
+	"\bwishlist\x18\x01 \x01(\v2\x0f.proto.WishlistR\bwishlist\"\x85\x01\n" +
+	"\x16AddWishlistItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x1f\n" +
+	"\vsize_system\x18\x04 \x01(\tR\n" +
+	"sizeSystem\"t\n" +
+	"\x19RemoveWishlistItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vsize_system\x18\x03 \x01(\tR\n" +
+	"sizeSystem\"N\n" +
+	"\x12GetWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\"O\n" +
+	"\x14ShareWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"regenerate\x18\x02 \x01(\bR\n" +
+	"regenerate\"8\n" +
+	"\x15ShareWishlistResponse\x12\x1f\n" +
+	"\vshare_token\x18\x01 \x01(\tR\n" +
+	"shareToken\"\\\n" +
+	"\x18GetSharedWishlistRequest\x12\x1f\n" +
+	"\vshare_token\x18\x01 \x01(\tR\n" +
+	"shareToken\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\"\x80\x01\n" +
+	"\x11MoveToCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x1f\n" +
+	"\vsize_system\x18\x04 \x01(\tR\n" +
+	"sizeSystem\"\x88\x01\n" +
+	"\x12MoveToCartResponse\x12$\n" +
+	"\x04item\x18\x01 \x01(\v2\x10.proto.OrderItemR\x04item\x12\x1f\n" +
+	"\vsize_system\x18\x02 \x01(\tR\n" +
+	"sizeSystem\x12+\n" +
+	"\bwishlist\x18\x03 \x01(\v2\x0f.proto.WishlistR\bwishlist2\xce\x03\n" +
+	"\x0fWishlistService\x12I\n" +
+	"\x0fAddWishlistItem\x12\x1d.proto.AddWishlistItemRequest\x1a\x17.proto.WishlistResponse\x12O\n" +
+	"\x12RemoveWishlistItem\x12 .proto.RemoveWishlistItemRequest\x1a\x17.proto.WishlistResponse\x12A\n" +
+	"\vGetWishlist\x12\x19.proto.GetWishlistRequest\x1a\x17.proto.WishlistResponse\x12J\n" +
+	"\rShareWishlist\x12\x1b.proto.ShareWishlistRequest\x1a\x1c.proto.ShareWishlistResponse\x12M\n" +
+	"\x11GetSharedWishlist\x12\x1f.proto.GetSharedWishlistRequest\x1a\x17.proto.WishlistResponse\x12A\n" +
+	"\n" +
+	"MoveToCart\x12\x18.proto.MoveToCartRequest\x1a\x19.proto.MoveToCartResponseB\x10Z\x0eshoeshop/protob\x06proto3"
+
+var (
+	file_wishlist_proto_rawDescOnce sync.Once
+	file_wishlist_proto_rawDescData []byte
+)
+
+func file_wishlist_proto_rawDescGZIP() []byte {
+	file_wishlist_proto_rawDescOnce.Do(func() {
+		file_wishlist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wishlist_proto_rawDesc), len(file_wishlist_proto_rawDesc)))
+	})
+	return file_wishlist_proto_rawDescData
+}
+
+var file_wishlist_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_wishlist_proto_goTypes = []any{
+	(*WishlistItem)(nil),              // 0: proto.WishlistItem
+	(*Wishlist)(nil),                  // 1: proto.Wishlist
+	(*WishlistResponse)(nil),          // 2: proto.WishlistResponse
+	(*AddWishlistItemRequest)(nil),    // 3: proto.AddWishlistItemRequest
+	(*RemoveWishlistItemRequest)(nil), // 4: proto.RemoveWishlistItemRequest
+	(*GetWishlistRequest)(nil),        // 5: proto.GetWishlistRequest
+	(*ShareWishlistRequest)(nil),      // 6: proto.ShareWishlistRequest
+	(*ShareWishlistResponse)(nil),     // 7: proto.ShareWishlistResponse
+	(*GetSharedWishlistRequest)(nil),  // 8: proto.GetSharedWishlistRequest
+	(*MoveToCartRequest)(nil),         // 9: proto.MoveToCartRequest
+	(*MoveToCartResponse)(nil),        // 10: proto.MoveToCartResponse
+	(*OrderItem)(nil),                 // 11: proto.OrderItem
+}
+var file_wishlist_proto_depIdxs = []int32{
+	0,  // 0: proto.Wishlist.items:type_name -> proto.WishlistItem
+	1,  // 1: proto.WishlistResponse.wishlist:type_name -> proto.Wishlist
+	11, // 2: proto.MoveToCartResponse.item:type_name -> proto.OrderItem
+	1,  // 3: proto.MoveToCartResponse.wishlist:type_name -> proto.Wishlist
+	3,  // 4: proto.WishlistService.AddWishlistItem:input_type -> proto.AddWishlistItemRequest
+	4,  // 5: proto.WishlistService.RemoveWishlistItem:input_type -> proto.RemoveWishlistItemRequest
+	5,  // 6: proto.WishlistService.GetWishlist:input_type -> proto.GetWishlistRequest
+	6,  // 7: proto.WishlistService.ShareWishlist:input_type -> proto.ShareWishlistRequest
+	8,  // 8: proto.WishlistService.GetSharedWishlist:input_type -> proto.GetSharedWishlistRequest
+	9,  // 9: proto.WishlistService.MoveToCart:input_type -> proto.MoveToCartRequest
+	2,  // 10: proto.WishlistService.AddWishlistItem:output_type -> proto.WishlistResponse
+	2,  // 11: proto.WishlistService.RemoveWishlistItem:output_type -> proto.WishlistResponse
+	2,  // 12: proto.WishlistService.GetWishlist:output_type -> proto.WishlistResponse
+	7,  // 13: proto.WishlistService.ShareWishlist:output_type -> proto.ShareWishlistResponse
+	2,  // 14: proto.WishlistService.GetSharedWishlist:output_type -> proto.WishlistResponse
+	10, // 15: proto.WishlistService.MoveToCart:output_type -> proto.MoveToCartResponse
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_wishlist_proto_init() }
+func file_wishlist_proto_init() {
+	if File_wishlist_proto != nil {
+		return
+	}
+	file_order_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wishlist_proto_rawDesc), len(file_wishlist_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wishlist_proto_goTypes,
+		DependencyIndexes: file_wishlist_proto_depIdxs,
+		MessageInfos:      file_wishlist_proto_msgTypes,
+	}.Build()
+	File_wishlist_proto = out.File
+	file_wishlist_proto_goTypes = nil
+	file_wishlist_proto_depIdxs = nil
+}
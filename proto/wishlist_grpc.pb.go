@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v6.30.2
+// source: wishlist.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WishlistServiceClient is the client API for WishlistService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WishlistServiceClient interface {
+	AddWishlistItem(ctx context.Context, in *AddWishlistItemRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	RemoveWishlistItem(ctx context.Context, in *RemoveWishlistItemRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	GetWishlist(ctx context.Context, in *GetWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	ShareWishlist(ctx context.Context, in *ShareWishlistRequest, opts ...grpc.CallOption) (*ShareWishlistResponse, error)
+	GetSharedWishlist(ctx context.Context, in *GetSharedWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error)
+}
+
+type wishlistServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWishlistServiceClient(cc grpc.ClientConnInterface) WishlistServiceClient {
+	return &wishlistServiceClient{cc}
+}
+
+func (c *wishlistServiceClient) AddWishlistItem(ctx context.Context, in *AddWishlistItemRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/AddWishlistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wishlistServiceClient) RemoveWishlistItem(ctx context.Context, in *RemoveWishlistItemRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/RemoveWishlistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wishlistServiceClient) GetWishlist(ctx context.Context, in *GetWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/GetWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wishlistServiceClient) ShareWishlist(ctx context.Context, in *ShareWishlistRequest, opts ...grpc.CallOption) (*ShareWishlistResponse, error) {
+	out := new(ShareWishlistResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/ShareWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wishlistServiceClient) GetSharedWishlist(ctx context.Context, in *GetSharedWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/GetSharedWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wishlistServiceClient) MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error) {
+	out := new(MoveToCartResponse)
+	err := c.cc.Invoke(ctx, "/proto.WishlistService/MoveToCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WishlistServiceServer is the server API for WishlistService service.
+// All implementations must embed UnimplementedWishlistServiceServer
+// for forward compatibility
+type WishlistServiceServer interface {
+	AddWishlistItem(context.Context, *AddWishlistItemRequest) (*WishlistResponse, error)
+	RemoveWishlistItem(context.Context, *RemoveWishlistItemRequest) (*WishlistResponse, error)
+	GetWishlist(context.Context, *GetWishlistRequest) (*WishlistResponse, error)
+	ShareWishlist(context.Context, *ShareWishlistRequest) (*ShareWishlistResponse, error)
+	GetSharedWishlist(context.Context, *GetSharedWishlistRequest) (*WishlistResponse, error)
+	MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error)
+	mustEmbedUnimplementedWishlistServiceServer()
+}
+
+// UnimplementedWishlistServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWishlistServiceServer struct {
+}
+
+func (UnimplementedWishlistServiceServer) AddWishlistItem(context.Context, *AddWishlistItemRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWishlistItem not implemented")
+}
+func (UnimplementedWishlistServiceServer) RemoveWishlistItem(context.Context, *RemoveWishlistItemRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWishlistItem not implemented")
+}
+func (UnimplementedWishlistServiceServer) GetWishlist(context.Context, *GetWishlistRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWishlist not implemented")
+}
+func (UnimplementedWishlistServiceServer) ShareWishlist(context.Context, *ShareWishlistRequest) (*ShareWishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareWishlist not implemented")
+}
+func (UnimplementedWishlistServiceServer) GetSharedWishlist(context.Context, *GetSharedWishlistRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedWishlist not implemented")
+}
+func (UnimplementedWishlistServiceServer) MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveToCart not implemented")
+}
+func (UnimplementedWishlistServiceServer) mustEmbedUnimplementedWishlistServiceServer() {}
+
+// UnsafeWishlistServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WishlistServiceServer will
+// result in compilation errors.
+type UnsafeWishlistServiceServer interface {
+	mustEmbedUnimplementedWishlistServiceServer()
+}
+
+func RegisterWishlistServiceServer(s grpc.ServiceRegistrar, srv WishlistServiceServer) {
+	s.RegisterService(&WishlistService_ServiceDesc, srv)
+}
+
+func _WishlistService_AddWishlistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWishlistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).AddWishlistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/AddWishlistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).AddWishlistItem(ctx, req.(*AddWishlistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WishlistService_RemoveWishlistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWishlistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).RemoveWishlistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/RemoveWishlistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).RemoveWishlistItem(ctx, req.(*RemoveWishlistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WishlistService_GetWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).GetWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/GetWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).GetWishlist(ctx, req.(*GetWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WishlistService_ShareWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).ShareWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/ShareWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).ShareWishlist(ctx, req.(*ShareWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WishlistService_GetSharedWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).GetSharedWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/GetSharedWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).GetSharedWishlist(ctx, req.(*GetSharedWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WishlistService_MoveToCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WishlistServiceServer).MoveToCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WishlistService/MoveToCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WishlistServiceServer).MoveToCart(ctx, req.(*MoveToCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WishlistService_ServiceDesc is the grpc.ServiceDesc for WishlistService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WishlistService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.WishlistService",
+	HandlerType: (*WishlistServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddWishlistItem",
+			Handler:    _WishlistService_AddWishlistItem_Handler,
+		},
+		{
+			MethodName: "RemoveWishlistItem",
+			Handler:    _WishlistService_RemoveWishlistItem_Handler,
+		},
+		{
+			MethodName: "GetWishlist",
+			Handler:    _WishlistService_GetWishlist_Handler,
+		},
+		{
+			MethodName: "ShareWishlist",
+			Handler:    _WishlistService_ShareWishlist_Handler,
+		},
+		{
+			MethodName: "GetSharedWishlist",
+			Handler:    _WishlistService_GetSharedWishlist_Handler,
+		},
+		{
+			MethodName: "MoveToCart",
+			Handler:    _WishlistService_MoveToCart_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "wishlist.proto",
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+
+	pb "shoeshop/proto"
+	"shoeshop/review-service/internal/handler"
+	"shoeshop/review-service/internal/repository"
+	"shoeshop/review-service/internal/service"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// Инициализация MongoDB репозитория
+	repo, err := repository.NewMongoRepository("mongodb://localhost:27017")
+	if err != nil {
+		log.Fatalf("Failed to create MongoDB repository: %v", err)
+	}
+
+	// Инициализация NATS для событий
+	natsClient, err := repository.NewNatsClient("nats://localhost:4222")
+	if err != nil {
+		log.Fatalf("Failed to connect to NATS: %v", err)
+	}
+
+	// Подключение к Product Service
+	productConn, err := grpc.Dial("localhost:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to product service: %v", err)
+	}
+	defer productConn.Close()
+	productClient := pb.NewProductServiceClient(productConn)
+
+	// Подключение к Order Service для проверки покупок
+	orderConn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer orderConn.Close()
+	orderClient := pb.NewOrderServiceClient(orderConn)
+
+	// Инициализация сервиса
+	svc := service.NewReviewService(repo, natsClient, productClient, orderClient)
+
+	// Отзывы о посадке из возвратов
+	if err := svc.StartFitSync(natsClient); err != nil {
+		log.Fatalf("Failed to start fit feedback sync: %v", err)
+	}
+
+	// Инициализация gRPC handler
+	grpcHandler := handler.NewGRPCHandler(svc)
+
+	// Создание gRPC сервера
+	server := grpc.NewServer()
+	pb.RegisterReviewServiceServer(server, grpcHandler)
+
+	// Включаем reflection для отладки
+	reflection.Register(server)
+
+	// Запуск gRPC сервера
+	port := ":50055"
+	lis, err := net.Listen("tcp", port)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	// Канал для graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		log.Printf("Starting Review service on port %s", port)
+		if err := server.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
+	}()
+
+	// Ожидание сигнала для graceful shutdown
+	sig := <-sigChan
+	fmt.Printf("\nReceived signal %v, initiating graceful shutdown\n", sig)
+
+	// Graceful shutdown
+	server.GracefulStop()
+
+	// Закрываем соединения
+	if err := natsClient.Close(); err != nil {
+		log.Printf("Error closing NATS connection: %v", err)
+	}
+
+	log.Println("Server stopped gracefully")
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "shoeshop/proto"
+	"shoeshop/review-service/internal/model"
+	"shoeshop/review-service/internal/repository"
+	"shoeshop/review-service/internal/service"
+	"shoeshop/sizing"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedReviewServiceServer
+	reviewService service.ReviewService
+}
+
+func NewGRPCHandler(reviewService service.ReviewService) *GRPCHandler {
+	return &GRPCHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *GRPCHandler) CreateReview(ctx context.Context, req *pb.CreateReviewRequest) (*pb.ReviewResponse, error) {
+	review := req.GetReview()
+	system, err := sizing.ParseSystem(review.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	createdReview, err := h.reviewService.CreateReview(
+		ctx,
+		review.GetProductId(),
+		review.GetUserId(),
+		review.GetRating(),
+		review.GetText(),
+		review.GetSize(),
+		system,
+		model.Fit(review.GetFit()),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidReview):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, service.ErrNotPurchased):
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		case errors.Is(err, service.ErrDuplicateReview):
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to create review: %v", err)
+		}
+	}
+
+	return &pb.ReviewResponse{
+		Review: createdReview.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	reviews, total, err := h.reviewService.ListReviews(ctx, repository.ReviewFilter{
+		ProductID: req.GetProductId(),
+		UserID:    req.GetUserId(),
+		Status:    model.ReviewStatus(req.GetStatus()),
+		Limit:     int64(req.GetLimit()),
+		Offset:    int64(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list reviews: %v", err)
+	}
+
+	pbReviews := make([]*pb.Review, len(reviews))
+	for i, review := range reviews {
+		pbReviews[i] = review.ToProto(system)
+	}
+
+	return &pb.ListReviewsResponse{
+		Reviews: pbReviews,
+		Total:   total,
+	}, nil
+}
+
+func (h *GRPCHandler) ModerateReview(ctx context.Context, req *pb.ModerateReviewRequest) (*pb.ReviewResponse, error) {
+	review, err := h.reviewService.ModerateReview(ctx, req.GetId(), model.ReviewStatus(req.GetStatus()), req.GetNote())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidModeration):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, mongo.ErrNoDocuments):
+			return nil, status.Error(codes.NotFound, "review not found")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to moderate review: %v", err)
+		}
+	}
+
+	return &pb.ReviewResponse{
+		Review: review.ToProto(sizing.DefaultSystem),
+	}, nil
+}
+
+func (h *GRPCHandler) RecommendSize(ctx context.Context, req *pb.RecommendSizeRequest) (*pb.RecommendSizeResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	recommendation, err := h.reviewService.RecommendSize(ctx, req.GetUserId(), req.GetProductId())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSizeQuery) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to recommend size: %v", err)
+	}
+
+	size := ""
+	if recommendation.Personalized {
+		size = sizing.WithOffset(recommendation.SizeOffset).Format(recommendation.Size, system)
+	}
+
+	return &pb.RecommendSizeResponse{
+		Size:          size,
+		Personalized:  recommendation.Personalized,
+		PurchasesUsed: recommendation.PurchasesUsed,
+		Fit:           recommendation.Fit.ToProto(),
+		SizeSystem:    string(system),
+	}, nil
+}
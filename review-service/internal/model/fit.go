@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+// Источники отзывов о посадке
+const (
+	FitSourceReview = "review"
+	FitSourceReturn = "return"
+)
+
+// FitFeedback - отзыв о посадке из одобренного отзыва или возврата.
+// ID строится из источника, поэтому повторное событие не создает дубликат
+type FitFeedback struct {
+	ID        string      `bson:"_id"`
+	ProductID string      `bson:"product_id"`
+	UserID    string      `bson:"user_id"`
+	Size      sizing.Size `bson:"size,omitempty"`
+	Fit       Fit         `bson:"fit"`
+	Source    string      `bson:"source"`
+	CreatedAt time.Time   `bson:"created_at"`
+}
+
+// FitSummary - количество отзывов о посадке товара по вариантам
+type FitSummary struct {
+	RunsSmall  int32
+	TrueToSize int32
+	RunsLarge  int32
+}
+
+// fitPrior - число воображаемых отзывов "в размер", которыми сглаживается
+// оценка, чтобы один-два голоса не сдвигали рекомендацию на целый размер
+const fitPrior = 3
+
+// Score возвращает смещение посадки от -1 (маломерит) до 1 (большемерит)
+func (s FitSummary) Score() float64 {
+	total := float64(s.RunsSmall + s.TrueToSize + s.RunsLarge + fitPrior)
+	return float64(s.RunsLarge-s.RunsSmall) / total
+}
+
+// Verdict возвращает преобладающую посадку или пустую строку без отзывов
+func (s FitSummary) Verdict() Fit {
+	if s.RunsSmall+s.TrueToSize+s.RunsLarge == 0 {
+		return ""
+	}
+	switch score := s.Score(); {
+	case score <= -1.0/3:
+		return FitRunsSmall
+	case score >= 1.0/3:
+		return FitRunsLarge
+	default:
+		return FitTrueToSize
+	}
+}
+
+func (s FitSummary) ToProto() *pb.FitSummary {
+	return &pb.FitSummary{
+		RunsSmall:  s.RunsSmall,
+		TrueToSize: s.TrueToSize,
+		RunsLarge:  s.RunsLarge,
+		Verdict:    string(s.Verdict()),
+	}
+}
+
+// SizeRecommendation - результат RecommendSize; размер выводится по таблице
+// бренда товара со смещением SizeOffset
+type SizeRecommendation struct {
+	Size          sizing.Size
+	SizeOffset    sizing.Size
+	Personalized  bool
+	PurchasesUsed int32
+	Fit           FitSummary
+}
+
+// ItemReturnedEvent - событие order.item_returned, которое публикует order-service
+type ItemReturnedEvent struct {
+	OrderID   string
+	UserID    string
+	ProductID string
+	Size      sizing.Size
+	Reason    string
+	Fit       Fit
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "PENDING"
+	ReviewStatusApproved ReviewStatus = "APPROVED"
+	ReviewStatusRejected ReviewStatus = "REJECTED"
+)
+
+func (s ReviewStatus) Valid() bool {
+	switch s {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+// Fit - отзыв покупателя о соответствии размера
+type Fit string
+
+const (
+	FitRunsSmall  Fit = "RUNS_SMALL"
+	FitTrueToSize Fit = "TRUE_TO_SIZE"
+	FitRunsLarge  Fit = "RUNS_LARGE"
+)
+
+func (f Fit) Valid() bool {
+	switch f {
+	case FitRunsSmall, FitTrueToSize, FitRunsLarge:
+		return true
+	}
+	return false
+}
+
+type Review struct {
+	ID             string       `bson:"_id"`
+	ProductID      string       `bson:"product_id"`
+	UserID         string       `bson:"user_id"`
+	OrderID        string       `bson:"order_id"`
+	Rating         int32        `bson:"rating"`
+	Text           string       `bson:"text"`
+	Size           sizing.Size  `bson:"size"`
+	Brand          string       `bson:"brand,omitempty"`
+	SizeOffset     *sizing.Size `bson:"size_offset,omitempty"`
+	Status         ReviewStatus `bson:"status"`
+	ModerationNote string       `bson:"moderation_note,omitempty"`
+	Fit            Fit          `bson:"fit,omitempty"`
+	CreatedAt      time.Time    `bson:"created_at"`
+	UpdatedAt      time.Time    `bson:"updated_at"`
+}
+
+// RatingSummary - агрегат одобренных отзывов о товаре
+type RatingSummary struct {
+	Average float64
+	Count   int32
+}
+
+// RatingChangedEvent - событие review.rating_changed для product-service
+type RatingChangedEvent struct {
+	ProductID string
+	Rating    float64
+	Count     int32
+}
+
+// SizeTable возвращает таблицу размеров бренда на момент отзыва. У старых
+// отзывов смещения нет - для них берется встроенная таблица бренда
+func (r *Review) SizeTable() *sizing.Table {
+	if r.SizeOffset != nil {
+		return sizing.WithOffset(*r.SizeOffset)
+	}
+	return sizing.TableFor(r.Brand)
+}
+
+// ToProto конвертирует доменную модель в protobuf модель,
+// размер выводится в указанной системе по таблице бренда товара
+func (r *Review) ToProto(system sizing.System) *pb.Review {
+	size := ""
+	if r.Size > 0 {
+		size = r.SizeTable().Format(r.Size, system)
+	}
+
+	return &pb.Review{
+		Id:             r.ID,
+		ProductId:      r.ProductID,
+		UserId:         r.UserID,
+		OrderId:        r.OrderID,
+		Rating:         r.Rating,
+		Text:           r.Text,
+		Size:           size,
+		Status:         string(r.Status),
+		ModerationNote: r.ModerationNote,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      r.UpdatedAt.Format(time.RFC3339),
+		Fit:            string(r.Fit),
+		SizeSystem:     string(system),
+	}
+}
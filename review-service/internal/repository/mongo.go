@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/review-service/internal/model"
+)
+
+// ReviewFilter - условия выборки отзывов; пустые поля не фильтруют
+type ReviewFilter struct {
+	ProductID string
+	UserID    string
+	Status    model.ReviewStatus
+	Limit     int64
+	Offset    int64
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *model.Review) (*model.Review, error)
+	GetByID(ctx context.Context, id string) (*model.Review, error)
+	List(ctx context.Context, filter ReviewFilter) ([]*model.Review, int64, error)
+	UpdateStatus(ctx context.Context, id string, status model.ReviewStatus, note string) (*model.Review, error)
+	Summary(ctx context.Context, productID string) (*model.RatingSummary, error)
+	SaveFit(ctx context.Context, feedback *model.FitFeedback) error
+	DeleteFit(ctx context.Context, id string) error
+	FitSummaries(ctx context.Context, productIDs []string) (map[string]model.FitSummary, error)
+}
+
+type mongoRepository struct {
+	client        *mongo.Client
+	collection    *mongo.Collection
+	fitCollection *mongo.Collection
+}
+
+func NewMongoRepository(uri string) (ReviewRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	collection := client.Database("shoeshop").Collection("reviews")
+
+	// Создаем индексы
+	indexes := []mongo.IndexModel{
+		{
+			// Один отзыв от пользователя на товар
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err = collection.Indexes().CreateMany(context.Background(), indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	fitCollection := client.Database("shoeshop").Collection("fit_feedback")
+	_, err = fitCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "product_id", Value: 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoRepository{
+		client:        client,
+		collection:    collection,
+		fitCollection: fitCollection,
+	}, nil
+}
+
+func (r *mongoRepository) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	now := time.Now()
+	review.CreatedAt = now
+	review.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (r *mongoRepository) GetByID(ctx context.Context, id string) (*model.Review, error) {
+	var review model.Review
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&review)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *mongoRepository) List(ctx context.Context, filter ReviewFilter) ([]*model.Review, int64, error) {
+	query := bson.M{}
+	if filter.ProductID != "" {
+		query["product_id"] = filter.ProductID
+	}
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(filter.Offset).
+		SetLimit(filter.Limit)
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []*model.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *mongoRepository) UpdateStatus(ctx context.Context, id string, status model.ReviewStatus, note string) (*model.Review, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"status":          status,
+			"moderation_note": note,
+			"updated_at":      time.Now(),
+		},
+	}
+
+	// Возвращаем документ до изменения, чтобы сервис видел прежний статус
+	var previous model.Review
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update).Decode(&previous)
+	if err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// Summary считает средний рейтинг и число одобренных отзывов о товаре
+func (r *mongoRepository) Summary(ctx context.Context, productID string) (*model.RatingSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": productID, "status": model.ReviewStatusApproved}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Average float64 `bson:"average"`
+		Count   int32   `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return &model.RatingSummary{}, nil
+	}
+	return &model.RatingSummary{Average: results[0].Average, Count: results[0].Count}, nil
+}
+
+// SaveFit сохраняет отзыв о посадке; запись с тем же ID перезаписывается
+func (r *mongoRepository) SaveFit(ctx context.Context, feedback *model.FitFeedback) error {
+	if feedback.CreatedAt.IsZero() {
+		feedback.CreatedAt = time.Now()
+	}
+	_, err := r.fitCollection.ReplaceOne(
+		ctx,
+		bson.M{"_id": feedback.ID},
+		feedback,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *mongoRepository) DeleteFit(ctx context.Context, id string) error {
+	_, err := r.fitCollection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// FitSummaries считает отзывы о посадке по каждому из товаров.
+// Товары без отзывов в результат не попадают
+func (r *mongoRepository) FitSummaries(ctx context.Context, productIDs []string) (map[string]model.FitSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": bson.M{"$in": productIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"product_id": "$product_id", "fit": "$fit"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.fitCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID struct {
+			ProductID string    `bson:"product_id"`
+			Fit       model.Fit `bson:"fit"`
+		} `bson:"_id"`
+		Count int32 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]model.FitSummary)
+	for _, result := range results {
+		summary := summaries[result.ID.ProductID]
+		switch result.ID.Fit {
+		case model.FitRunsSmall:
+			summary.RunsSmall += result.Count
+		case model.FitTrueToSize:
+			summary.TrueToSize += result.Count
+		case model.FitRunsLarge:
+			summary.RunsLarge += result.Count
+		}
+		summaries[result.ID.ProductID] = summary
+	}
+	return summaries, nil
+}
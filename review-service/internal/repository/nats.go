@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"shoeshop/review-service/internal/model"
+)
+
+type EventPublisher interface {
+	PublishRatingChanged(event *model.RatingChangedEvent) error
+	Close() error
+}
+
+type EventSubscriber interface {
+	SubscribeItemReturned(handler func(event *model.ItemReturnedEvent)) error
+}
+
+type EventBus interface {
+	EventPublisher
+	EventSubscriber
+}
+
+type natsClient struct {
+	conn *nats.Conn
+}
+
+func NewNatsClient(url string) (EventBus, error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natsClient{
+		conn: nc,
+	}, nil
+}
+
+func (n *natsClient) PublishRatingChanged(event *model.RatingChangedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.conn.Publish("review.rating_changed", data)
+}
+
+func (n *natsClient) SubscribeItemReturned(handler func(event *model.ItemReturnedEvent)) error {
+	_, err := n.conn.Subscribe("order.item_returned", func(msg *nats.Msg) {
+		var event model.ItemReturnedEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			log.Printf("failed to decode order.item_returned event: %v", err)
+			return
+		}
+		handler(&event)
+	})
+	return err
+}
+
+func (n *natsClient) Close() error {
+	n.conn.Close()
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"shoeshop/ids"
+	pb "shoeshop/proto"
+	"shoeshop/review-service/internal/model"
+	"shoeshop/review-service/internal/repository"
+	"shoeshop/sizing"
+)
+
+const (
+	maxTextLength   = 5000
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	ErrInvalidReview     = errors.New("invalid review")
+	ErrNotPurchased      = errors.New("only customers with a delivered order can review this product")
+	ErrDuplicateReview   = errors.New("user has already reviewed this product")
+	ErrInvalidModeration = errors.New("invalid moderation status")
+	ErrInvalidSizeQuery  = errors.New("invalid size recommendation request")
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, productID, userID string, rating int32, text, size string, system sizing.System, fit model.Fit) (*model.Review, error)
+	ListReviews(ctx context.Context, filter repository.ReviewFilter) ([]*model.Review, int64, error)
+	ModerateReview(ctx context.Context, id string, status model.ReviewStatus, note string) (*model.Review, error)
+	RecommendSize(ctx context.Context, userID, productID string) (*model.SizeRecommendation, error)
+	StartFitSync(subscriber repository.EventSubscriber) error
+}
+
+type reviewService struct {
+	repo          repository.ReviewRepository
+	publisher     repository.EventPublisher
+	productClient pb.ProductServiceClient
+	orderClient   pb.OrderServiceClient
+}
+
+func NewReviewService(
+	repo repository.ReviewRepository,
+	publisher repository.EventPublisher,
+	productClient pb.ProductServiceClient,
+	orderClient pb.OrderServiceClient,
+) ReviewService {
+	return &reviewService{
+		repo:          repo,
+		publisher:     publisher,
+		productClient: productClient,
+		orderClient:   orderClient,
+	}
+}
+
+// CreateReview принимает отзыв только от покупателя с доставленным заказом.
+// Новый отзыв попадает на модерацию и в рейтинг товара пока не входит
+func (s *reviewService) CreateReview(ctx context.Context, productID, userID string, rating int32, text, size string, system sizing.System, fit model.Fit) (*model.Review, error) {
+	text = strings.TrimSpace(text)
+	if productID == "" || userID == "" {
+		return nil, fmt.Errorf("%w: product_id and user_id are required", ErrInvalidReview)
+	}
+	if rating < 1 || rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", ErrInvalidReview)
+	}
+	if utf8.RuneCountInString(text) > maxTextLength {
+		return nil, fmt.Errorf("%w: text must be at most %d characters", ErrInvalidReview, maxTextLength)
+	}
+	if fit != "" && !fit.Valid() {
+		return nil, fmt.Errorf("%w: unknown fit %q", ErrInvalidReview, fit)
+	}
+
+	// Размер должен быть одним из размеров товара
+	product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		Id:         productID,
+		SizeSystem: string(sizing.CM),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product %s: %w", productID, err)
+	}
+	sizeValue, err := resolveSize(size, system, product.Product)
+	if err != nil {
+		return nil, err
+	}
+	sizeOffset := sizing.Size(product.Product.SizeOffset)
+
+	purchase, err := s.orderClient.VerifyPurchase(ctx, &pb.VerifyPurchaseRequest{
+		UserId:    userID,
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify purchase: %w", err)
+	}
+	if !purchase.Verified {
+		return nil, ErrNotPurchased
+	}
+
+	review := &model.Review{
+		ID:         ids.New(),
+		ProductID:  productID,
+		UserID:     userID,
+		OrderID:    purchase.OrderId,
+		Rating:     rating,
+		Text:       text,
+		Size:       sizeValue,
+		Brand:      product.Product.Brand,
+		SizeOffset: &sizeOffset,
+		Fit:        fit,
+		Status:     model.ReviewStatusPending,
+	}
+
+	createdReview, err := s.repo.Create(ctx, review)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicateReview
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return createdReview, nil
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, filter repository.ReviewFilter) ([]*model.Review, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	reviews, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	return reviews, total, nil
+}
+
+// ModerateReview меняет статус отзыва и пересчитывает рейтинг товара и
+// отзывы о посадке, если отзыв вошел в число одобренных или выбыл из него
+func (s *reviewService) ModerateReview(ctx context.Context, id string, status model.ReviewStatus, note string) (*model.Review, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidModeration, status)
+	}
+
+	previous, err := s.repo.UpdateStatus(ctx, id, status, note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	if (previous.Status == model.ReviewStatusApproved) != (status == model.ReviewStatusApproved) {
+		s.publishRating(ctx, previous.ProductID)
+		s.syncReviewFit(ctx, previous, status == model.ReviewStatusApproved)
+	}
+
+	review, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return review, nil
+}
+
+// publishRating отправляет актуальный агрегат целиком, поэтому порядок
+// и повторная доставка событий на итог не влияют
+func (s *reviewService) publishRating(ctx context.Context, productID string) {
+	summary, err := s.repo.Summary(ctx, productID)
+	if err != nil {
+		fmt.Printf("failed to compute rating for product %s: %v\n", productID, err)
+		return
+	}
+
+	event := &model.RatingChangedEvent{
+		ProductID: productID,
+		Rating:    summary.Average,
+		Count:     summary.Count,
+	}
+	if err := s.publisher.PublishRatingChanged(event); err != nil {
+		fmt.Printf("failed to publish rating changed event: %v\n", err)
+	}
+}
+
+// resolveSize находит размер товара, который в системе запроса выглядит как
+// size. Товар должен быть запрошен в сантиметрах
+func resolveSize(size string, system sizing.System, product *pb.Product) (sizing.Size, error) {
+	if strings.TrimSpace(size) == "" {
+		return 0, fmt.Errorf("%w: size is required", ErrInvalidReview)
+	}
+
+	available, err := productSizes(product)
+	if err != nil {
+		return 0, err
+	}
+	value, err := sizing.WithOffset(sizing.Size(product.SizeOffset)).Resolve(size, system, available)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidReview, err)
+	}
+	return value, nil
+}
+
+// productSizes разбирает размеры товара, запрошенного в сантиметрах;
+// сантиметры от бренда не зависят, поэтому подойдет любая таблица
+func productSizes(product *pb.Product) ([]sizing.Size, error) {
+	sizes, err := sizing.Default().ParseAll(product.Sizes, sizing.CM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sizes of product %s: %w", product.Id, err)
+	}
+	return sizes, nil
+}
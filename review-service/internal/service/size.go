@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+
+	pb "shoeshop/proto"
+	"shoeshop/review-service/internal/model"
+	"shoeshop/review-service/internal/repository"
+	"shoeshop/sizing"
+)
+
+const (
+	// Сколько последних оставленных покупок учитывать в рекомендации
+	maxPurchasesConsidered = 20
+
+	sameProductWeight = 3
+	sameBrandWeight   = 2
+	otherBrandWeight  = 1
+
+	// Посадка с оценкой ±1 сдвигает размер на один размер EU
+	fitStepMM = 20.0 / 3
+)
+
+// purchase - оставленная покупателем позиция доставленного заказа
+type purchase struct {
+	ProductID string
+	Size      sizing.Size
+}
+
+// RecommendSize подбирает размер из Product.Sizes. Размеры сравниваются как
+// длина стопы, поэтому покупки разных брендов сопоставимы. Для каждой оставленной
+// покупки размер поправляется на посадку купленной модели, что дает "свой"
+// размер покупателя; покупки той же модели и бренда весят больше. Затем
+// результат поправляется на посадку нужной модели и округляется до ближайшего
+// доступного размера
+func (s *reviewService) RecommendSize(ctx context.Context, userID, productID string) (*model.SizeRecommendation, error) {
+	if userID == "" || productID == "" {
+		return nil, fmt.Errorf("%w: user_id and product_id are required", ErrInvalidSizeQuery)
+	}
+
+	product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		Id:         productID,
+		SizeSystem: string(sizing.CM),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product %s: %w", productID, err)
+	}
+	available, err := productSizes(product.Product)
+	if err != nil {
+		return nil, err
+	}
+
+	purchases, err := s.keptPurchases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := []string{productID}
+	for _, p := range purchases {
+		productIDs = append(productIDs, p.ProductID)
+	}
+	summaries, err := s.repo.FitSummaries(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fit feedback: %w", err)
+	}
+
+	recommendation := &model.SizeRecommendation{
+		SizeOffset: sizing.Size(product.Product.SizeOffset),
+		Fit:        summaries[productID],
+	}
+
+	brands := map[string]string{productID: product.Product.BrandId}
+	var weightedSum, totalWeight float64
+	for _, p := range purchases {
+		brand, ok := brands[p.ProductID]
+		if !ok {
+			// Удаленный товар все равно говорит о размере, но без бренда
+			if purchased, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: p.ProductID}); err == nil {
+				brand = purchased.Product.BrandId
+			}
+			brands[p.ProductID] = brand
+		}
+
+		weight := float64(otherBrandWeight)
+		switch {
+		case p.ProductID == productID:
+			weight = sameProductWeight
+		case brand != "" && brand == product.Product.BrandId:
+			weight = sameBrandWeight
+		}
+
+		ownSize := float64(p.Size) + summaries[p.ProductID].Score()*fitStepMM
+		weightedSum += weight * ownSize
+		totalWeight += weight
+		recommendation.PurchasesUsed++
+	}
+
+	if recommendation.PurchasesUsed == 0 {
+		return recommendation, nil
+	}
+
+	estimate := weightedSum/totalWeight - recommendation.Fit.Score()*fitStepMM
+	size, ok := closestSize(available, estimate)
+	if !ok {
+		return recommendation, nil
+	}
+	recommendation.Size = size
+	recommendation.Personalized = true
+
+	return recommendation, nil
+}
+
+// keptPurchases возвращает последние позиции доставленных заказов с
+// указанным размером, которые покупатель не вернул
+func (s *reviewService) keptPurchases(ctx context.Context, userID string) ([]purchase, error) {
+	resp, err := s.orderClient.ListOrders(ctx, &pb.ListOrdersRequest{
+		UserId:     userID,
+		SizeSystem: string(sizing.CM),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders of user %s: %w", userID, err)
+	}
+
+	var purchases []purchase
+	for _, order := range resp.Orders {
+		if order.Status != "DELIVERED" {
+			continue
+		}
+		for _, item := range order.Items {
+			if item.Returned || item.Size == "" {
+				continue
+			}
+			size, err := sizing.Default().Parse(item.Size, sizing.CM)
+			if err != nil {
+				continue
+			}
+			purchases = append(purchases, purchase{ProductID: item.ProductId, Size: size})
+			if len(purchases) == maxPurchasesConsidered {
+				return purchases, nil
+			}
+		}
+	}
+	return purchases, nil
+}
+
+// closestSize выбирает ближайший к оценке размер; при равном расстоянии
+// берется больший, потому что в чуть большей обуви ходить проще
+func closestSize(sizes []sizing.Size, estimate float64) (sizing.Size, bool) {
+	var best sizing.Size
+	found := false
+	bestDistance := math.Inf(1)
+	for _, size := range sizes {
+		distance := math.Abs(float64(size) - estimate)
+		if distance < bestDistance || (distance == bestDistance && size > best) {
+			best, bestDistance, found = size, distance, true
+		}
+	}
+	return best, found
+}
+
+// StartFitSync подписывается на возвраты и сохраняет отзывы о посадке из них
+func (s *reviewService) StartFitSync(subscriber repository.EventSubscriber) error {
+	err := subscriber.SubscribeItemReturned(func(event *model.ItemReturnedEvent) {
+		if !event.Fit.Valid() {
+			return
+		}
+
+		feedback := &model.FitFeedback{
+			ID:        fmt.Sprintf("%s:%s:%s:%d", model.FitSourceReturn, event.OrderID, event.ProductID, event.Size),
+			ProductID: event.ProductID,
+			UserID:    event.UserID,
+			Size:      event.Size,
+			Fit:       event.Fit,
+			Source:    model.FitSourceReturn,
+		}
+		if err := s.repo.SaveFit(context.Background(), feedback); err != nil {
+			fmt.Printf("failed to save fit feedback from return: %v\n", err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to order.item_returned: %w", err)
+	}
+
+	log.Println("Fit feedback sync started")
+	return nil
+}
+
+// syncReviewFit учитывает посадку только из одобренных отзывов, как и рейтинг
+func (s *reviewService) syncReviewFit(ctx context.Context, review *model.Review, approved bool) {
+	if review.Fit == "" {
+		return
+	}
+
+	id := model.FitSourceReview + ":" + review.ID
+	var err error
+	if approved {
+		err = s.repo.SaveFit(ctx, &model.FitFeedback{
+			ID:        id,
+			ProductID: review.ProductID,
+			UserID:    review.UserID,
+			Size:      review.Size,
+			Fit:       review.Fit,
+			Source:    model.FitSourceReview,
+		})
+	} else {
+		err = s.repo.DeleteFit(ctx, id)
+	}
+	if err != nil {
+		fmt.Printf("failed to sync fit feedback of review %s: %v\n", review.ID, err)
+	}
+}
@@ -0,0 +1,358 @@
+// Package sizing переводит размеры обуви между системами размеров
+//
+// Сервисы хранят размер как длину стопы в миллиметрах. Подписи вида "42.5",
+// "US 9.5" или "26.5 cm" существуют только на границе API и зависят от
+// бренда: один и тот же размер EU у разных производителей сидит по-разному
+package sizing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Size - длина стопы в миллиметрах
+type Size int32
+
+// System - система размеров для вывода и разбора подписей
+type System string
+
+const (
+	EU      System = "EU"
+	USMen   System = "US_M"
+	USWomen System = "US_W"
+	UK      System = "UK"
+	CM      System = "CM"
+)
+
+// DefaultSystem используется, если запрос не указал систему размеров
+const DefaultSystem = EU
+
+var (
+	ErrUnknownSystem = errors.New("unknown size system")
+	ErrInvalidSize   = errors.New("invalid size")
+	ErrInvalidOffset = errors.New("invalid size offset")
+	ErrUnavailable   = errors.New("size is not available")
+)
+
+// ParseSystem принимает название системы без учета регистра; "US" - мужской
+// размер США. Пустое значение дает DefaultSystem
+func ParseSystem(value string) (System, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	normalized = strings.NewReplacer("-", "_", " ", "_", "'", "").Replace(normalized)
+
+	switch normalized {
+	case "":
+		return DefaultSystem, nil
+	case "EU", "EUR":
+		return EU, nil
+	case "US", "US_M", "USM", "US_MEN", "MEN":
+		return USMen, nil
+	case "US_W", "USW", "US_WOMEN", "WOMEN":
+		return USWomen, nil
+	case "UK":
+		return UK, nil
+	case "CM", "MONDOPOINT":
+		return CM, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownSystem, value)
+}
+
+// Table переводит подписи размеров одного бренда в длину стопы и обратно
+type Table struct {
+	rows []row
+}
+
+// row - один размер таблицы; usExact хранит неокругленный размер США, чтобы
+// выбрать лучшую строку, когда у двух строк одинаковая округленная подпись
+type row struct {
+	length  Size
+	eu      float64
+	usMen   float64
+	usExact float64
+}
+
+const (
+	minEU = 34
+	maxEU = 50
+
+	// Парижский штих: один размер EU - 2/3 см длины колодки
+	euStepMM = 20.0 / 3
+	// Колодка примерно на 15 мм длиннее стопы
+	lastAllowanceMM = 15
+	// Шаг размеров США - 1/3 дюйма; мужской US 9 подходит на стопу 270 мм
+	usStepMM     = 25.4 / 3
+	usMenRefSize = 9
+	usMenRefMM   = 270
+
+	usWomenOffset = 1.5
+	ukOffset      = -1
+
+	// Длина дальше этого от всех строк выводится в сантиметрах
+	maxDeviationMM = 3
+
+	// MaxOffset ограничивает сдвиг таблицы бренда: больше целого размера EU
+	// значит, что ошибка в данных бренда, а не в посадке
+	MaxOffset Size = 10
+)
+
+// brandOffsets сдвигают таблицу по умолчанию для брендов, которые маломерят
+// (минус) или большемерят (плюс): Converse EU 42 подходит на стопу на 5 мм длиннее
+var brandOffsets = map[string]Size{
+	"adidas":      -2,
+	"asics":       -3,
+	"converse":    5,
+	"new balance": 2,
+	"vans":        3,
+}
+
+var (
+	defaultTable = newTable(0)
+
+	offsetTablesMu sync.Mutex
+	offsetTables   = map[Size]*Table{0: defaultTable}
+)
+
+// newTable строит строки для каждого полуразмера EU, остальные системы
+// выводятся из длины стопы
+func newTable(offset Size) *Table {
+	var rows []row
+	for eu := float64(minEU); eu <= maxEU; eu += 0.5 {
+		length := Size(math.Round(eu*euStepMM-lastAllowanceMM)) + offset
+		usExact := usMenRefSize + float64(length-usMenRefMM)/usStepMM
+		rows = append(rows, row{
+			length:  length,
+			eu:      eu,
+			usMen:   roundHalf(usExact),
+			usExact: usExact,
+		})
+	}
+	return &Table{rows: rows}
+}
+
+// Default возвращает таблицу для брендов без своего сдвига
+func Default() *Table {
+	return defaultTable
+}
+
+// TableFor возвращает встроенную таблицу бренда. Если сдвиг бренда известен
+// из записи, вместо нее нужен WithOffset
+func TableFor(brand string) *Table {
+	return WithOffset(OffsetFor(brand))
+}
+
+// OffsetFor возвращает встроенный сдвиг бренда, ноль для неизвестных брендов
+func OffsetFor(brand string) Size {
+	return brandOffsets[strings.ToLower(strings.TrimSpace(brand))]
+}
+
+// WithOffset возвращает таблицу по умолчанию, сдвинутую на offset миллиметров
+func WithOffset(offset Size) *Table {
+	offsetTablesMu.Lock()
+	defer offsetTablesMu.Unlock()
+
+	table, ok := offsetTables[offset]
+	if !ok {
+		table = newTable(offset)
+		offsetTables[offset] = table
+	}
+	return table
+}
+
+// ValidateOffset проверяет, что сдвиг бренда не больше MaxOffset
+func ValidateOffset(offset Size) error {
+	if offset < -MaxOffset || offset > MaxOffset {
+		return fmt.Errorf("%w: %d mm is outside of ±%d mm", ErrInvalidOffset, offset, MaxOffset)
+	}
+	return nil
+}
+
+// ChartRow - один размер таблицы во всех системах
+type ChartRow struct {
+	Length  Size
+	EU      float64
+	USMen   float64
+	USWomen float64
+	UK      float64
+}
+
+// Chart возвращает строки таблицы от меньшего размера к большему
+func (t *Table) Chart() []ChartRow {
+	chart := make([]ChartRow, len(t.rows))
+	for i, r := range t.rows {
+		chart[i] = ChartRow{
+			Length:  r.length,
+			EU:      r.eu,
+			USMen:   r.value(USMen),
+			USWomen: r.value(USWomen),
+			UK:      r.value(UK),
+		}
+	}
+	return chart
+}
+
+// Format выводит размер в системе system без названия системы
+func (t *Table) Format(size Size, system System) string {
+	if system == CM {
+		return formatNumber(float64(size) / 10)
+	}
+
+	r := t.nearest(size)
+	if math.Abs(float64(r.length-size)) > maxDeviationMM {
+		return formatNumber(float64(size)/10) + " cm"
+	}
+	return formatNumber(r.value(system))
+}
+
+// FormatAll выводит размеры в системе system
+func (t *Table) FormatAll(sizes []Size, system System) []string {
+	labels := make([]string, len(sizes))
+	for i, size := range sizes {
+		labels[i] = t.Format(size, system)
+	}
+	return labels
+}
+
+// Parse переводит подпись вида "42.5", "US 9.5" или "26.5 cm" в длину стопы.
+// Система, названная в подписи, важнее переданной system
+func (t *Table) Parse(label string, system System) (Size, error) {
+	labelSystem, value, err := parseLabel(label, system)
+	if err != nil {
+		return 0, err
+	}
+	if labelSystem == CM {
+		return Size(math.Round(value * 10)), nil
+	}
+
+	best, found := t.rows[0], false
+	for _, r := range t.rows {
+		if r.value(labelSystem) == value && (!found || r.distance(labelSystem, value) < best.distance(labelSystem, value)) {
+			best, found = r, true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: %s %s", ErrInvalidSize, labelSystem, formatNumber(value))
+	}
+	return best.length, nil
+}
+
+// ParseAll переводит подписи в длину стопы и падает на первой неверной
+func (t *Table) ParseAll(labels []string, system System) ([]Size, error) {
+	sizes := make([]Size, 0, len(labels))
+	for _, label := range labels {
+		size, err := t.Parse(label, system)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// Resolve находит доступный размер, который выводится как label. В отличие
+// от Parse принимает подпись, если так выводится любой доступный размер,
+// даже когда у нескольких размеров в этой системе одна подпись
+func (t *Table) Resolve(label string, system System, available []Size) (Size, error) {
+	labelSystem, value, err := parseLabel(label, system)
+	if err != nil {
+		return 0, err
+	}
+
+	var best Size
+	bestDistance := math.Inf(1)
+	for _, size := range available {
+		if t.Format(size, labelSystem) != formatNumber(value) {
+			continue
+		}
+		distance := 0.0
+		if labelSystem != CM {
+			distance = t.nearest(size).distance(labelSystem, value)
+		}
+		if distance < bestDistance {
+			best, bestDistance = size, distance
+		}
+	}
+	if math.IsInf(bestDistance, 1) {
+		return 0, fmt.Errorf("%w: %s %s", ErrUnavailable, labelSystem, formatNumber(value))
+	}
+	return best, nil
+}
+
+func (t *Table) nearest(size Size) row {
+	best := t.rows[0]
+	for _, r := range t.rows[1:] {
+		if math.Abs(float64(r.length-size)) < math.Abs(float64(best.length-size)) {
+			best = r
+		}
+	}
+	return best
+}
+
+func (r row) value(system System) float64 {
+	switch system {
+	case USMen:
+		return r.usMen
+	case USWomen:
+		return r.usMen + usWomenOffset
+	case UK:
+		return r.usMen + ukOffset
+	case CM:
+		return float64(r.length) / 10
+	default:
+		return r.eu
+	}
+}
+
+// distance показывает, насколько неокругленный размер строки далек от подписи
+func (r row) distance(system System, value float64) float64 {
+	switch system {
+	case USMen, USWomen, UK:
+		return math.Abs(r.usExact - (value - (r.value(system) - r.usMen)))
+	default:
+		return math.Abs(r.value(system) - value)
+	}
+}
+
+var labelPattern = regexp.MustCompile(`^(EU|UK|CM|US(?:[ _-]?(?:M|W|MEN|WOMEN))?)?\s*([0-9]+(?:[.,][0-9]+)?)\s*(CM)?$`)
+
+func parseLabel(label string, system System) (System, float64, error) {
+	match := labelPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(label)))
+	if match == nil {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidSize, label)
+	}
+
+	if match[1] != "" {
+		parsed, err := ParseSystem(match[1])
+		if err != nil {
+			return "", 0, err
+		}
+		system = parsed
+	}
+	if match[3] != "" {
+		if match[1] != "" && system != CM {
+			return "", 0, fmt.Errorf("%w: %q", ErrInvalidSize, label)
+		}
+		system = CM
+	}
+	if system == "" {
+		system = DefaultSystem
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(match[2], ",", ".", 1), 64)
+	if err != nil || value <= 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidSize, label)
+	}
+	return system, value, nil
+}
+
+func roundHalf(value float64) float64 {
+	return math.Round(value*2) / 2
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -3,7 +3,7 @@ echo Starting MongoDB...
 start cmd /k "C:\Programm Files\MongoDB\Server\6.0\bin\mongod.exe"
 timeout /t 5
 
-echo Seeding database...
-cd product-service/scripts && go run seed.go && cd ../..
+echo To seed the catalog, start the services and run:
+echo   cd product-service/seed ^&^& go run main.go
 
 echo MongoDB is ready! 
\ No newline at end of file
@@ -20,6 +20,10 @@ cd product-service/cmd && start cmd /k "go run main.go" && cd ../..
 timeout /t 3
 cd order-service/cmd && start cmd /k "go run main.go" && cd ../..
 timeout /t 3
+cd review-service/cmd && start cmd /k "go run main.go" && cd ../..
+timeout /t 3
+cd wishlist-service/cmd && start cmd /k "go run main.go" && cd ../..
+timeout /t 3
 cd api-gateway && start cmd /k "go run main.go" && cd ..
 
 echo Installing frontend dependencies...
@@ -2,8 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"shoeshop/user-service/internal/service"
 	"shoeshop/user-service/internal/model"
 	pb "shoeshop/proto"
@@ -73,7 +77,16 @@ func (h *GRPCHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	user.UpdatedAt = time.Now()
 	
-	updatedUser, err := h.userService.UpdateUser(ctx, user)
+	updatedUser, err := h.userService.UpdateUser(ctx, user, req.GetUpdateMask().GetPaths())
+	if errors.Is(err, model.ErrVersionConflict) {
+		return nil, status.Errorf(codes.Aborted, "failed to update user: %v", err)
+	}
+	if errors.Is(err, model.ErrInvalidUpdateMask) {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to update user: %v", err)
+	}
+	if errors.Is(err, model.ErrVersionRequired) {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to update user: %v", err)
+	}
 	if err != nil {
 		return nil, err
 	}
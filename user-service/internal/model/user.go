@@ -1,10 +1,45 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"time"
 	pb "shoeshop/proto"
 )
 
+var (
+	// ErrVersionConflict - пользователя изменили после того, как клиент его прочитал
+	ErrVersionConflict   = errors.New("user was modified concurrently")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+	// ErrVersionRequired - изменение без версии, которую клиент прочитал
+	ErrVersionRequired = errors.New("user version is required")
+)
+
+// updatableFields - поля профиля, которые пользователь может менять через
+// маску обновления, и поля документа, которые они меняют. Email, пароль,
+// баланс и права меняются только своими методами
+var updatableFields = map[string]string{
+	"username":        "username",
+	"firstName":       "first_name",
+	"lastName":        "last_name",
+	"shippingAddress": "shipping_address",
+	"phone":           "phone",
+}
+
+// UpdateFields переводит пути маски в поля документа. Пустая маска -
+// пустой список, то есть обновление всех полей
+func UpdateFields(paths []string) ([]string, error) {
+	fields := make([]string, 0, len(paths))
+	for _, path := range paths {
+		field, ok := updatableFields[path]
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
 // User представляет доменную модель пользователя
 type User struct {
 	ID               string    `bson:"_id,omitempty"`
@@ -21,6 +56,8 @@ type User struct {
 	OrderIDs         []string  `bson:"order_ids"`
 	IsAdmin          bool      `bson:"is_admin"`
 	Balance          float64   `bson:"balance"`
+	// Version растет при каждой записи
+	Version int64 `bson:"version"`
 }
 
 // ToProto конвертирует доменную модель в protobuf модель
@@ -40,6 +77,7 @@ func (u *User) ToProto() *pb.User {
 		OrderIds:        u.OrderIDs,
 		IsAdmin:         u.IsAdmin,
 		Balance:         u.Balance,
+		Version:         u.Version,
 	}
 }
 
@@ -70,5 +108,6 @@ func FromProto(pbUser *pb.User) (*User, error) {
 		OrderIDs:     pbUser.OrderIds,
 		IsAdmin:      pbUser.IsAdmin,
 		Balance:      pbUser.Balance,
+		Version:      pbUser.Version,
 	}, nil
 } 
\ No newline at end of file
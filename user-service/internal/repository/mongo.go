@@ -2,13 +2,13 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"shoeshop/ids"
 	"shoeshop/user-service/internal/model"
 )
 
@@ -16,15 +16,14 @@ type UserRepository interface {
 	Create(ctx context.Context, user *model.User) (*model.User, error)
 	GetByID(ctx context.Context, id string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
-	Update(ctx context.Context, user *model.User) (*model.User, error)
+	Update(ctx context.Context, user *model.User, fields []string) (*model.User, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]*model.User, error)
 }
 
 type mongoRepository struct {
-	client            *mongo.Client
-	collection        *mongo.Collection
-	countersCollection *mongo.Collection
+	client     *mongo.Client
+	collection *mongo.Collection
 }
 
 func NewMongoRepository(uri string) (UserRepository, error) {
@@ -35,7 +34,6 @@ func NewMongoRepository(uri string) (UserRepository, error) {
 
 	db := client.Database("shoeshop")
 	collection := db.Collection("users")
-	countersCollection := db.Collection("counters")
 
 	// Создание уникального индекса для email
 	_, err = collection.Indexes().CreateOne(
@@ -49,56 +47,38 @@ func NewMongoRepository(uri string) (UserRepository, error) {
 		return nil, err
 	}
 
-	// Инициализация счетчика пользователей
-	_, err = countersCollection.UpdateOne(
+	// Пользователи, которым ID выдал Mongo, получают строковый ID. Числовые
+	// ID из старого счетчика остаются как есть: на них ссылаются заказы
+	if err := ids.MigrateObjectIDs(context.Background(), collection); err != nil {
+		return nil, err
+	}
+
+	// Пользователи, созданные до появления версий, получают версию 1
+	_, err = collection.UpdateMany(
 		context.Background(),
-		bson.M{"_id": "userId"},
-		bson.M{"$setOnInsert": bson.M{"value": 0}},
-		options.Update().SetUpsert(true),
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": 1}},
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &mongoRepository{
-		client:            client,
-		collection:        collection,
-		countersCollection: countersCollection,
+		client:     client,
+		collection: collection,
 	}, nil
 }
 
-func (r *mongoRepository) getNextUserID(ctx context.Context) (string, error) {
-	result := r.countersCollection.FindOneAndUpdate(
-		ctx,
-		bson.M{"_id": "userId"},
-		bson.M{"$inc": bson.M{"value": 1}},
-		options.FindOneAndUpdate().SetReturnDocument(options.After),
-	)
-
-	var counter struct {
-		Value int `bson:"value"`
-	}
-	if err := result.Decode(&counter); err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%d", counter.Value), nil
-}
-
 func (r *mongoRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
-	// Получение следующего ID
-	nextID, err := r.getNextUserID(ctx)
-	if err != nil {
-		return nil, err
-	}
-	user.ID = nextID
+	user.ID = ids.New()
 
 	// Установка временных меток
 	now := time.Now()
 	user.CreatedAt = now
 	user.UpdatedAt = now
+	user.Version = 1
 
-	_, err = r.collection.InsertOne(ctx, user)
+	_, err := r.collection.InsertOne(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -124,12 +104,48 @@ func (r *mongoRepository) GetByID(ctx context.Context, id string) (*model.User,
 	return &user, nil
 }
 
-func (r *mongoRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
-	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": user.ID}, user)
+// Update записывает поля fields, а при пустом списке - всего пользователя.
+// Запись применяется, только если документ все еще в версии user.Version
+func (r *mongoRepository) Update(ctx context.Context, user *model.User, fields []string) (*model.User, error) {
+	data, err := bson.Marshal(user)
 	if err != nil {
 		return nil, err
 	}
-	return user, nil
+	var set bson.M
+	if err := bson.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	delete(set, "_id")
+	delete(set, "version")
+	if len(fields) > 0 {
+		selected := bson.M{"updated_at": set["updated_at"]}
+		for _, field := range fields {
+			selected[field] = set[field]
+		}
+		set = selected
+	}
+
+	var updated model.User
+	err = r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": user.ID, "version": user.Version},
+		bson.M{"$set": set, "$inc": bson.M{"version": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": user.ID})
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, mongo.ErrNoDocuments
+		}
+		return nil, model.ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
 
 func (r *mongoRepository) Delete(ctx context.Context, id string) error {
@@ -14,7 +14,7 @@ type UserService interface {
 	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
 	GetUser(ctx context.Context, id string) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
-	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ListUsers(ctx context.Context) ([]*model.User, error)
 	Register(ctx context.Context, user *model.User) (*model.User, error)
@@ -43,13 +43,27 @@ func (s *userService) GetUser(ctx context.Context, id string) (*model.User, erro
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *userService) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+// UpdateUser меняет поля из маски paths; пустая маска перезаписывает
+// пользователя целиком, кроме неизменяемых полей
+func (s *userService) UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error) {
+	fields, err := model.UpdateFields(paths)
+	if err != nil {
+		return nil, err
+	}
+	if user.Version == 0 {
+		return nil, model.ErrVersionRequired
+	}
+
 	// Получаем текущего пользователя из БД
 	currentUser, err := s.repo.GetByEmail(ctx, user.Email)
 	if err != nil {
 		return nil, err
 	}
 
+	if user.Version != currentUser.Version {
+		return nil, model.ErrVersionConflict
+	}
+
 	// Сохраняем неизменяемые поля
 	user.ID = currentUser.ID
 	user.CreatedAt = currentUser.CreatedAt
@@ -72,7 +86,7 @@ func (s *userService) UpdateUser(ctx context.Context, user *model.User) (*model.
 	}
 
 	// Обновляем пользователя в БД
-	return s.repo.Update(ctx, user)
+	return s.repo.Update(ctx, user, fields)
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id string) error {
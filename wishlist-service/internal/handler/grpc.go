@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+	"shoeshop/wishlist-service/internal/service"
+)
+
+type GRPCHandler struct {
+	pb.UnimplementedWishlistServiceServer
+	wishlistService service.WishlistService
+}
+
+func NewGRPCHandler(wishlistService service.WishlistService) *GRPCHandler {
+	return &GRPCHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+func (h *GRPCHandler) AddWishlistItem(ctx context.Context, req *pb.AddWishlistItemRequest) (*pb.WishlistResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	wishlist, err := h.wishlistService.AddItem(ctx, req.GetUserId(), req.GetProductId(), req.GetSize(), system)
+	if err != nil {
+		return nil, wishlistError("failed to add wishlist item", err)
+	}
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlist.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) RemoveWishlistItem(ctx context.Context, req *pb.RemoveWishlistItemRequest) (*pb.WishlistResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	wishlist, err := h.wishlistService.RemoveItem(ctx, req.GetUserId(), req.GetProductId())
+	if err != nil {
+		return nil, wishlistError("failed to remove wishlist item", err)
+	}
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlist.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) GetWishlist(ctx context.Context, req *pb.GetWishlistRequest) (*pb.WishlistResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	wishlist, err := h.wishlistService.GetWishlist(ctx, req.GetUserId())
+	if err != nil {
+		return nil, wishlistError("failed to get wishlist", err)
+	}
+
+	return &pb.WishlistResponse{
+		Wishlist: wishlist.ToProto(system),
+	}, nil
+}
+
+func (h *GRPCHandler) ShareWishlist(ctx context.Context, req *pb.ShareWishlistRequest) (*pb.ShareWishlistResponse, error) {
+	token, err := h.wishlistService.ShareWishlist(ctx, req.GetUserId(), req.GetRegenerate())
+	if err != nil {
+		return nil, wishlistError("failed to share wishlist", err)
+	}
+
+	return &pb.ShareWishlistResponse{
+		ShareToken: token,
+	}, nil
+}
+
+func (h *GRPCHandler) GetSharedWishlist(ctx context.Context, req *pb.GetSharedWishlistRequest) (*pb.WishlistResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	wishlist, err := h.wishlistService.GetSharedWishlist(ctx, req.GetShareToken())
+	if err != nil {
+		return nil, wishlistError("failed to get shared wishlist", err)
+	}
+
+	// По ссылке видны только товары, без владельца
+	shared := wishlist.ToProto(system)
+	shared.UserId = ""
+
+	return &pb.WishlistResponse{
+		Wishlist: shared,
+	}, nil
+}
+
+func (h *GRPCHandler) MoveToCart(ctx context.Context, req *pb.MoveToCartRequest) (*pb.MoveToCartResponse, error) {
+	system, err := sizing.ParseSystem(req.GetSizeSystem())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	item, wishlist, err := h.wishlistService.MoveToCart(ctx, req.GetUserId(), req.GetProductId(), req.GetSize(), system)
+	if err != nil {
+		return nil, wishlistError("failed to move wishlist item to cart", err)
+	}
+
+	return &pb.MoveToCartResponse{
+		Item:       item,
+		SizeSystem: string(system),
+		Wishlist:   wishlist.ToProto(system),
+	}, nil
+}
+
+func wishlistError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidWishlist):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, service.ErrNotInWishlist):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "wishlist not found")
+	case errors.Is(err, service.ErrUnavailable):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case status.Code(err) == codes.NotFound:
+		return status.Errorf(codes.NotFound, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
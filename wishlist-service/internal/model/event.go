@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// ProductEvent - поля товара из событий product-service, нужные списку
+type ProductEvent struct {
+	ID        string
+	Name      string
+	Price     float64
+	DeletedAt *time.Time
+}
+
+// PriceDroppedEvent - событие wishlist.price_dropped: цена сохраненного
+// товара снизилась
+type PriceDroppedEvent struct {
+	UserID      string
+	Email       string
+	ProductID   string
+	ProductName string
+	Size        string
+	OldPrice    float64
+	NewPrice    float64
+	At          time.Time
+}
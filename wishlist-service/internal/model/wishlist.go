@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+)
+
+// WishlistItem - сохраненный товар. Name и LastPrice обновляются по событиям
+// product.updated, поэтому список не ходит в product-service за ценами
+type WishlistItem struct {
+	ProductID string `bson:"product_id"`
+	Name      string `bson:"name"`
+	// Желаемый размер; nil, если пользователь его не выбрал
+	Size       *sizing.Size `bson:"size,omitempty"`
+	SizeOffset sizing.Size  `bson:"size_offset"`
+	PriceAtAdd float64      `bson:"price_at_add"`
+	LastPrice  float64      `bson:"last_price"`
+	AddedAt    time.Time    `bson:"added_at"`
+}
+
+// Wishlist - список пользователя, по одному документу на пользователя
+type Wishlist struct {
+	UserID     string         `bson:"_id"`
+	Items      []WishlistItem `bson:"items"`
+	ShareToken string         `bson:"share_token,omitempty"`
+	UpdatedAt  time.Time      `bson:"updated_at"`
+}
+
+// Item возвращает сохраненный товар или nil
+func (w *Wishlist) Item(productID string) *WishlistItem {
+	for i := range w.Items {
+		if w.Items[i].ProductID == productID {
+			return &w.Items[i]
+		}
+	}
+	return nil
+}
+
+func (i *WishlistItem) FormatSize(system sizing.System) string {
+	if i.Size == nil {
+		return ""
+	}
+	return sizing.WithOffset(i.SizeOffset).Format(*i.Size, system)
+}
+
+func (w *Wishlist) ToProto(system sizing.System) *pb.Wishlist {
+	items := make([]*pb.WishlistItem, len(w.Items))
+	for i := range w.Items {
+		item := &w.Items[i]
+		items[i] = &pb.WishlistItem{
+			ProductId:  item.ProductID,
+			Name:       item.Name,
+			Size:       item.FormatSize(system),
+			Price:      item.LastPrice,
+			PriceAtAdd: item.PriceAtAdd,
+			AddedAt:    item.AddedAt.Format(time.RFC3339),
+		}
+	}
+
+	wishlist := &pb.Wishlist{
+		UserId:     w.UserID,
+		Items:      items,
+		SizeSystem: string(system),
+		ShareToken: w.ShareToken,
+	}
+	if !w.UpdatedAt.IsZero() {
+		wishlist.UpdatedAt = w.UpdatedAt.Format(time.RFC3339)
+	}
+	return wishlist
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"shoeshop/wishlist-service/internal/model"
+)
+
+type WishlistRepository interface {
+	Get(ctx context.Context, userID string) (*model.Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (*model.Wishlist, error)
+	AddItem(ctx context.Context, userID string, item model.WishlistItem) (*model.Wishlist, error)
+	RemoveItem(ctx context.Context, userID, productID string) (*model.Wishlist, error)
+	SetShareToken(ctx context.Context, userID, token string) (*model.Wishlist, error)
+	ListPriceDrops(ctx context.Context, productID string, price float64) ([]*model.Wishlist, error)
+	ClaimPriceDrop(ctx context.Context, userID string, product *model.ProductEvent) (*model.WishlistItem, error)
+	SyncProduct(ctx context.Context, product *model.ProductEvent) error
+}
+
+type mongoRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoRepository(uri string) (WishlistRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	collection := client.Database("shoeshop").Collection("wishlists")
+
+	// Создаем индексы
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "items.product_id", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "share_token", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}
+
+	_, err = collection.Indexes().CreateMany(context.Background(), indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoRepository{
+		client:     client,
+		collection: collection,
+	}, nil
+}
+
+func (r *mongoRepository) Get(ctx context.Context, userID string) (*model.Wishlist, error) {
+	return r.findOne(ctx, bson.M{"_id": userID})
+}
+
+func (r *mongoRepository) GetByShareToken(ctx context.Context, token string) (*model.Wishlist, error) {
+	return r.findOne(ctx, bson.M{"share_token": token})
+}
+
+// AddItem сохраняет товар в список. Повторное добавление не создает
+// дубликат: меняется только желаемый размер, если он указан
+func (r *mongoRepository) AddItem(ctx context.Context, userID string, item model.WishlistItem) (*model.Wishlist, error) {
+	now := time.Now()
+
+	set := bson.M{"updated_at": now}
+	if item.Size != nil {
+		set["items.$.size"] = item.Size
+		set["items.$.size_offset"] = item.SizeOffset
+	}
+	wishlist, err := r.findOneAndUpdate(ctx,
+		bson.M{"_id": userID, "items.product_id": item.ProductID},
+		bson.M{"$set": set},
+		false,
+	)
+	if err != mongo.ErrNoDocuments {
+		return wishlist, err
+	}
+
+	item.AddedAt = now
+	return r.findOneAndUpdate(ctx,
+		bson.M{"_id": userID, "items.product_id": bson.M{"$ne": item.ProductID}},
+		bson.M{
+			"$push": bson.M{"items": item},
+			"$set":  bson.M{"updated_at": now},
+		},
+		true,
+	)
+}
+
+// RemoveItem удаляет товар из списка; если товара в списке нет,
+// возвращает mongo.ErrNoDocuments
+func (r *mongoRepository) RemoveItem(ctx context.Context, userID, productID string) (*model.Wishlist, error) {
+	return r.findOneAndUpdate(ctx,
+		bson.M{"_id": userID, "items.product_id": productID},
+		bson.M{
+			"$pull": bson.M{"items": bson.M{"product_id": productID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		false,
+	)
+}
+
+// SetShareToken задает токен ссылки на список; список создается, если его
+// еще нет, чтобы ссылкой можно было поделиться заранее
+func (r *mongoRepository) SetShareToken(ctx context.Context, userID, token string) (*model.Wishlist, error) {
+	return r.findOneAndUpdate(ctx,
+		bson.M{"_id": userID},
+		bson.M{
+			"$set":         bson.M{"share_token": token, "updated_at": time.Now()},
+			"$setOnInsert": bson.M{"items": []model.WishlistItem{}},
+		},
+		true,
+	)
+}
+
+// ListPriceDrops возвращает списки, в которых товар сохранен по цене выше price
+func (r *mongoRepository) ListPriceDrops(ctx context.Context, productID string, price float64) ([]*model.Wishlist, error) {
+	cursor, err := r.collection.Find(ctx, priceDropFilter(productID, price))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	wishlists := []*model.Wishlist{}
+	if err := cursor.All(ctx, &wishlists); err != nil {
+		return nil, err
+	}
+	return wishlists, nil
+}
+
+// ClaimPriceDrop записывает новую цену товара в список пользователя и
+// возвращает товар с прежней ценой. Снижение забирает только один из
+// одновременных обработчиков, остальные получают mongo.ErrNoDocuments
+func (r *mongoRepository) ClaimPriceDrop(ctx context.Context, userID string, product *model.ProductEvent) (*model.WishlistItem, error) {
+	filter := priceDropFilter(product.ID, product.Price)
+	filter["_id"] = userID
+
+	var before model.Wishlist
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{
+		"$set": bson.M{
+			"items.$.last_price": product.Price,
+			"items.$.name":       product.Name,
+		},
+	}).Decode(&before)
+	if err != nil {
+		return nil, err
+	}
+
+	item := before.Item(product.ID)
+	if item == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	return item, nil
+}
+
+// SyncProduct обновляет название и последнюю цену товара во всех списках
+func (r *mongoRepository) SyncProduct(ctx context.Context, product *model.ProductEvent) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"items.product_id": product.ID},
+		bson.M{"$set": bson.M{
+			"items.$[item].name":       product.Name,
+			"items.$[item].last_price": product.Price,
+		}},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"item.product_id": product.ID}},
+		}),
+	)
+	return err
+}
+
+func priceDropFilter(productID string, price float64) bson.M {
+	return bson.M{"items": bson.M{"$elemMatch": bson.M{
+		"product_id": productID,
+		"last_price": bson.M{"$gt": price},
+	}}}
+}
+
+func (r *mongoRepository) findOne(ctx context.Context, filter bson.M) (*model.Wishlist, error) {
+	var wishlist model.Wishlist
+	if err := r.collection.FindOne(ctx, filter).Decode(&wishlist); err != nil {
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *mongoRepository) findOneAndUpdate(ctx context.Context, filter, update bson.M, upsert bool) (*model.Wishlist, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(upsert).SetReturnDocument(options.After)
+
+	var wishlist model.Wishlist
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&wishlist); err != nil {
+		return nil, err
+	}
+	return &wishlist, nil
+}
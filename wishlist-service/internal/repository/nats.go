@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"shoeshop/wishlist-service/internal/model"
+)
+
+type EventPublisher interface {
+	PublishPriceDropped(event *model.PriceDroppedEvent) error
+	Close() error
+}
+
+type EventSubscriber interface {
+	SubscribeProductUpdated(handler func(product *model.ProductEvent)) error
+}
+
+type EventBus interface {
+	EventPublisher
+	EventSubscriber
+}
+
+type natsClient struct {
+	conn *nats.Conn
+}
+
+func NewNatsClient(url string) (EventBus, error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natsClient{
+		conn: nc,
+	}, nil
+}
+
+func (n *natsClient) PublishPriceDropped(event *model.PriceDroppedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return n.conn.Publish("wishlist.price_dropped", data)
+}
+
+func (n *natsClient) SubscribeProductUpdated(handler func(product *model.ProductEvent)) error {
+	_, err := n.conn.Subscribe("product.updated", func(msg *nats.Msg) {
+		var product model.ProductEvent
+		if err := json.Unmarshal(msg.Data, &product); err != nil {
+			log.Printf("failed to decode product.updated event: %v", err)
+			return
+		}
+		handler(&product)
+	})
+	return err
+}
+
+func (n *natsClient) Close() error {
+	n.conn.Close()
+	return nil
+}
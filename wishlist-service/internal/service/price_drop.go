@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+	"shoeshop/wishlist-service/internal/model"
+	"shoeshop/wishlist-service/internal/repository"
+)
+
+// StartPriceDropNotifications подписывается на product.updated и, когда цена
+// сохраненного товара опускается ниже последней известной списку, публикует
+// wishlist.price_dropped для email-service
+func (s *wishlistService) StartPriceDropNotifications(subscriber repository.EventSubscriber) error {
+	err := subscriber.SubscribeProductUpdated(func(product *model.ProductEvent) {
+		s.handleProductUpdated(context.Background(), product)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to product.updated: %w", err)
+	}
+
+	log.Println("Price drop notifications started")
+	return nil
+}
+
+func (s *wishlistService) handleProductUpdated(ctx context.Context, product *model.ProductEvent) {
+	if product.DeletedAt == nil {
+		s.notifyPriceDrops(ctx, product)
+	}
+
+	// Остальные списки просто запоминают новую цену: после роста цены
+	// следующее снижение снова попадет в уведомления
+	if err := s.repo.SyncProduct(ctx, product); err != nil {
+		fmt.Printf("failed to sync product %s in wishlists: %v\n", product.ID, err)
+	}
+}
+
+func (s *wishlistService) notifyPriceDrops(ctx context.Context, product *model.ProductEvent) {
+	wishlists, err := s.repo.ListPriceDrops(ctx, product.ID, product.Price)
+	if err != nil {
+		fmt.Printf("failed to get wishlists of product %s: %v\n", product.ID, err)
+		return
+	}
+
+	for _, wishlist := range wishlists {
+		// Снижение мог уже забрать другой обработчик
+		item, err := s.repo.ClaimPriceDrop(ctx, wishlist.UserID, product)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("failed to record price drop for user %s: %v\n", wishlist.UserID, err)
+			continue
+		}
+
+		// Письмо уходит на адрес из профиля пользователя
+		user, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: wishlist.UserID})
+		if err != nil {
+			fmt.Printf("failed to get user %s: %v\n", wishlist.UserID, err)
+			continue
+		}
+
+		event := &model.PriceDroppedEvent{
+			UserID:      wishlist.UserID,
+			Email:       user.GetUser().GetEmail(),
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Size:        item.FormatSize(sizing.DefaultSystem),
+			OldPrice:    item.LastPrice,
+			NewPrice:    product.Price,
+			At:          time.Now(),
+		}
+		if err := s.publisher.PublishPriceDropped(event); err != nil {
+			fmt.Printf("failed to publish price dropped event: %v\n", err)
+		}
+	}
+}
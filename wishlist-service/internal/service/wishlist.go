@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	pb "shoeshop/proto"
+	"shoeshop/sizing"
+	"shoeshop/wishlist-service/internal/model"
+	"shoeshop/wishlist-service/internal/repository"
+)
+
+// Длина токена ссылки на список в байтах до кодирования
+const shareTokenBytes = 16
+
+var (
+	ErrInvalidWishlist = errors.New("invalid wishlist request")
+	ErrNotInWishlist   = errors.New("product is not in the wishlist")
+	ErrUnavailable     = errors.New("product is not available")
+)
+
+type WishlistService interface {
+	AddItem(ctx context.Context, userID, productID, size string, system sizing.System) (*model.Wishlist, error)
+	RemoveItem(ctx context.Context, userID, productID string) (*model.Wishlist, error)
+	GetWishlist(ctx context.Context, userID string) (*model.Wishlist, error)
+	ShareWishlist(ctx context.Context, userID string, regenerate bool) (string, error)
+	GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error)
+	MoveToCart(ctx context.Context, userID, productID, size string, system sizing.System) (*pb.OrderItem, *model.Wishlist, error)
+	StartPriceDropNotifications(subscriber repository.EventSubscriber) error
+}
+
+type wishlistService struct {
+	repo          repository.WishlistRepository
+	publisher     repository.EventPublisher
+	productClient pb.ProductServiceClient
+	userClient    pb.UserServiceClient
+}
+
+func NewWishlistService(
+	repo repository.WishlistRepository,
+	publisher repository.EventPublisher,
+	productClient pb.ProductServiceClient,
+	userClient pb.UserServiceClient,
+) WishlistService {
+	return &wishlistService{
+		repo:          repo,
+		publisher:     publisher,
+		productClient: productClient,
+		userClient:    userClient,
+	}
+}
+
+// AddItem сохраняет товар в список пользователя. Желаемый размер
+// необязателен, но должен быть одним из размеров товара
+func (s *wishlistService) AddItem(ctx context.Context, userID, productID, size string, system sizing.System) (*model.Wishlist, error) {
+	if userID == "" || productID == "" {
+		return nil, fmt.Errorf("%w: user_id and product_id are required", ErrInvalidWishlist)
+	}
+
+	product, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.DeletedAt != "" {
+		return nil, ErrUnavailable
+	}
+
+	item := model.WishlistItem{
+		ProductID:  productID,
+		Name:       product.Name,
+		SizeOffset: sizing.Size(product.SizeOffset),
+		PriceAtAdd: product.Price,
+		LastPrice:  product.Price,
+	}
+	if size != "" {
+		value, err := resolveSize(size, system, product)
+		if err != nil {
+			return nil, err
+		}
+		item.Size = &value
+	}
+
+	wishlist, err := s.repo.AddItem(ctx, userID, item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+	return wishlist, nil
+}
+
+func (s *wishlistService) RemoveItem(ctx context.Context, userID, productID string) (*model.Wishlist, error) {
+	wishlist, err := s.repo.RemoveItem(ctx, userID, productID)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotInWishlist
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+	return wishlist, nil
+}
+
+// GetWishlist возвращает список пользователя; у нового пользователя он пуст
+func (s *wishlistService) GetWishlist(ctx context.Context, userID string) (*model.Wishlist, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidWishlist)
+	}
+
+	wishlist, err := s.repo.Get(ctx, userID)
+	if err == mongo.ErrNoDocuments {
+		return &model.Wishlist{UserID: userID, Items: []model.WishlistItem{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+	return wishlist, nil
+}
+
+// ShareWishlist возвращает токен ссылки на список, создавая его при первом
+// вызове. С regenerate токен заменяется и старые ссылки перестают работать
+func (s *wishlistService) ShareWishlist(ctx context.Context, userID string, regenerate bool) (string, error) {
+	wishlist, err := s.GetWishlist(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if wishlist.ShareToken != "" && !regenerate {
+		return wishlist.ShareToken, nil
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	wishlist, err = s.repo.SetShareToken(ctx, userID, token)
+	if err != nil {
+		return "", fmt.Errorf("failed to share wishlist: %w", err)
+	}
+	return wishlist.ShareToken, nil
+}
+
+func (s *wishlistService) GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: share_token is required", ErrInvalidWishlist)
+	}
+
+	wishlist, err := s.repo.GetByShareToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared wishlist: %w", err)
+	}
+	return wishlist, nil
+}
+
+// MoveToCart убирает товар из списка и возвращает позицию корзины по
+// текущей цене. Корзина хранится на клиенте, поэтому позицию добавляет он
+func (s *wishlistService) MoveToCart(ctx context.Context, userID, productID, size string, system sizing.System) (*pb.OrderItem, *model.Wishlist, error) {
+	wishlist, err := s.GetWishlist(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	item := wishlist.Item(productID)
+	if item == nil {
+		return nil, nil, ErrNotInWishlist
+	}
+
+	product, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if product.DeletedAt != "" {
+		return nil, nil, ErrUnavailable
+	}
+	if product.Stock <= 0 {
+		return nil, nil, fmt.Errorf("%w: out of stock", ErrUnavailable)
+	}
+
+	// Размер из запроса важнее желаемого; желаемый размер мог закончиться
+	table := sizing.WithOffset(sizing.Size(product.SizeOffset))
+	var value sizing.Size
+	switch {
+	case size != "":
+		value, err = resolveSize(size, system, product)
+		if err != nil {
+			return nil, nil, err
+		}
+	case item.Size != nil:
+		available, err := productSizes(product)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !slices.Contains(available, *item.Size) {
+			return nil, nil, fmt.Errorf("%w: size %s", ErrUnavailable, item.FormatSize(system))
+		}
+		value = *item.Size
+	default:
+		return nil, nil, fmt.Errorf("%w: size is required", ErrInvalidWishlist)
+	}
+
+	wishlist, err = s.RemoveItem(ctx, userID, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cartItem := &pb.OrderItem{
+		ProductId: productID,
+		Quantity:  1,
+		Price:     product.Price,
+		Size:      table.Format(value, system),
+	}
+	return cartItem, wishlist, nil
+}
+
+// getProduct запрашивает товар с размерами в сантиметрах
+func (s *wishlistService) getProduct(ctx context.Context, productID string) (*pb.Product, error) {
+	resp, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{
+		Id:         productID,
+		SizeSystem: string(sizing.CM),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product %s: %w", productID, err)
+	}
+	return resp.Product, nil
+}
+
+func resolveSize(size string, system sizing.System, product *pb.Product) (sizing.Size, error) {
+	available, err := productSizes(product)
+	if err != nil {
+		return 0, err
+	}
+	value, err := sizing.WithOffset(sizing.Size(product.SizeOffset)).Resolve(size, system, available)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidWishlist, err)
+	}
+	return value, nil
+}
+
+// productSizes разбирает размеры товара, запрошенного в сантиметрах;
+// сантиметры от бренда не зависят, поэтому подойдет любая таблица
+func productSizes(product *pb.Product) ([]sizing.Size, error) {
+	sizes, err := sizing.Default().ParseAll(product.Sizes, sizing.CM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sizes of product %s: %w", product.Id, err)
+	}
+	return sizes, nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}